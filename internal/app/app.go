@@ -3,8 +3,15 @@ package app
 import (
 	"fmt"
 	"github.com/NastyaAR/music_library/internal/config"
+	"github.com/NastyaAR/music_library/internal/delivery/graphql/v1/resolvers"
+	"github.com/NastyaAR/music_library/internal/delivery/grpc/v1/services"
 	"github.com/NastyaAR/music_library/internal/delivery/http/v1/handlers"
+	"github.com/NastyaAR/music_library/internal/domain"
+	"github.com/NastyaAR/music_library/internal/pkg/circuit_breaker"
 	pkg "github.com/NastyaAR/music_library/internal/pkg/logger"
+	"github.com/NastyaAR/music_library/internal/pkg/ratelimit"
+	"github.com/NastyaAR/music_library/internal/pkg/token"
+	"github.com/NastyaAR/music_library/internal/provider/song_info"
 	repo "github.com/NastyaAR/music_library/internal/repo/postgres"
 	"github.com/NastyaAR/music_library/internal/usecase"
 	"github.com/gin-gonic/gin"
@@ -25,8 +32,6 @@ func Run() {
 		panic(err)
 	}
 
-	fmt.Println(cfg)
-
 	logger, err := pkg.CreateLogger(cfg.LogFile, cfg.LogLevel)
 	if err != nil {
 		panic(err)
@@ -54,17 +59,149 @@ func Run() {
 	}
 
 	songRepo := repo.NewPostgresSongRepo(pool, logger)
+	artistRepo := repo.NewPostgresArtistRepo(pool, logger)
+	albumRepo := repo.NewPostgresAlbumRepo(pool, logger)
+	userRepo := repo.NewPostgresUserRepo(pool, logger)
+	apiKeyRepo := repo.NewPostgresAPIKeyRepo(pool, logger)
+	playlistRepo := repo.NewPostgresPlaylistRepo(pool, logger)
+
+	var infoProvider domain.SongInfoProvider
+	if cfg.SongInfo.URL != "" {
+		breaker := circuit_breaker.NewCircuitBreaker(cfg.SongInfo.BreakerThreshold,
+			time.Duration(cfg.SongInfo.BreakerCooldownSec)*time.Second)
+		infoProvider = song_info.NewHTTPSongInfoProvider(cfg.SongInfo.URL,
+			time.Duration(cfg.SongInfo.TimeoutSec)*time.Second, cfg.SongInfo.Retries,
+			time.Duration(cfg.SongInfo.RetryDelayMs)*time.Millisecond, breaker, logger)
+	}
+
 	validate := validator.New()
-	songUsecase := usecase.NewSongUsecase(songRepo, validate, logger)
+	songUsecase := usecase.NewSongUsecase(songRepo, infoProvider,
+		domain.SearchLanguage(cfg.Search.DefaultLanguage), validate, logger)
+	artistUsecase := usecase.NewArtistUsecase(artistRepo, validate, logger)
+	albumUsecase := usecase.NewAlbumUsecase(albumRepo, validate, logger)
+
+	if cfg.Auth.JWTSecret == "" {
+		log.Fatal("JWT_SECRET is not set")
+	}
+	tokens := token.NewManager(cfg.Auth.JWTSecret, cfg.Auth.Issuer,
+		time.Duration(cfg.Auth.AccessTTLMin)*time.Minute)
+	userUsecase := usecase.NewUserUsecase(userRepo, tokens,
+		time.Duration(cfg.Auth.RefreshTTLHours)*time.Hour, logger)
+	if cfg.Auth.AdminUsername != "" {
+		_, err = userUsecase.SeedAdmin(ctx, cfg.Auth.AdminUsername, cfg.Auth.AdminPasswordHash)
+		if err != nil {
+			log.Fatalf("can't seed admin: %v", err.Error())
+		}
+	}
+	apiKeyUsecase := usecase.NewAPIKeyUsecase(apiKeyRepo, cfg.APIKeys.DefaultKeyRateLimit,
+		cfg.APIKeys.MaxKeyRateLimit, logger)
+	playlistUsecase := usecase.NewPlaylistUsecase(playlistRepo, logger)
+	limits := newRateLimitStore(ctx, cfg.RateLimit, logger)
+	authFailures := ratelimit.Budget{
+		Limit:  cfg.RateLimit.AuthFailureLimit,
+		Period: time.Duration(cfg.RateLimit.AuthFailurePeriodSec) * time.Second,
+	}
+
+	songHandler := handlers.NewSongHandler(songUsecase, int64(cfg.Import.MaxBodyMB)<<20, logger)
+	artistHandler := handlers.NewArtistHandler(artistUsecase, logger)
+	albumHandler := handlers.NewAlbumHandler(albumUsecase, logger)
+	userHandler := handlers.NewUserHandler(userUsecase, logger)
+	apiKeyHandler := handlers.NewAPIKeyHandler(apiKeyUsecase, logger)
+	playlistHandler := handlers.NewPlaylistHandler(playlistUsecase, logger)
+	songService := services.NewSongService(songUsecase, logger)
+	go serveGRPC(services.NewServer(songService, userUsecase, apiKeyUsecase, limits,
+		authFailures, logger), cfg.GRPCPort, logger)
+
+	resolver := resolvers.NewResolver(songUsecase, artistUsecase, albumUsecase, logger)
+	schema := resolvers.NewSchema(resolver)
+
+	go purgeTrash(songUsecase, time.Duration(cfg.Trash.RetentionHours)*time.Hour,
+		time.Duration(cfg.Trash.PurgeIntervalMin)*time.Minute, logger)
 
-	songHandler := handlers.NewSongHandler(songUsecase, logger)
 	router := gin.Default()
-	router.POST("/songs", songHandler.Create)
-	router.DELETE("/songs", songHandler.Delete)
-	router.PATCH("/songs", songHandler.Update)
+	// handlers pass *gin.Context on as context.Context, the fallback makes
+	// it see the identity and cancellation of the request
+	router.ContextWithFallback = true
+	// gin trusts X-Forwarded-For from anyone by default, rate limits would
+	// then count whatever ip the client makes up
+	err = router.SetTrustedProxies(cfg.RateLimit.TrustedProxies)
+	if err != nil {
+		log.Fatalf("bad trusted proxies: %v", err.Error())
+	}
+	authMiddleware := handlers.NewAuthMiddleware(userUsecase, apiKeyUsecase, limits, authFailures, logger)
+	router.Use(authMiddleware.Authenticate(), handlers.RateLimit(limits, ratelimit.Budget{
+		Limit:  cfg.RateLimit.DefaultLimit,
+		Period: time.Duration(cfg.RateLimit.DefaultPeriodSec) * time.Second,
+	}, routeBudgets(cfg.RateLimit), logger))
+	auth := authMiddleware.Required()
+
+	router.POST("/auth/register", userHandler.Register)
+	router.POST("/auth/login", authMiddleware.SignIn(), userHandler.Login)
+	router.POST("/auth/refresh", authMiddleware.SignIn(), userHandler.Refresh)
+	router.POST("/auth/logout", userHandler.Logout)
+	router.GET("/auth/me", auth, userHandler.Me)
+	router.GET("/users", auth, userHandler.GetAll)
+	router.PUT("/users/:id/role", auth, userHandler.SetRole)
+
+	router.POST("/api-keys", auth, apiKeyHandler.Create)
+	router.GET("/api-keys", auth, apiKeyHandler.GetAll)
+	router.DELETE("/api-keys/:id", auth, apiKeyHandler.Revoke)
+
+	router.POST("/songs", auth, songHandler.Create)
+	router.DELETE("/songs", auth, songHandler.Delete)
+	router.PATCH("/songs", auth, songHandler.Update)
 	router.GET("/songs", songHandler.GetSongs)
 	router.GET("/info", songHandler.Get)
 	router.GET("/songs/couplet", songHandler.GetCouplet)
+	router.GET("/songs/search", songHandler.Search)
+	router.POST("/songs:action", auth, handlers.SongsAction(map[string]gin.HandlerFunc{
+		":import": songHandler.Import,
+	}))
+	router.GET("/songs:action", handlers.SongsAction(map[string]gin.HandlerFunc{
+		":export": songHandler.Export,
+	}))
+	router.GET("/songs/imports/:id", auth, songHandler.GetImportJob)
+	router.GET("/songs/:id", songHandler.GetByID)
+	router.PATCH("/songs/:id", auth, songHandler.UpdateByID)
+	router.DELETE("/songs/:id", auth, songHandler.DeleteByID)
+	router.GET("/songs/:id/couplets/:n", songHandler.GetCoupletByID)
+	router.GET("/songs/:id/sections", songHandler.GetSections)
+	router.GET("/songs/:id/sections/:type/:n", songHandler.GetSection)
+	router.PUT("/songs/:id/lyrics/lrc", auth, songHandler.ImportLRC)
+	router.GET("/songs/:id/lyrics/lrc", songHandler.ExportLRC)
+	router.GET("/songs/:id/lyrics/at", songHandler.GetLyricsAt)
+	router.GET("/songs/:id/revisions", songHandler.GetRevisions)
+	router.GET("/songs/:id/revisions/diff", songHandler.DiffRevisions)
+	router.GET("/songs/:id/revisions/:rev", songHandler.GetRevision)
+	router.POST("/songs/:id/revisions/:rev/restore", auth, songHandler.RestoreRevision)
+
+	router.GET("/trash", auth, songHandler.GetTrash)
+	router.POST("/trash/:id/restore", auth, songHandler.RestoreFromTrash)
+
+	router.POST("/artists", auth, artistHandler.Create)
+	router.GET("/artists", artistHandler.GetAll)
+	router.GET("/artists/:id", artistHandler.Get)
+	router.PATCH("/artists/:id", auth, artistHandler.Update)
+	router.DELETE("/artists/:id", auth, artistHandler.Delete)
+	router.GET("/artists/:id/songs", artistHandler.GetSongs)
+
+	router.POST("/albums", auth, albumHandler.Create)
+	router.GET("/albums", albumHandler.GetAll)
+	router.GET("/albums/:id", albumHandler.Get)
+	router.PATCH("/albums/:id", auth, albumHandler.Update)
+	router.DELETE("/albums/:id", auth, albumHandler.Delete)
+	router.GET("/albums/:id/tracks", albumHandler.GetTracks)
+
+	router.POST("/playlists", auth, playlistHandler.Create)
+	router.GET("/playlists", auth, playlistHandler.GetAll)
+	router.GET("/playlists/:id", playlistHandler.Get)
+	router.PATCH("/playlists/:id", auth, playlistHandler.Update)
+	router.DELETE("/playlists/:id", auth, playlistHandler.Delete)
+	router.POST("/playlists/:id/items", auth, playlistHandler.AddItem)
+	router.PATCH("/playlists/:id/items/:item", auth, playlistHandler.MoveItem)
+	router.DELETE("/playlists/:id/items/:item", auth, playlistHandler.RemoveItem)
+
+	router.POST("/graphql", resolvers.Handler(schema, resolver, logger))
 
 	router.Run(":8080")
 }
@@ -0,0 +1,29 @@
+package app
+
+import (
+	"fmt"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"net"
+)
+
+// serveGRPC runs the gRPC API next to the HTTP one, the service is
+// disabled when the port is not positive.
+func serveGRPC(server *grpc.Server, port int, lg *zap.Logger) {
+	if port <= 0 {
+		lg.Info("grpc server disabled")
+		return
+	}
+
+	listener, err := net.Listen("tcp", fmt.Sprintf(":%d", port))
+	if err != nil {
+		lg.Error("grpc listen error", zap.Error(err))
+		return
+	}
+
+	lg.Info("grpc server started", zap.Int("port", port))
+	err = server.Serve(listener)
+	if err != nil {
+		lg.Error("grpc serve error", zap.Error(err))
+	}
+}
@@ -0,0 +1,48 @@
+package app
+
+import (
+	"context"
+	"github.com/NastyaAR/music_library/internal/config"
+	"github.com/NastyaAR/music_library/internal/pkg/ratelimit"
+	"github.com/redis/go-redis/v9"
+	"go.uber.org/zap"
+	"log"
+	"time"
+)
+
+// newRateLimitStore keeps buckets in memory unless redis is configured,
+// then all instances of the app share them.
+func newRateLimitStore(ctx context.Context, cfg config.RateLimit, lg *zap.Logger) ratelimit.Store {
+	switch cfg.Backend {
+	case "", "memory":
+		lg.Info("rate limit store: memory")
+		return ratelimit.NewMemoryStore()
+	case "redis":
+		client := redis.NewClient(&redis.Options{
+			Addr:     cfg.RedisAddr,
+			Password: cfg.RedisPassword,
+			DB:       cfg.RedisDB,
+		})
+		err := client.Ping(ctx).Err()
+		if err != nil {
+			// requests pass while redis is down, see the middleware
+			lg.Warn("rate limit store: redis ping error", zap.Error(err))
+		}
+		lg.Info("rate limit store: redis", zap.String("addr", cfg.RedisAddr))
+		return ratelimit.NewRedisStore(client, "music_library:ratelimit:")
+	default:
+		log.Fatalf("unknown rate limit backend: %s", cfg.Backend)
+		return nil
+	}
+}
+
+func routeBudgets(cfg config.RateLimit) map[string]ratelimit.Budget {
+	budgets := make(map[string]ratelimit.Budget, len(cfg.Routes))
+	for _, route := range cfg.Routes {
+		budgets[route.Route] = ratelimit.Budget{
+			Limit:  route.Limit,
+			Period: time.Duration(route.PeriodSec) * time.Second,
+		}
+	}
+	return budgets
+}
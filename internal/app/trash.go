@@ -0,0 +1,29 @@
+package app
+
+import (
+	"context"
+	"github.com/NastyaAR/music_library/internal/domain"
+	"go.uber.org/zap"
+	"time"
+)
+
+// purgeTrash periodically removes songs kept in the trash longer than
+// retention, a non-positive retention disables purging.
+func purgeTrash(songUsecase domain.SongUsecase, retention time.Duration,
+	interval time.Duration, lg *zap.Logger) {
+	if retention <= 0 || interval <= 0 {
+		lg.Info("trash purge disabled")
+		return
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		_, err := songUsecase.PurgeTrash(context.Background(), retention)
+		if err != nil {
+			lg.Warn("trash purge error", zap.Error(err))
+		}
+		<-ticker.C
+	}
+}
@@ -6,8 +6,16 @@ import (
 )
 
 type Config struct {
-	Logger `yaml:"logger"`
-	Db     `yaml:"postgres"`
+	Logger    `yaml:"logger"`
+	Db        `yaml:"postgres"`
+	SongInfo  `yaml:"song_info"`
+	Search    `yaml:"search"`
+	Trash     `yaml:"trash"`
+	Import    `yaml:"import"`
+	GRPC      `yaml:"grpc"`
+	Auth      `yaml:"auth"`
+	APIKeys   `yaml:"api_keys"`
+	RateLimit `yaml:"rate_limit"`
 }
 
 type Logger struct {
@@ -24,6 +32,74 @@ type Db struct {
 	DbTimeoutSec int    `yaml:"db_timeout_sec"`
 }
 
+type SongInfo struct {
+	URL                string `yaml:"url" env:"SONG_INFO_URL"`
+	TimeoutSec         int    `yaml:"timeout_sec"`
+	Retries            int    `yaml:"retries"`
+	RetryDelayMs       int    `yaml:"retry_delay_ms"`
+	BreakerThreshold   int    `yaml:"breaker_threshold"`
+	BreakerCooldownSec int    `yaml:"breaker_cooldown_sec"`
+}
+
+type Search struct {
+	DefaultLanguage string `yaml:"default_language" env-default:"russian"`
+}
+
+type Trash struct {
+	RetentionHours   int `yaml:"retention_hours" env:"TRASH_RETENTION_HOURS" env-default:"720"`
+	PurgeIntervalMin int `yaml:"purge_interval_min" env-default:"60"`
+}
+
+type Import struct {
+	MaxBodyMB int `yaml:"max_body_mb" env:"IMPORT_MAX_BODY_MB" env-default:"32"`
+}
+
+type GRPC struct {
+	GRPCPort int `yaml:"port" env:"GRPC_PORT" env-default:"9090"`
+}
+
+type Auth struct {
+	JWTSecret       string `yaml:"jwt_secret" env:"JWT_SECRET"`
+	Issuer          string `yaml:"issuer" env-default:"music_library"`
+	AccessTTLMin    int    `yaml:"access_ttl_min" env-default:"15"`
+	RefreshTTLHours int    `yaml:"refresh_ttl_hours" env-default:"720"`
+	// AdminUsername is made an admin at startup with the bcrypt
+	// AdminPasswordHash, registration never gives the admin role
+	AdminUsername     string `yaml:"admin_username" env:"ADMIN_USERNAME"`
+	AdminPasswordHash string `yaml:"admin_password_hash" env:"ADMIN_PASSWORD_HASH"`
+}
+
+type APIKeys struct {
+	DefaultKeyRateLimit int `yaml:"default_rate_limit" env-default:"600"`
+	MaxKeyRateLimit     int `yaml:"max_rate_limit" env-default:"6000"`
+}
+
+type RateLimit struct {
+	// Backend is memory or redis
+	Backend          string       `yaml:"backend" env:"RATE_LIMIT_BACKEND" env-default:"memory"`
+	RedisAddr        string       `yaml:"redis_addr" env:"REDIS_ADDR"`
+	RedisPassword    string       `yaml:"redis_password" env:"REDIS_PASSWORD"`
+	RedisDB          int          `yaml:"redis_db"`
+	DefaultLimit     int          `yaml:"default_limit" env-default:"300"`
+	DefaultPeriodSec int          `yaml:"default_period_sec" env-default:"60"`
+	Routes           []RouteLimit `yaml:"routes"`
+	// TrustedProxies may set X-Forwarded-For, without them the client ip
+	// is the address of the connection
+	TrustedProxies []string `yaml:"trusted_proxies" env:"TRUSTED_PROXIES" env-separator:","`
+	// AuthFailureLimit is how many bad tokens or api keys one ip may send
+	// in AuthFailurePeriodSec
+	AuthFailureLimit     int `yaml:"auth_failure_limit" env-default:"10"`
+	AuthFailurePeriodSec int `yaml:"auth_failure_period_sec" env-default:"60"`
+}
+
+// RouteLimit is the budget of a route like "GET /songs", limit 0 turns
+// limiting off for it.
+type RouteLimit struct {
+	Route     string `yaml:"route"`
+	Limit     int    `yaml:"limit"`
+	PeriodSec int    `yaml:"period_sec"`
+}
+
 func ReadConfig(configPath string) (*Config, error) {
 	cfg := Config{}
 
@@ -0,0 +1,35 @@
+package resolvers
+
+import (
+	"github.com/NastyaAR/music_library/internal/domain"
+	"github.com/NastyaAR/music_library/internal/pkg/error_handler"
+	"github.com/gin-gonic/gin"
+	"github.com/graph-gophers/graphql-go"
+	"go.uber.org/zap"
+	"net/http"
+)
+
+type graphqlRequest struct {
+	Query         string                 `json:"query"`
+	OperationName string                 `json:"operationName"`
+	Variables     map[string]interface{} `json:"variables"`
+}
+
+// Handler serves GraphQL over POST with a JSON body. Every request gets
+// its own loaders.
+func Handler(schema *graphql.Schema, r *Resolver, lg *zap.Logger) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		var req graphqlRequest
+		err := ctx.ShouldBindJSON(&req)
+		if err != nil || req.Query == "" {
+			lg.Warn("graphql handler: bad request", zap.Error(err))
+			error_handler.NewError(ctx, domain.ErrBadRequestBody)
+			return
+		}
+
+		reqCtx := r.WithLoaders(ctx.Request.Context())
+		resp := schema.Exec(reqCtx, req.Query, req.OperationName, req.Variables)
+
+		ctx.JSON(http.StatusOK, resp)
+	}
+}
@@ -0,0 +1,131 @@
+package resolvers
+
+import (
+	"context"
+	"github.com/NastyaAR/music_library/internal/domain"
+	"sync"
+	"time"
+)
+
+// loaderWait is how long a loader collects keys before fetching them.
+const loaderWait = time.Millisecond
+
+type loaderEntry[V any] struct {
+	value V
+	found bool
+	err   error
+}
+
+type loaderBatch[K comparable] struct {
+	keys  []K
+	timer *time.Timer
+	done  chan struct{}
+}
+
+// loader batches loads of one kind for a single request, like a
+// dataloader. Keys asked for while a batch is collecting, and keys
+// announced with Expect, are fetched together; results are kept for the
+// rest of the request.
+type loader[K comparable, V any] struct {
+	ctx   context.Context
+	fetch func(ctx context.Context, keys []K) (map[K]V, error)
+
+	mu      sync.Mutex
+	open    *loaderBatch[K]
+	batches map[K]*loaderBatch[K]
+	cache   map[K]loaderEntry[V]
+}
+
+func newLoader[K comparable, V any](ctx context.Context,
+	fetch func(ctx context.Context, keys []K) (map[K]V, error)) *loader[K, V] {
+	return &loader[K, V]{
+		ctx:     ctx,
+		fetch:   fetch,
+		batches: make(map[K]*loaderBatch[K]),
+		cache:   make(map[K]loaderEntry[V]),
+	}
+}
+
+// add puts key into the open batch, l.mu must be held.
+func (l *loader[K, V]) add(key K) {
+	if _, ok := l.cache[key]; ok {
+		return
+	}
+	if _, ok := l.batches[key]; ok {
+		return
+	}
+
+	if l.open == nil {
+		b := &loaderBatch[K]{done: make(chan struct{})}
+		b.timer = time.AfterFunc(loaderWait, func() { l.dispatch(b) })
+		l.open = b
+	}
+
+	l.open.keys = append(l.open.keys, key)
+	l.batches[key] = l.open
+
+	if len(l.open.keys) >= domain.MaxBatchIDs {
+		b := l.open
+		l.open = nil
+		if b.timer.Stop() {
+			go l.dispatch(b)
+		}
+	}
+}
+
+func (l *loader[K, V]) dispatch(b *loaderBatch[K]) {
+	l.mu.Lock()
+	if l.open == b {
+		l.open = nil
+	}
+	l.mu.Unlock()
+
+	values, err := l.fetch(l.ctx, b.keys)
+
+	l.mu.Lock()
+	for _, key := range b.keys {
+		value, found := values[key]
+		l.cache[key] = loaderEntry[V]{value: value, found: found, err: err}
+		delete(l.batches, key)
+	}
+	l.mu.Unlock()
+
+	close(b.done)
+}
+
+// Expect announces keys that are about to be loaded so that they go
+// into one batch.
+func (l *loader[K, V]) Expect(keys ...K) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	for _, key := range keys {
+		l.add(key)
+	}
+}
+
+// Load returns the value of key, found is false when the fetch did not
+// return it.
+func (l *loader[K, V]) Load(ctx context.Context, key K) (V, bool, error) {
+	l.mu.Lock()
+	if entry, ok := l.cache[key]; ok {
+		l.mu.Unlock()
+		return entry.value, entry.found, entry.err
+	}
+	l.add(key)
+	b := l.batches[key]
+	l.mu.Unlock()
+
+	var zero V
+	select {
+	case <-b.done:
+	case <-ctx.Done():
+		return zero, false, ctx.Err()
+	}
+
+	l.mu.Lock()
+	entry := l.cache[key]
+	l.mu.Unlock()
+
+	return entry.value, entry.found, entry.err
+}
@@ -0,0 +1,98 @@
+package resolvers
+
+import (
+	"context"
+	"github.com/NastyaAR/music_library/internal/domain"
+)
+
+type loadersKey struct{}
+
+type artistSongsKey struct {
+	artistID int64
+	limit    int
+}
+
+type coupletKey struct {
+	songID int64
+	n      int
+}
+
+// loaders are created for every request, so their caches never outlive
+// it.
+type loaders struct {
+	artists     *loader[int64, domain.Artist]
+	albums      *loader[int64, domain.Album]
+	artistSongs *loader[artistSongsKey, []domain.Song]
+	couplets    *loader[coupletKey, domain.Couplet]
+}
+
+func (r *Resolver) WithLoaders(ctx context.Context) context.Context {
+	l := &loaders{
+		artists: newLoader(ctx, func(ctx context.Context, ids []int64) (map[int64]domain.Artist, error) {
+			artists, err := r.artistUsecase.GetByIDs(ctx, ids)
+			if err != nil {
+				return nil, err
+			}
+
+			byID := make(map[int64]domain.Artist, len(artists))
+			for _, artist := range artists {
+				byID[artist.ID] = artist
+			}
+			return byID, nil
+		}),
+		albums: newLoader(ctx, func(ctx context.Context, ids []int64) (map[int64]domain.Album, error) {
+			albums, err := r.albumUsecase.GetByIDs(ctx, ids)
+			if err != nil {
+				return nil, err
+			}
+
+			byID := make(map[int64]domain.Album, len(albums))
+			for _, album := range albums {
+				byID[album.ID] = album
+			}
+			return byID, nil
+		}),
+		artistSongs: newLoader(ctx, func(ctx context.Context, keys []artistSongsKey) (map[artistSongsKey][]domain.Song, error) {
+			byLimit := make(map[int][]int64)
+			for _, key := range keys {
+				byLimit[key.limit] = append(byLimit[key.limit], key.artistID)
+			}
+
+			songs := make(map[artistSongsKey][]domain.Song, len(keys))
+			for limit, ids := range byLimit {
+				byArtist, err := r.artistUsecase.GetSongsByArtists(ctx, ids, limit)
+				if err != nil {
+					return nil, err
+				}
+				for _, id := range ids {
+					songs[artistSongsKey{artistID: id, limit: limit}] = byArtist[id]
+				}
+			}
+			return songs, nil
+		}),
+		couplets: newLoader(ctx, func(ctx context.Context, keys []coupletKey) (map[coupletKey]domain.Couplet, error) {
+			byN := make(map[int][]int64)
+			for _, key := range keys {
+				byN[key.n] = append(byN[key.n], key.songID)
+			}
+
+			couplets := make(map[coupletKey]domain.Couplet, len(keys))
+			for n, ids := range byN {
+				bySong, err := r.songUsecase.GetCoupletsByIDs(ctx, ids, n)
+				if err != nil {
+					return nil, err
+				}
+				for id, couplet := range bySong {
+					couplets[coupletKey{songID: id, n: n}] = couplet
+				}
+			}
+			return couplets, nil
+		}),
+	}
+
+	return context.WithValue(ctx, loadersKey{}, l)
+}
+
+func loadersFrom(ctx context.Context) *loaders {
+	return ctx.Value(loadersKey{}).(*loaders)
+}
@@ -0,0 +1,189 @@
+package resolvers
+
+import (
+	"context"
+	"github.com/NastyaAR/music_library/internal/domain"
+	"github.com/NastyaAR/music_library/internal/pkg/date_validate"
+	"github.com/graph-gophers/graphql-go"
+	"time"
+)
+
+type createSongInput struct {
+	Group       string
+	Name        string
+	ReleaseDate *string
+	Text        *string
+	Link        *string
+	AlbumID     *graphql.ID
+	TrackNumber *int32
+}
+
+func valueOf[T any](value *T) T {
+	if value == nil {
+		var zero T
+		return zero
+	}
+	return *value
+}
+
+// requireIdentity keeps mutations for signed in users, queries stay public.
+func requireIdentity(ctx context.Context) error {
+	if _, ok := domain.IdentityFrom(ctx); !ok {
+		return domain.ErrUnauthorized
+	}
+	return nil
+}
+
+func (r *Resolver) CreateSong(ctx context.Context, args struct{ Input createSongInput }) (*songResolver, error) {
+	if err := requireIdentity(ctx); err != nil {
+		return nil, r.fail("create song", err)
+	}
+
+	input := args.Input
+
+	date, err := parseOptionalDate(input.ReleaseDate)
+	if err != nil {
+		return nil, r.fail("create song", err)
+	}
+
+	albumID, err := parseOptionalID(input.AlbumID)
+	if err != nil {
+		return nil, r.fail("create song", err)
+	}
+
+	song := domain.Song{
+		Group:       input.Group,
+		Name:        input.Name,
+		ReleaseDate: date,
+		Text:        valueOf(input.Text),
+		Link:        valueOf(input.Link),
+		AlbumID:     albumID,
+		TrackNumber: int(valueOf(input.TrackNumber)),
+	}
+
+	created, err := r.songUsecase.Create(ctx, &song)
+	if err != nil {
+		return nil, r.fail("create song", err)
+	}
+
+	return r.songList(ctx, []domain.Song{created})[0], nil
+}
+
+type updateSongInput struct {
+	Group       *string
+	Name        *string
+	ReleaseDate *string
+	Text        *string
+	Link        *string
+	AlbumID     *graphql.ID
+	TrackNumber *int32
+	Clear       *[]string
+}
+
+func patchField[T any](value *T) domain.PatchField[T] {
+	return domain.PatchField[T]{Set: value != nil, Value: value}
+}
+
+// toPatch builds a patch of the given fields, GraphQL does not tell an
+// absent field from null, so fields are reset through clear.
+func (u *updateSongInput) toPatch() (domain.SongPatch, error) {
+	patch := domain.SongPatch{
+		Group: patchField(u.Group),
+		Name:  patchField(u.Name),
+		Text:  patchField(u.Text),
+		Link:  patchField(u.Link),
+	}
+
+	if u.ReleaseDate != nil {
+		date, err := date_validate.ParseDate(*u.ReleaseDate)
+		if err != nil {
+			return domain.SongPatch{}, err
+		}
+		patch.ReleaseDate = patchField(&date)
+	}
+
+	if u.AlbumID != nil {
+		albumID, err := parseID(*u.AlbumID)
+		if err != nil {
+			return domain.SongPatch{}, err
+		}
+		patch.AlbumID = patchField(&albumID)
+	}
+
+	if u.TrackNumber != nil {
+		track := int(*u.TrackNumber)
+		patch.TrackNumber = patchField(&track)
+	}
+
+	for _, field := range valueOf(u.Clear) {
+		switch field {
+		case "releaseDate":
+			patch.ReleaseDate = domain.PatchField[time.Time]{Set: true}
+		case "text":
+			patch.Text = domain.PatchField[string]{Set: true}
+		case "link":
+			patch.Link = domain.PatchField[string]{Set: true}
+		case "albumId":
+			patch.AlbumID = domain.PatchField[int64]{Set: true}
+		case "trackNumber":
+			patch.TrackNumber = domain.PatchField[int]{Set: true}
+		default:
+			return domain.SongPatch{}, domain.ErrBadPatch
+		}
+	}
+
+	return patch, nil
+}
+
+type updateSongArgs struct {
+	ID      graphql.ID
+	Input   updateSongInput
+	Version *int32
+}
+
+func (r *Resolver) UpdateSong(ctx context.Context, args updateSongArgs) (*songResolver, error) {
+	if err := requireIdentity(ctx); err != nil {
+		return nil, r.fail("update song", err)
+	}
+
+	id, err := parseID(args.ID)
+	if err != nil {
+		return nil, r.fail("update song", err)
+	}
+
+	patch, err := args.Input.toPatch()
+	if err != nil {
+		return nil, r.fail("update song", err)
+	}
+	patch.Version = int(valueOf(args.Version))
+
+	updated, err := r.songUsecase.UpdateByID(ctx, id, &patch)
+	if err != nil {
+		return nil, r.fail("update song", err)
+	}
+
+	return r.songList(ctx, []domain.Song{updated})[0], nil
+}
+
+type deleteSongArgs struct {
+	ID      graphql.ID
+	Version *int32
+}
+
+func (r *Resolver) DeleteSong(ctx context.Context, args deleteSongArgs) (bool, error) {
+	if err := requireIdentity(ctx); err != nil {
+		return false, r.fail("delete song", err)
+	}
+
+	id, err := parseID(args.ID)
+	if err != nil {
+		return false, r.fail("delete song", err)
+	}
+
+	err = r.songUsecase.DeleteByID(ctx, id, int(valueOf(args.Version)))
+	if err != nil {
+		return false, r.fail("delete song", err)
+	}
+
+	return true, nil
+}
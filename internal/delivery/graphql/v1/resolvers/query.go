@@ -0,0 +1,294 @@
+package resolvers
+
+import (
+	"context"
+	"errors"
+	"github.com/NastyaAR/music_library/internal/domain"
+	"github.com/NastyaAR/music_library/internal/pkg/date_validate"
+	"github.com/graph-gophers/graphql-go"
+	"time"
+)
+
+type songArgs struct {
+	ID    *graphql.ID
+	Group *string
+	Name  *string
+}
+
+func (r *Resolver) Song(ctx context.Context, args songArgs) (*songResolver, error) {
+	var song domain.Song
+	var err error
+
+	switch {
+	case args.ID != nil:
+		var id int64
+		id, err = parseID(*args.ID)
+		if err != nil {
+			return nil, r.fail("song", err)
+		}
+		song, err = r.songUsecase.GetByID(ctx, id)
+	case args.Group != nil && args.Name != nil:
+		song, err = r.songUsecase.Get(ctx, *args.Group, *args.Name)
+	default:
+		return nil, r.fail("song", domain.ErrQueryParams)
+	}
+
+	if errors.Is(err, domain.ErrSongNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, r.fail("song", err)
+	}
+
+	return r.songList(ctx, []domain.Song{song})[0], nil
+}
+
+type stringFilterInput struct {
+	Value string
+	Match *string
+}
+
+func (f *stringFilterInput) toDomain() domain.StringFilter {
+	if f == nil {
+		return domain.StringFilter{}
+	}
+
+	filter := domain.StringFilter{Value: f.Value}
+	if f.Match != nil {
+		filter.Match = domain.MatchMode(*f.Match)
+	}
+	return filter
+}
+
+type songFilterInput struct {
+	Group        *stringFilterInput
+	Name         *stringFilterInput
+	Text         *stringFilterInput
+	Link         *stringFilterInput
+	ReleaseDate  *string
+	ReleasedFrom *string
+	ReleasedTo   *string
+	Year         *int32
+	Decade       *int32
+	AlbumID      *graphql.ID
+}
+
+func parseOptionalDate(date *string) (time.Time, error) {
+	if date == nil || *date == "" {
+		return time.Time{}, nil
+	}
+	return date_validate.ParseDate(*date)
+}
+
+func (f *songFilterInput) toDomain() (domain.SongFilter, error) {
+	if f == nil {
+		return domain.SongFilter{}, nil
+	}
+
+	filter := domain.SongFilter{
+		Group: f.Group.toDomain(),
+		Name:  f.Name.toDomain(),
+		Text:  f.Text.toDomain(),
+		Link:  f.Link.toDomain(),
+	}
+	if f.Year != nil {
+		filter.Year = int(*f.Year)
+	}
+	if f.Decade != nil {
+		filter.Decade = int(*f.Decade)
+	}
+
+	var err error
+	filter.AlbumID, err = parseOptionalID(f.AlbumID)
+	if err != nil {
+		return domain.SongFilter{}, err
+	}
+	filter.ReleaseDate, err = parseOptionalDate(f.ReleaseDate)
+	if err != nil {
+		return domain.SongFilter{}, err
+	}
+	filter.ReleasedFrom, err = parseOptionalDate(f.ReleasedFrom)
+	if err != nil {
+		return domain.SongFilter{}, err
+	}
+	filter.ReleasedTo, err = parseOptionalDate(f.ReleasedTo)
+	if err != nil {
+		return domain.SongFilter{}, err
+	}
+
+	return filter, nil
+}
+
+type songsArgs struct {
+	Filter *songFilterInput
+	Sort   *string
+	First  int32
+	Cursor *string
+	Page   *int32
+}
+
+func (r *Resolver) Songs(ctx context.Context, args songsArgs) (*songConnectionResolver, error) {
+	filter, err := args.Filter.toDomain()
+	if err != nil {
+		return nil, r.fail("songs", err)
+	}
+	if args.Sort != nil {
+		filter.Sort = domain.ParseSort(*args.Sort)
+	}
+
+	page := domain.Pagination{Limit: int(args.First)}
+	if args.Cursor != nil {
+		page.Cursor = *args.Cursor
+	}
+	if args.Page != nil {
+		if *args.Page < 1 {
+			return nil, r.fail("songs", domain.ErrBadOffset)
+		}
+		page.Page = int(*args.Page)
+	}
+
+	result, err := r.songUsecase.GetSongs(ctx, &filter, &page)
+	if err != nil {
+		return nil, r.fail("songs", err)
+	}
+
+	return &songConnectionResolver{
+		songs: r.songList(ctx, result.Songs),
+		page:  result,
+	}, nil
+}
+
+// SongsByIds keeps the order of ids, songs that are not found are null.
+func (r *Resolver) SongsByIds(ctx context.Context, args struct{ IDs []graphql.ID }) ([]*songResolver, error) {
+	ids := make([]int64, 0, len(args.IDs))
+	for _, id := range args.IDs {
+		parsed, err := parseID(id)
+		if err != nil {
+			return nil, r.fail("songs by ids", err)
+		}
+		ids = append(ids, parsed)
+	}
+
+	songs, err := r.songUsecase.GetByIDs(ctx, ids)
+	if err != nil {
+		return nil, r.fail("songs by ids", err)
+	}
+
+	byID := make(map[int64]*songResolver, len(songs))
+	for _, song := range r.songList(ctx, songs) {
+		byID[song.song.ID] = song
+	}
+
+	ordered := make([]*songResolver, 0, len(ids))
+	for _, id := range ids {
+		ordered = append(ordered, byID[id])
+	}
+
+	return ordered, nil
+}
+
+type coupletArgs struct {
+	SongID graphql.ID
+	N      int32
+}
+
+func (r *Resolver) Couplet(ctx context.Context, args coupletArgs) (*coupletResolver, error) {
+	id, err := parseID(args.SongID)
+	if err != nil {
+		return nil, r.fail("couplet", err)
+	}
+
+	couplet, err := r.songUsecase.GetCoupletByID(ctx, id, int(args.N))
+	if errors.Is(err, domain.ErrSongNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, r.fail("couplet", err)
+	}
+
+	return &coupletResolver{couplet: couplet}, nil
+}
+
+type searchArgs struct {
+	Query    string
+	Language *string
+	Limit    int32
+	Offset   int32
+}
+
+func (r *Resolver) Search(ctx context.Context, args searchArgs) ([]*searchResultResolver, error) {
+	var lang domain.SearchLanguage
+	if args.Language != nil {
+		lang = domain.SearchLanguage(*args.Language)
+	}
+
+	results, err := r.songUsecase.Search(ctx, args.Query, lang, int(args.Limit), int(args.Offset))
+	if err != nil {
+		return nil, r.fail("search", err)
+	}
+
+	songs := make([]domain.Song, 0, len(results))
+	for _, result := range results {
+		songs = append(songs, result.Song)
+	}
+
+	resolvers := make([]*searchResultResolver, 0, len(results))
+	for i, song := range r.songList(ctx, songs) {
+		resolvers = append(resolvers, &searchResultResolver{song: song, result: results[i]})
+	}
+
+	return resolvers, nil
+}
+
+func (r *Resolver) Artist(ctx context.Context, args struct{ ID graphql.ID }) (*artistResolver, error) {
+	id, err := parseID(args.ID)
+	if err != nil {
+		return nil, r.fail("artist", err)
+	}
+
+	artist, found, err := loadersFrom(ctx).artists.Load(ctx, id)
+	if err != nil {
+		return nil, r.fail("artist", err)
+	}
+	if !found {
+		return nil, nil
+	}
+
+	return &artistResolver{r: r, artist: artist}, nil
+}
+
+type artistsArgs struct {
+	Limit int32
+	Page  int32
+}
+
+func (r *Resolver) Artists(ctx context.Context, args artistsArgs) ([]*artistResolver, error) {
+	artists, err := r.artistUsecase.GetAll(ctx, int(args.Limit), int(args.Page))
+	if err != nil {
+		return nil, r.fail("artists", err)
+	}
+
+	resolvers := make([]*artistResolver, 0, len(artists))
+	for _, artist := range artists {
+		resolvers = append(resolvers, &artistResolver{r: r, artist: artist})
+	}
+
+	return resolvers, nil
+}
+
+func (r *Resolver) Album(ctx context.Context, args struct{ ID graphql.ID }) (*albumResolver, error) {
+	id, err := parseID(args.ID)
+	if err != nil {
+		return nil, r.fail("album", err)
+	}
+
+	album, found, err := loadersFrom(ctx).albums.Load(ctx, id)
+	if err != nil {
+		return nil, r.fail("album", err)
+	}
+	if !found {
+		return nil, nil
+	}
+
+	return &albumResolver{r: r, album: album}, nil
+}
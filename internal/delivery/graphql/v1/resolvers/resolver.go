@@ -0,0 +1,102 @@
+package resolvers
+
+import (
+	"context"
+	_ "embed"
+	"errors"
+	"github.com/NastyaAR/music_library/internal/domain"
+	"github.com/NastyaAR/music_library/internal/pkg/error_handler"
+	"github.com/graph-gophers/graphql-go"
+	"go.uber.org/zap"
+	"strconv"
+)
+
+//go:embed schema.graphql
+var schema string
+
+const (
+	maxDepth       = 10
+	maxParallelism = 50
+)
+
+type Resolver struct {
+	songUsecase   domain.SongUsecase
+	artistUsecase domain.ArtistUsecase
+	albumUsecase  domain.AlbumUsecase
+	lg            *zap.Logger
+}
+
+func NewResolver(songUsecase domain.SongUsecase, artistUsecase domain.ArtistUsecase,
+	albumUsecase domain.AlbumUsecase, lg *zap.Logger) *Resolver {
+	return &Resolver{
+		songUsecase:   songUsecase,
+		artistUsecase: artistUsecase,
+		albumUsecase:  albumUsecase,
+		lg:            lg,
+	}
+}
+
+// NewSchema parses the schema with the resolver, it panics on a schema
+// that does not match the resolvers.
+func NewSchema(r *Resolver) *graphql.Schema {
+	return graphql.MustParseSchema(schema, r,
+		graphql.MaxDepth(maxDepth),
+		graphql.MaxParallelism(maxParallelism))
+}
+
+// resolverError adds the error code of the HTTP API to the error
+// extensions.
+type resolverError struct {
+	err  error
+	code string
+}
+
+func (e resolverError) Error() string {
+	return e.err.Error()
+}
+
+func (e resolverError) Unwrap() error {
+	return e.err
+}
+
+func (e resolverError) Extensions() map[string]interface{} {
+	return map[string]interface{}{"code": e.code}
+}
+
+func (r *Resolver) fail(op string, err error) error {
+	r.lg.Warn("graphql resolver: "+op+" error", zap.Error(err))
+
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return err
+	}
+
+	_, code := error_handler.Classify(err)
+	return resolverError{err: err, code: code}
+}
+
+func parseID(id graphql.ID) (int64, error) {
+	parsed, err := strconv.ParseInt(string(id), 10, 64)
+	if err != nil || parsed <= 0 {
+		return 0, domain.ErrBadID
+	}
+	return parsed, nil
+}
+
+func parseOptionalID(id *graphql.ID) (int64, error) {
+	if id == nil {
+		return 0, nil
+	}
+	return parseID(*id)
+}
+
+func toID(id int64) graphql.ID {
+	return graphql.ID(strconv.FormatInt(id, 10))
+}
+
+func optionalInt(value int) *int32 {
+	if value == 0 {
+		return nil
+	}
+	v := int32(value)
+	return &v
+}
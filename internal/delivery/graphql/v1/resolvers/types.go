@@ -0,0 +1,314 @@
+package resolvers
+
+import (
+	"context"
+	"github.com/NastyaAR/music_library/internal/domain"
+	"github.com/NastyaAR/music_library/internal/pkg/date_validate"
+	"github.com/graph-gophers/graphql-go"
+	"time"
+)
+
+func optionalDate(date time.Time) *string {
+	if date.IsZero() {
+		return nil
+	}
+	formatted := date_validate.FormatDate(date)
+	return &formatted
+}
+
+type songResolver struct {
+	r    *Resolver
+	song domain.Song
+}
+
+// songList announces the artists and albums of the songs to the loaders,
+// so a list asking for them costs one query each.
+func (r *Resolver) songList(ctx context.Context, songs []domain.Song) []*songResolver {
+	l := loadersFrom(ctx)
+
+	resolvers := make([]*songResolver, 0, len(songs))
+	for _, song := range songs {
+		if song.ArtistID != 0 {
+			l.artists.Expect(song.ArtistID)
+		}
+		if song.AlbumID != 0 {
+			l.albums.Expect(song.AlbumID)
+		}
+		resolvers = append(resolvers, &songResolver{r: r, song: song})
+	}
+
+	return resolvers
+}
+
+func (s *songResolver) ID() graphql.ID {
+	return toID(s.song.ID)
+}
+
+func (s *songResolver) Group() string {
+	return s.song.Group
+}
+
+func (s *songResolver) Name() string {
+	return s.song.Name
+}
+
+func (s *songResolver) ReleaseDate() *string {
+	return optionalDate(s.song.ReleaseDate)
+}
+
+func (s *songResolver) Text() string {
+	return s.song.Text
+}
+
+func (s *songResolver) Link() string {
+	return s.song.Link
+}
+
+func (s *songResolver) TrackNumber() *int32 {
+	return optionalInt(s.song.TrackNumber)
+}
+
+func (s *songResolver) Version() int32 {
+	return int32(s.song.Version)
+}
+
+func (s *songResolver) Artist(ctx context.Context) (*artistResolver, error) {
+	if s.song.ArtistID == 0 {
+		return nil, nil
+	}
+
+	artist, found, err := loadersFrom(ctx).artists.Load(ctx, s.song.ArtistID)
+	if err != nil {
+		return nil, s.r.fail("song artist", err)
+	}
+	if !found {
+		return nil, nil
+	}
+
+	return &artistResolver{r: s.r, artist: artist}, nil
+}
+
+func (s *songResolver) Album(ctx context.Context) (*albumResolver, error) {
+	if s.song.AlbumID == 0 {
+		return nil, nil
+	}
+
+	album, found, err := loadersFrom(ctx).albums.Load(ctx, s.song.AlbumID)
+	if err != nil {
+		return nil, s.r.fail("song album", err)
+	}
+	if !found {
+		return nil, nil
+	}
+
+	return &albumResolver{r: s.r, album: album}, nil
+}
+
+func (s *songResolver) Sections() []*sectionResolver {
+	sections := make([]*sectionResolver, 0, len(s.song.Sections))
+	for _, section := range s.song.Sections {
+		sections = append(sections, &sectionResolver{section: section})
+	}
+	return sections
+}
+
+func (s *songResolver) Couplet(ctx context.Context, args struct{ N int32 }) (*coupletResolver, error) {
+	if args.N < 1 {
+		return nil, s.r.fail("song couplet", domain.ErrBadOffset)
+	}
+
+	couplet, found, err := loadersFrom(ctx).couplets.Load(ctx,
+		coupletKey{songID: s.song.ID, n: int(args.N)})
+	if err != nil {
+		return nil, s.r.fail("song couplet", err)
+	}
+	if !found {
+		return nil, nil
+	}
+
+	return &coupletResolver{couplet: couplet}, nil
+}
+
+type sectionResolver struct {
+	section domain.LyricsSection
+}
+
+func (s *sectionResolver) Type() string {
+	return string(s.section.Type)
+}
+
+func (s *sectionResolver) Index() int32 {
+	return int32(s.section.Index)
+}
+
+func (s *sectionResolver) Order() int32 {
+	return int32(s.section.Order)
+}
+
+func (s *sectionResolver) Lines() []string {
+	return s.section.Lines
+}
+
+func (s *sectionResolver) Text() string {
+	return s.section.Text()
+}
+
+type coupletResolver struct {
+	couplet domain.Couplet
+}
+
+func (c *coupletResolver) Text() string {
+	return c.couplet.Text
+}
+
+func (c *coupletResolver) StartMs() *int32 {
+	if !c.couplet.Synced {
+		return nil
+	}
+	start := int32(c.couplet.StartMs)
+	return &start
+}
+
+func (c *coupletResolver) EndMs() *int32 {
+	if !c.couplet.Synced {
+		return nil
+	}
+	end := int32(c.couplet.EndMs)
+	return &end
+}
+
+type songConnectionResolver struct {
+	songs []*songResolver
+	page  domain.SongsPage
+}
+
+func (c *songConnectionResolver) Songs() []*songResolver {
+	return c.songs
+}
+
+func (c *songConnectionResolver) Total() *int32 {
+	if c.page.Total == nil {
+		return nil
+	}
+	total := int32(*c.page.Total)
+	return &total
+}
+
+func (c *songConnectionResolver) HasMore() bool {
+	return c.page.HasMore
+}
+
+func optionalString(value string) *string {
+	if value == "" {
+		return nil
+	}
+	return &value
+}
+
+func (c *songConnectionResolver) NextCursor() *string {
+	return optionalString(c.page.NextCursor)
+}
+
+func (c *songConnectionResolver) PrevCursor() *string {
+	return optionalString(c.page.PrevCursor)
+}
+
+type searchResultResolver struct {
+	song   *songResolver
+	result domain.SongSearchResult
+}
+
+func (s *searchResultResolver) Song() *songResolver {
+	return s.song
+}
+
+func (s *searchResultResolver) Rank() float64 {
+	return s.result.Rank
+}
+
+func (s *searchResultResolver) Snippet() string {
+	return s.result.Snippet
+}
+
+type artistResolver struct {
+	r      *Resolver
+	artist domain.Artist
+}
+
+func (a *artistResolver) ID() graphql.ID {
+	return toID(a.artist.ID)
+}
+
+func (a *artistResolver) Name() string {
+	return a.artist.Name
+}
+
+func (a *artistResolver) Country() string {
+	return a.artist.Country
+}
+
+func (a *artistResolver) FormedYear() *int32 {
+	return optionalInt(a.artist.FormedYear)
+}
+
+func (a *artistResolver) Description() string {
+	return a.artist.Description
+}
+
+func (a *artistResolver) Aliases() []string {
+	if a.artist.Aliases == nil {
+		return []string{}
+	}
+	return a.artist.Aliases
+}
+
+func (a *artistResolver) Songs(ctx context.Context, args struct{ Limit int32 }) ([]*songResolver, error) {
+	if args.Limit <= 0 {
+		return nil, a.r.fail("artist songs", domain.ErrBadLimit)
+	}
+
+	songs, _, err := loadersFrom(ctx).artistSongs.Load(ctx,
+		artistSongsKey{artistID: a.artist.ID, limit: int(args.Limit)})
+	if err != nil {
+		return nil, a.r.fail("artist songs", err)
+	}
+
+	return a.r.songList(ctx, songs), nil
+}
+
+type albumResolver struct {
+	r     *Resolver
+	album domain.Album
+}
+
+func (a *albumResolver) ID() graphql.ID {
+	return toID(a.album.ID)
+}
+
+func (a *albumResolver) Title() string {
+	return a.album.Title
+}
+
+func (a *albumResolver) ReleaseDate() *string {
+	return optionalDate(a.album.ReleaseDate)
+}
+
+func (a *albumResolver) CoverLink() string {
+	return a.album.CoverLink
+}
+
+func (a *albumResolver) Artist(ctx context.Context) (*artistResolver, error) {
+	if a.album.ArtistID == 0 {
+		return nil, nil
+	}
+
+	artist, found, err := loadersFrom(ctx).artists.Load(ctx, a.album.ArtistID)
+	if err != nil {
+		return nil, a.r.fail("album artist", err)
+	}
+	if !found {
+		return nil, nil
+	}
+
+	return &artistResolver{r: a.r, artist: artist}, nil
+}
@@ -0,0 +1,156 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"github.com/NastyaAR/music_library/internal/delivery/grpc/v1/songpb"
+	"github.com/NastyaAR/music_library/internal/domain"
+	"github.com/NastyaAR/music_library/internal/pkg/ratelimit"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// writeMethods need an access token or an api key, the rest of the calls
+// are public as in the HTTP API.
+var writeMethods = map[string]bool{
+	songpb.SongService_CreateSong_FullMethodName: true,
+	songpb.SongService_UpdateSong_FullMethodName: true,
+	songpb.SongService_DeleteSong_FullMethodName: true,
+}
+
+type authenticator struct {
+	users    domain.UserUsecase
+	apiKeys  domain.APIKeyUsecase
+	limits   ratelimit.Store
+	failures ratelimit.Budget
+	lg       *zap.Logger
+}
+
+// failureKey counts failed sign ins per peer ip as the HTTP auth
+// middleware does.
+func failureKey(ctx context.Context) string {
+	p, ok := peer.FromContext(ctx)
+	if !ok || p.Addr == nil {
+		return "auth_failure:ip:"
+	}
+
+	host, _, err := net.SplitHostPort(p.Addr.String())
+	if err != nil {
+		host = p.Addr.String()
+	}
+	return "auth_failure:ip:" + host
+}
+
+func bearerToken(md metadata.MD) (string, bool) {
+	for _, header := range md.Get("authorization") {
+		scheme, token, ok := strings.Cut(header, " ")
+		if ok && strings.EqualFold(scheme, "Bearer") && strings.TrimSpace(token) != "" {
+			return strings.TrimSpace(token), true
+		}
+	}
+	return "", false
+}
+
+func (a *authenticator) authenticateKey(ctx context.Context, key string, method string) (domain.Identity, error) {
+	identity, err := a.apiKeys.Authenticate(ctx, key)
+	if err != nil {
+		return domain.Identity{}, err
+	}
+
+	res, err := a.limits.Take(ctx, "api_key:"+strconv.FormatInt(identity.APIKeyID, 10),
+		ratelimit.Budget{Limit: identity.RateLimit, Period: time.Minute})
+	if err != nil {
+		a.lg.Warn("grpc auth: api key rate limit error", zap.Error(err))
+		res.Allowed = true
+	}
+	if !res.Allowed {
+		return domain.Identity{}, domain.ErrRateLimited
+	}
+
+	if !writeMethods[method] && !identity.HasScope(domain.ScopeSongsRead) {
+		return domain.Identity{}, domain.ErrForbidden
+	}
+
+	return identity, nil
+}
+
+// authenticate returns ctx with the caller in it, like the HTTP auth
+// middleware does.
+func (a *authenticator) authenticate(ctx context.Context, method string) (context.Context, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+
+	keys := md.Get("x-api-key")
+	hasKey := len(keys) > 0 && keys[0] != ""
+	token, hasToken := bearerToken(md)
+	if !hasKey && !hasToken {
+		if writeMethods[method] {
+			a.lg.Warn("grpc auth: anonymous call", zap.String("method", method))
+			return nil, statusError(domain.ErrUnauthorized)
+		}
+		return ctx, nil
+	}
+
+	res, err := a.limits.Check(ctx, failureKey(ctx), a.failures)
+	if err != nil {
+		a.lg.Warn("grpc auth: failure limit error", zap.Error(err))
+	} else if !res.Allowed {
+		a.lg.Warn("grpc auth: too many failures", zap.String("method", method))
+		return nil, statusError(domain.ErrRateLimited)
+	}
+
+	var identity domain.Identity
+	if hasKey {
+		identity, err = a.authenticateKey(ctx, keys[0], method)
+	} else {
+		identity, err = a.users.Authenticate(ctx, token)
+	}
+
+	if err != nil {
+		a.lg.Warn("grpc auth error", zap.String("method", method), zap.Error(err))
+		if errors.Is(err, domain.ErrInvalidAPIKey) || errors.Is(err, domain.ErrInvalidToken) {
+			if _, takeErr := a.limits.Take(ctx, failureKey(ctx), a.failures); takeErr != nil {
+				a.lg.Warn("grpc auth: failure limit error", zap.Error(takeErr))
+			}
+		}
+		return nil, statusError(err)
+	}
+
+	return domain.WithIdentity(ctx, identity), nil
+}
+
+func (a *authenticator) unary() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler) (interface{}, error) {
+		ctx, err := a.authenticate(ctx, info.FullMethod)
+		if err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+type identityStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s identityStream) Context() context.Context {
+	return s.ctx
+}
+
+func (a *authenticator) stream() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo,
+		handler grpc.StreamHandler) error {
+		ctx, err := a.authenticate(ss.Context(), info.FullMethod)
+		if err != nil {
+			return err
+		}
+		return handler(srv, identityStream{ServerStream: ss, ctx: ctx})
+	}
+}
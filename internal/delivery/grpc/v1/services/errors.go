@@ -0,0 +1,54 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"github.com/NastyaAR/music_library/internal/pkg/error_handler"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"net/http"
+)
+
+const errorDomain = "music_library"
+
+var httpCodes = map[int]codes.Code{
+	http.StatusBadRequest:           codes.InvalidArgument,
+	http.StatusNotFound:             codes.NotFound,
+	http.StatusConflict:             codes.Aborted,
+	http.StatusPreconditionFailed:   codes.FailedPrecondition,
+	http.StatusUnsupportedMediaType: codes.InvalidArgument,
+	http.StatusUnauthorized:         codes.Unauthenticated,
+	http.StatusForbidden:            codes.PermissionDenied,
+	http.StatusTooManyRequests:      codes.ResourceExhausted,
+}
+
+// statusError turns a domain error into a gRPC status. The code follows
+// the HTTP status of the error and the HTTP error code goes into an
+// ErrorInfo detail, so both APIs report errors the same way.
+func statusError(err error) error {
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return status.FromContextError(err).Err()
+	}
+
+	httpStatus, errorCode := error_handler.Classify(err)
+
+	code, ok := httpCodes[httpStatus]
+	if !ok {
+		code = codes.Internal
+	}
+	if errorCode == "song_exists" || errorCode == "user_exists" || errorCode == "artist_exists" {
+		code = codes.AlreadyExists
+	}
+
+	st := status.New(code, err.Error())
+	detailed, detailErr := st.WithDetails(&errdetails.ErrorInfo{
+		Reason: errorCode,
+		Domain: errorDomain,
+	})
+	if detailErr != nil {
+		return st.Err()
+	}
+
+	return detailed.Err()
+}
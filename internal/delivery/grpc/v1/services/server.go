@@ -0,0 +1,78 @@
+package services
+
+import (
+	"context"
+	"github.com/NastyaAR/music_library/internal/delivery/grpc/v1/songpb"
+	"github.com/NastyaAR/music_library/internal/domain"
+	"github.com/NastyaAR/music_library/internal/pkg/ratelimit"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"time"
+)
+
+// NewServer registers the services on a gRPC server that logs calls and
+// turns panics into Internal errors, like gin.Default does for HTTP. Write
+// calls need a bearer token in the authorization metadata or an api key
+// in x-api-key.
+func NewServer(songService *SongService, users domain.UserUsecase, apiKeys domain.APIKeyUsecase,
+	limits ratelimit.Store, failures ratelimit.Budget, lg *zap.Logger) *grpc.Server {
+	auth := &authenticator{users: users, apiKeys: apiKeys, limits: limits, failures: failures, lg: lg}
+	server := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(unaryLogger(lg), unaryRecovery(lg), auth.unary()),
+		grpc.ChainStreamInterceptor(streamLogger(lg), streamRecovery(lg), auth.stream()),
+	)
+	songpb.RegisterSongServiceServer(server, songService)
+
+	return server
+}
+
+func logCall(lg *zap.Logger, method string, start time.Time, err error) {
+	lg.Info("grpc call", zap.String("method", method),
+		zap.String("code", status.Code(err).String()),
+		zap.Duration("latency", time.Since(start)))
+}
+
+func unaryLogger(lg *zap.Logger) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler) (interface{}, error) {
+		start := time.Now()
+		resp, err := handler(ctx, req)
+		logCall(lg, info.FullMethod, start, err)
+		return resp, err
+	}
+}
+
+func streamLogger(lg *zap.Logger) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo,
+		handler grpc.StreamHandler) error {
+		start := time.Now()
+		err := handler(srv, ss)
+		logCall(lg, info.FullMethod, start, err)
+		return err
+	}
+}
+
+func recovered(lg *zap.Logger, method string, err *error) {
+	if r := recover(); r != nil {
+		lg.Error("grpc panic", zap.String("method", method), zap.Any("panic", r))
+		*err = status.Error(codes.Internal, "internal error")
+	}
+}
+
+func unaryRecovery(lg *zap.Logger) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler) (resp interface{}, err error) {
+		defer recovered(lg, info.FullMethod, &err)
+		return handler(ctx, req)
+	}
+}
+
+func streamRecovery(lg *zap.Logger) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo,
+		handler grpc.StreamHandler) (err error) {
+		defer recovered(lg, info.FullMethod, &err)
+		return handler(srv, ss)
+	}
+}
@@ -0,0 +1,292 @@
+package services
+
+import (
+	"context"
+	"github.com/NastyaAR/music_library/internal/delivery/grpc/v1/songpb"
+	"github.com/NastyaAR/music_library/internal/domain"
+	"github.com/NastyaAR/music_library/internal/pkg/date_validate"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"strconv"
+	"time"
+)
+
+// listBatch is how many songs ListSongs asks the usecase for at a time.
+const listBatch = 100
+
+type SongService struct {
+	songpb.UnimplementedSongServiceServer
+	songUsecase domain.SongUsecase
+	lg          *zap.Logger
+}
+
+func NewSongService(songUsecase domain.SongUsecase, lg *zap.Logger) *SongService {
+	return &SongService{
+		songUsecase: songUsecase,
+		lg:          lg,
+	}
+}
+
+func toSongMessage(song domain.Song) *songpb.Song {
+	msg := &songpb.Song{
+		Id:          song.ID,
+		ArtistId:    song.ArtistID,
+		Group:       song.Group,
+		Name:        song.Name,
+		Text:        song.Text,
+		Link:        song.Link,
+		AlbumId:     song.AlbumID,
+		TrackNumber: int32(song.TrackNumber),
+		Version:     int32(song.Version),
+	}
+	if !song.ReleaseDate.IsZero() {
+		msg.ReleaseDate = date_validate.FormatDate(song.ReleaseDate)
+	}
+
+	return msg
+}
+
+func parseDate(date string) (time.Time, error) {
+	if date == "" {
+		return time.Time{}, nil
+	}
+	return date_validate.ParseDate(date)
+}
+
+func (s *SongService) CreateSong(ctx context.Context, req *songpb.CreateSongRequest) (*songpb.Song, error) {
+	date, err := parseDate(req.GetReleaseDate())
+	if err != nil {
+		s.lg.Warn("song service: create error", zap.Error(err))
+		return nil, statusError(err)
+	}
+
+	song := domain.Song{
+		Group:       req.GetGroup(),
+		Name:        req.GetName(),
+		ReleaseDate: date,
+		Text:        req.GetText(),
+		Link:        req.GetLink(),
+		AlbumID:     req.GetAlbumId(),
+		TrackNumber: int(req.GetTrackNumber()),
+	}
+
+	created, err := s.songUsecase.Create(ctx, &song)
+	if err != nil {
+		s.lg.Warn("song service: create error", zap.Error(err))
+		return nil, statusError(err)
+	}
+
+	return toSongMessage(created), nil
+}
+
+func (s *SongService) DeleteSong(ctx context.Context, req *songpb.DeleteSongRequest) (*emptypb.Empty, error) {
+	err := s.songUsecase.Delete(ctx, req.GetGroup(), req.GetName(), int(req.GetVersion()))
+	if err != nil {
+		s.lg.Warn("song service: delete error", zap.Error(err))
+		return nil, statusError(err)
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+func patchField[T any](value *T) domain.PatchField[T] {
+	return domain.PatchField[T]{Set: value != nil, Value: value}
+}
+
+// toSongPatch builds a patch of the set fields, fields named in clear are
+// set to nil.
+func toSongPatch(req *songpb.UpdateSongRequest) (domain.SongPatch, error) {
+	patch := domain.SongPatch{
+		Group:   patchField(req.NewGroup),
+		Name:    patchField(req.NewName),
+		Text:    patchField(req.Text),
+		Link:    patchField(req.Link),
+		AlbumID: patchField(req.AlbumId),
+		Version: int(req.GetVersion()),
+	}
+
+	if req.ReleaseDate != nil {
+		date, err := date_validate.ParseDate(req.GetReleaseDate())
+		if err != nil {
+			return domain.SongPatch{}, err
+		}
+		patch.ReleaseDate = patchField(&date)
+	}
+
+	if req.TrackNumber != nil {
+		track := int(req.GetTrackNumber())
+		patch.TrackNumber = patchField(&track)
+	}
+
+	for _, field := range req.GetClear() {
+		switch field {
+		case "release_date":
+			patch.ReleaseDate = domain.PatchField[time.Time]{Set: true}
+		case "text":
+			patch.Text = domain.PatchField[string]{Set: true}
+		case "link":
+			patch.Link = domain.PatchField[string]{Set: true}
+		case "album_id":
+			patch.AlbumID = domain.PatchField[int64]{Set: true}
+		case "track_number":
+			patch.TrackNumber = domain.PatchField[int]{Set: true}
+		default:
+			return domain.SongPatch{}, domain.ErrBadPatch
+		}
+	}
+
+	return patch, nil
+}
+
+func (s *SongService) UpdateSong(ctx context.Context, req *songpb.UpdateSongRequest) (*songpb.Song, error) {
+	patch, err := toSongPatch(req)
+	if err != nil {
+		s.lg.Warn("song service: update error", zap.Error(err))
+		return nil, statusError(err)
+	}
+
+	updated, err := s.songUsecase.Update(ctx, req.GetGroup(), req.GetName(), &patch)
+	if err != nil {
+		s.lg.Warn("song service: update error", zap.Error(err))
+		return nil, statusError(err)
+	}
+
+	return toSongMessage(updated), nil
+}
+
+func toStringFilter(filter *songpb.ListSongsRequest_StringFilter) domain.StringFilter {
+	return domain.StringFilter{
+		Value: filter.GetValue(),
+		Match: domain.MatchMode(filter.GetMatch()),
+	}
+}
+
+func toSongFilter(req *songpb.ListSongsRequest) (domain.SongFilter, error) {
+	filter := domain.SongFilter{
+		Group:   toStringFilter(req.GetGroup()),
+		Name:    toStringFilter(req.GetName()),
+		Text:    toStringFilter(req.GetText()),
+		Link:    toStringFilter(req.GetLink()),
+		AlbumID: req.GetAlbumId(),
+		Year:    int(req.GetYear()),
+		Decade:  int(req.GetDecade()),
+		Sort:    domain.ParseSort(req.GetSort()),
+	}
+
+	var err error
+	filter.ReleaseDate, err = parseDate(req.GetReleaseDate())
+	if err != nil {
+		return domain.SongFilter{}, err
+	}
+	filter.ReleasedFrom, err = parseDate(req.GetReleasedFrom())
+	if err != nil {
+		return domain.SongFilter{}, err
+	}
+	filter.ReleasedTo, err = parseDate(req.GetReleasedTo())
+	if err != nil {
+		return domain.SongFilter{}, err
+	}
+
+	return filter, nil
+}
+
+// ListSongs walks the listing with keyset cursors, or with page numbers
+// when a similarity filter rules cursors out.
+func (s *SongService) ListSongs(req *songpb.ListSongsRequest, stream grpc.ServerStreamingServer[songpb.Song]) error {
+	filter, err := toSongFilter(req)
+	if err != nil {
+		s.lg.Warn("song service: list error", zap.Error(err))
+		return statusError(err)
+	}
+
+	limit := int(req.GetLimit())
+	if limit < 0 {
+		s.lg.Warn("song service: list error: bad limit")
+		return statusError(domain.ErrBadLimit)
+	}
+
+	page := domain.Pagination{Limit: listBatch, Cursor: req.GetCursor()}
+	byPage := filter.HasSimilarity() && page.Cursor == ""
+	if byPage {
+		page.Page = 1
+	}
+
+	sent := 0
+	for {
+		// page numbers only work with a fixed page size, so the last page
+		// is cut when sending
+		if limit > 0 && !byPage && limit-sent < listBatch {
+			page.Limit = limit - sent
+		}
+
+		pageFilter := filter
+		result, err := s.songUsecase.GetSongs(stream.Context(), &pageFilter, &page)
+		if err != nil {
+			s.lg.Warn("song service: list error", zap.Error(err))
+			return statusError(err)
+		}
+
+		if sent == 0 && result.Total != nil {
+			err = stream.SendHeader(metadata.Pairs("x-total-count", strconv.Itoa(*result.Total)))
+			if err != nil {
+				return err
+			}
+		}
+
+		for _, song := range result.Songs {
+			if limit > 0 && sent >= limit {
+				return nil
+			}
+
+			err = stream.Send(toSongMessage(song))
+			if err != nil {
+				s.lg.Warn("song service: list send error", zap.Error(err))
+				return err
+			}
+			sent++
+		}
+
+		switch {
+		case len(result.Songs) == 0 || (limit > 0 && sent >= limit):
+			return nil
+		case byPage:
+			if !result.HasMore {
+				return nil
+			}
+			page.Page++
+		default:
+			if result.NextCursor == "" {
+				return nil
+			}
+			page.Cursor = result.NextCursor
+		}
+	}
+}
+
+func (s *SongService) GetSong(ctx context.Context, req *songpb.GetSongRequest) (*songpb.Song, error) {
+	song, err := s.songUsecase.Get(ctx, req.GetGroup(), req.GetName())
+	if err != nil {
+		s.lg.Warn("song service: get error", zap.Error(err))
+		return nil, statusError(err)
+	}
+
+	return toSongMessage(song), nil
+}
+
+func (s *SongService) GetCouplet(ctx context.Context, req *songpb.GetCoupletRequest) (*songpb.Couplet, error) {
+	couplet, err := s.songUsecase.GetСouplet(ctx, req.GetGroup(), req.GetName(), int(req.GetOffset()))
+	if err != nil {
+		s.lg.Warn("song service: get couplet error", zap.Error(err))
+		return nil, statusError(err)
+	}
+
+	msg := &songpb.Couplet{Text: couplet.Text}
+	if couplet.Synced {
+		start, end := int32(couplet.StartMs), int32(couplet.EndMs)
+		msg.StartMs, msg.EndMs = &start, &end
+	}
+
+	return msg, nil
+}
@@ -0,0 +1,271 @@
+package handlers
+
+import (
+	"encoding/json"
+	"github.com/NastyaAR/music_library/internal/domain"
+	"github.com/NastyaAR/music_library/internal/pkg/date_validate"
+	"github.com/NastyaAR/music_library/internal/pkg/error_handler"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+	"io"
+	"net/http"
+	"time"
+)
+
+type AlbumHandler struct {
+	albumUsecase domain.AlbumUsecase
+	lg           *zap.Logger
+}
+
+func NewAlbumHandler(a domain.AlbumUsecase, lg *zap.Logger) *AlbumHandler {
+	return &AlbumHandler{
+		albumUsecase: a,
+		lg:           lg,
+	}
+}
+
+func toAlbumResponse(album domain.Album) domain.AlbumResponse {
+	return domain.AlbumResponse{
+		ID:          album.ID,
+		Title:       album.Title,
+		ArtistID:    album.ArtistID,
+		ReleaseDate: date_validate.FormatDate(album.ReleaseDate),
+		CoverLink:   album.CoverLink,
+	}
+}
+
+func (h *AlbumHandler) readAlbumRequest(ctx *gin.Context) (domain.Album, error) {
+	var albumRequest domain.CreateAlbumRequest
+
+	body, err := io.ReadAll(ctx.Request.Body)
+	if err != nil {
+		h.lg.Warn("album handler: read error", zap.Error(err))
+		return domain.Album{}, domain.ErrInternalServer
+	}
+	err = json.Unmarshal(body, &albumRequest)
+	if err != nil {
+		h.lg.Warn("album handler: unmarsh error", zap.Error(err))
+		return domain.Album{}, domain.ErrBadRequestBody
+	}
+
+	var date time.Time
+
+	if albumRequest.ReleaseDate != "" {
+		date, err = date_validate.ParseDate(albumRequest.ReleaseDate)
+		if err != nil {
+			h.lg.Warn("album handler: bad release date", zap.Error(err))
+			return domain.Album{}, err
+		}
+	}
+
+	return domain.Album{
+		Title:       albumRequest.Title,
+		ArtistID:    albumRequest.ArtistID,
+		ReleaseDate: date,
+		CoverLink:   albumRequest.CoverLink,
+	}, nil
+}
+
+// Create godoc
+// @Summary      Create album
+// @Description  create album
+// @Tags         albums
+// @Accept       json
+// @Produce      json
+// @Param        album  body  domain.CreateAlbumRequest  true  "album"
+// @Success      200  {object}  domain.AlbumResponse
+// @Failure      400  {object}  error_handler.HTTPError
+// @Failure      401  {object}  error_handler.HTTPError
+// @Failure      403  {object}  error_handler.HTTPError
+// @Failure      409  {object}  error_handler.HTTPError
+// @Failure      500  {object}  error_handler.HTTPError
+// @Security     BearerAuth
+// @Security     ApiKeyAuth
+// @Router       /albums [post]
+func (h *AlbumHandler) Create(ctx *gin.Context) {
+	album, err := h.readAlbumRequest(ctx)
+	if err != nil {
+		error_handler.NewError(ctx, err)
+		return
+	}
+
+	created, err := h.albumUsecase.Create(ctx, &album)
+	if err != nil {
+		h.lg.Warn("album handler: create error", zap.Error(err))
+		error_handler.NewError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, toAlbumResponse(created))
+}
+
+// Delete godoc
+// @Summary      Delete album
+// @Description  delete album, its songs stay in the library without album
+// @Tags         albums
+// @Produce      json
+// @Param        id    path     int  true  "id of album"
+// @Success      200
+// @Failure      400  {object}  error_handler.HTTPError
+// @Failure      401  {object}  error_handler.HTTPError
+// @Failure      403  {object}  error_handler.HTTPError
+// @Failure      404  {object}  error_handler.HTTPError
+// @Failure      500  {object}  error_handler.HTTPError
+// @Security     BearerAuth
+// @Security     ApiKeyAuth
+// @Router       /albums/{id} [delete]
+func (h *AlbumHandler) Delete(ctx *gin.Context) {
+	id, err := getIDParam(ctx)
+	if err != nil {
+		h.lg.Warn("album handler: delete error", zap.Error(err))
+		error_handler.NewError(ctx, err)
+		return
+	}
+
+	err = h.albumUsecase.Delete(ctx, id)
+	if err != nil {
+		h.lg.Warn("album handler: delete error", zap.Error(err))
+		error_handler.NewError(ctx, err)
+		return
+	}
+
+	ctx.Status(http.StatusOK)
+}
+
+// Update godoc
+// @Summary      Update album
+// @Description  update album
+// @Tags         albums
+// @Accept       json
+// @Produce      json
+// @Param        id    path     int  true  "id of album"
+// @Param        album  body  domain.CreateAlbumRequest  true  "album"
+// @Success      200  {object}  domain.AlbumResponse
+// @Failure      400  {object}  error_handler.HTTPError
+// @Failure      401  {object}  error_handler.HTTPError
+// @Failure      403  {object}  error_handler.HTTPError
+// @Failure      404  {object}  error_handler.HTTPError
+// @Failure      409  {object}  error_handler.HTTPError
+// @Failure      500  {object}  error_handler.HTTPError
+// @Security     BearerAuth
+// @Security     ApiKeyAuth
+// @Router       /albums/{id} [patch]
+func (h *AlbumHandler) Update(ctx *gin.Context) {
+	id, err := getIDParam(ctx)
+	if err != nil {
+		h.lg.Warn("album handler: update error", zap.Error(err))
+		error_handler.NewError(ctx, err)
+		return
+	}
+
+	album, err := h.readAlbumRequest(ctx)
+	if err != nil {
+		error_handler.NewError(ctx, err)
+		return
+	}
+
+	updated, err := h.albumUsecase.Update(ctx, id, &album)
+	if err != nil {
+		h.lg.Warn("album handler: update error", zap.Error(err))
+		error_handler.NewError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, toAlbumResponse(updated))
+}
+
+// Get godoc
+// @Summary      Get album
+// @Description  get album
+// @Tags         albums
+// @Produce      json
+// @Param        id    path     int  true  "id of album"
+// @Success      200  {object}  domain.AlbumResponse
+// @Failure      400  {object}  error_handler.HTTPError
+// @Failure      404  {object}  error_handler.HTTPError
+// @Failure      500  {object}  error_handler.HTTPError
+// @Router       /albums/{id} [get]
+func (h *AlbumHandler) Get(ctx *gin.Context) {
+	id, err := getIDParam(ctx)
+	if err != nil {
+		h.lg.Warn("album handler: get error", zap.Error(err))
+		error_handler.NewError(ctx, err)
+		return
+	}
+
+	album, err := h.albumUsecase.Get(ctx, id)
+	if err != nil {
+		h.lg.Warn("album handler: get error", zap.Error(err))
+		error_handler.NewError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, toAlbumResponse(album))
+}
+
+// GetAll godoc
+// @Summary      Get albums with limit and offset
+// @Description  get albums with limit and offset
+// @Tags         albums
+// @Produce      json
+// @Param        limit    query     string  true  "albums on page"
+// @Param        offset    query     string  true  "page"
+// @Success      200  {object}  domain.GetAlbumsResponse
+// @Failure      400  {object}  error_handler.HTTPError
+// @Failure      500  {object}  error_handler.HTTPError
+// @Router       /albums [get]
+func (h *AlbumHandler) GetAll(ctx *gin.Context) {
+	limit, offset, err := getPageParams(ctx)
+	if err != nil {
+		h.lg.Warn("album handler: get all error", zap.Error(err))
+		error_handler.NewError(ctx, err)
+		return
+	}
+
+	albums, err := h.albumUsecase.GetAll(ctx, limit, offset)
+	if err != nil {
+		h.lg.Warn("album handler: get all error", zap.Error(err))
+		error_handler.NewError(ctx, err)
+		return
+	}
+
+	albumsResponse := domain.GetAlbumsResponse{Albums: make([]domain.AlbumResponse, 0)}
+	for _, a := range albums {
+		albumsResponse.Albums = append(albumsResponse.Albums, toAlbumResponse(a))
+	}
+
+	ctx.JSON(http.StatusOK, albumsResponse)
+}
+
+// GetTracks godoc
+// @Summary      Get album tracks
+// @Description  get album songs in track order
+// @Tags         albums
+// @Produce      json
+// @Param        id    path     int  true  "id of album"
+// @Success      200  {object}  domain.GetSongsResponse
+// @Failure      400  {object}  error_handler.HTTPError
+// @Failure      500  {object}  error_handler.HTTPError
+// @Router       /albums/{id}/tracks [get]
+func (h *AlbumHandler) GetTracks(ctx *gin.Context) {
+	id, err := getIDParam(ctx)
+	if err != nil {
+		h.lg.Warn("album handler: get tracks error", zap.Error(err))
+		error_handler.NewError(ctx, err)
+		return
+	}
+
+	tracks, err := h.albumUsecase.GetTracks(ctx, id)
+	if err != nil {
+		h.lg.Warn("album handler: get tracks error", zap.Error(err))
+		error_handler.NewError(ctx, err)
+		return
+	}
+
+	songsResponse := domain.GetSongsResponse{Songs: make([]domain.CreateSongResponse, 0)}
+	for _, s := range tracks {
+		songsResponse.Songs = append(songsResponse.Songs, toSongResponse(s))
+	}
+
+	ctx.JSON(http.StatusOK, songsResponse)
+}
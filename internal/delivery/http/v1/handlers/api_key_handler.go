@@ -0,0 +1,142 @@
+package handlers
+
+import (
+	"github.com/NastyaAR/music_library/internal/domain"
+	"github.com/NastyaAR/music_library/internal/pkg/error_handler"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+	"net/http"
+	"time"
+)
+
+type APIKeyHandler struct {
+	apiKeyUsecase domain.APIKeyUsecase
+	lg            *zap.Logger
+}
+
+func NewAPIKeyHandler(u domain.APIKeyUsecase, lg *zap.Logger) *APIKeyHandler {
+	return &APIKeyHandler{
+		apiKeyUsecase: u,
+		lg:            lg,
+	}
+}
+
+func toAPIKeyResponse(key domain.APIKey) domain.APIKeyResponse {
+	keyResponse := domain.APIKeyResponse{
+		ID:        key.ID,
+		Name:      key.Name,
+		Prefix:    key.Prefix,
+		Scopes:    make([]string, 0, len(key.Scopes)),
+		RateLimit: key.RateLimit,
+		CreatedAt: key.CreatedAt.Format(time.RFC3339),
+	}
+	for _, scope := range key.Scopes {
+		keyResponse.Scopes = append(keyResponse.Scopes, string(scope))
+	}
+	if key.LastUsedAt != nil {
+		keyResponse.LastUsedAt = key.LastUsedAt.Format(time.RFC3339)
+	}
+	if key.RevokedAt != nil {
+		keyResponse.RevokedAt = key.RevokedAt.Format(time.RFC3339)
+	}
+	return keyResponse
+}
+
+// Create godoc
+// @Summary      Create api key
+// @Description  create an api key that acts as the current user within its scopes: songs:read, songs:write, songs:import. The key is shown only in this response. rate_limit is requests per minute
+// @Tags         api keys
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        request body domain.CreateAPIKeyRequest true "name, scopes and rate limit"
+// @Success      201  {object}  domain.CreateAPIKeyResponse
+// @Failure      400  {object}  error_handler.HTTPError
+// @Failure      401  {object}  error_handler.HTTPError
+// @Failure      403  {object}  error_handler.HTTPError
+// @Failure      500  {object}  error_handler.HTTPError
+// @Router       /api-keys [post]
+func (h *APIKeyHandler) Create(ctx *gin.Context) {
+	var req domain.CreateAPIKeyRequest
+	err := ctx.ShouldBindJSON(&req)
+	if err != nil {
+		h.lg.Warn("api key handler: create error: bad body", zap.Error(err))
+		error_handler.NewError(ctx, domain.ErrBadRequestBody)
+		return
+	}
+
+	scopes := make([]domain.Scope, 0, len(req.Scopes))
+	for _, scope := range req.Scopes {
+		scopes = append(scopes, domain.Scope(scope))
+	}
+
+	key, plain, err := h.apiKeyUsecase.Create(ctx, req.Name, scopes, req.RateLimit)
+	if err != nil {
+		h.lg.Warn("api key handler: create error", zap.Error(err))
+		error_handler.NewError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, domain.CreateAPIKeyResponse{
+		APIKeyResponse: toAPIKeyResponse(key),
+		Key:            plain,
+	})
+}
+
+// GetAll godoc
+// @Summary      Get api keys
+// @Description  get api keys of the current user, revoked ones included
+// @Tags         api keys
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200  {object}  domain.GetAPIKeysResponse
+// @Failure      401  {object}  error_handler.HTTPError
+// @Failure      403  {object}  error_handler.HTTPError
+// @Failure      500  {object}  error_handler.HTTPError
+// @Router       /api-keys [get]
+func (h *APIKeyHandler) GetAll(ctx *gin.Context) {
+	keys, err := h.apiKeyUsecase.GetAll(ctx)
+	if err != nil {
+		h.lg.Warn("api key handler: get all error", zap.Error(err))
+		error_handler.NewError(ctx, err)
+		return
+	}
+
+	keysResponse := domain.GetAPIKeysResponse{APIKeys: make([]domain.APIKeyResponse, 0)}
+	for _, k := range keys {
+		keysResponse.APIKeys = append(keysResponse.APIKeys, toAPIKeyResponse(k))
+	}
+
+	ctx.JSON(http.StatusOK, keysResponse)
+}
+
+// Revoke godoc
+// @Summary      Revoke api key
+// @Description  revoke an api key of the current user, admins can revoke any key
+// @Tags         api keys
+// @Security     BearerAuth
+// @Param        id    path     int  true  "id of api key"
+// @Success      204
+// @Failure      400  {object}  error_handler.HTTPError
+// @Failure      401  {object}  error_handler.HTTPError
+// @Failure      403  {object}  error_handler.HTTPError
+// @Failure      404  {object}  error_handler.HTTPError
+// @Failure      500  {object}  error_handler.HTTPError
+// @Router       /api-keys/{id} [delete]
+func (h *APIKeyHandler) Revoke(ctx *gin.Context) {
+	id, err := getIDParam(ctx)
+	if err != nil {
+		h.lg.Warn("api key handler: revoke error", zap.Error(err))
+		error_handler.NewError(ctx, err)
+		return
+	}
+
+	err = h.apiKeyUsecase.Revoke(ctx, id)
+	if err != nil {
+		h.lg.Warn("api key handler: revoke error", zap.Error(err))
+		error_handler.NewError(ctx, err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
@@ -0,0 +1,292 @@
+package handlers
+
+import (
+	"encoding/json"
+	"github.com/NastyaAR/music_library/internal/domain"
+	"github.com/NastyaAR/music_library/internal/pkg/error_handler"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+	"io"
+	"net/http"
+	"strconv"
+)
+
+type ArtistHandler struct {
+	artistUsecase domain.ArtistUsecase
+	lg            *zap.Logger
+}
+
+func NewArtistHandler(a domain.ArtistUsecase, lg *zap.Logger) *ArtistHandler {
+	return &ArtistHandler{
+		artistUsecase: a,
+		lg:            lg,
+	}
+}
+
+func toArtistResponse(artist domain.Artist) domain.ArtistResponse {
+	aliases := artist.Aliases
+	if aliases == nil {
+		aliases = []string{}
+	}
+
+	return domain.ArtistResponse{
+		ID:          artist.ID,
+		Name:        artist.Name,
+		Country:     artist.Country,
+		FormedYear:  artist.FormedYear,
+		Description: artist.Description,
+		Aliases:     aliases,
+	}
+}
+
+func getPageParams(ctx *gin.Context) (int, int, error) {
+	limit, err := strconv.Atoi(ctx.Request.URL.Query().Get("limit"))
+	if err != nil {
+		return 0, 0, domain.ErrBadLimit
+	}
+
+	offset, err := strconv.Atoi(ctx.Request.URL.Query().Get("offset"))
+	if err != nil {
+		return 0, 0, domain.ErrBadOffset
+	}
+
+	return limit, offset, nil
+}
+
+func (h *ArtistHandler) readArtistRequest(ctx *gin.Context) (domain.Artist, error) {
+	var artistRequest domain.CreateArtistRequest
+
+	body, err := io.ReadAll(ctx.Request.Body)
+	if err != nil {
+		h.lg.Warn("artist handler: read error", zap.Error(err))
+		return domain.Artist{}, domain.ErrInternalServer
+	}
+	err = json.Unmarshal(body, &artistRequest)
+	if err != nil {
+		h.lg.Warn("artist handler: unmarsh error", zap.Error(err))
+		return domain.Artist{}, domain.ErrBadRequestBody
+	}
+
+	return domain.Artist{
+		Name:        artistRequest.Name,
+		Country:     artistRequest.Country,
+		FormedYear:  artistRequest.FormedYear,
+		Description: artistRequest.Description,
+		Aliases:     artistRequest.Aliases,
+	}, nil
+}
+
+// Create godoc
+// @Summary      Create artist
+// @Description  create artist
+// @Tags         artists
+// @Accept       json
+// @Produce      json
+// @Param        artist  body  domain.CreateArtistRequest  true  "artist"
+// @Success      200  {object}  domain.ArtistResponse
+// @Failure      400  {object}  error_handler.HTTPError
+// @Failure      401  {object}  error_handler.HTTPError
+// @Failure      403  {object}  error_handler.HTTPError
+// @Failure      409  {object}  error_handler.HTTPError
+// @Failure      500  {object}  error_handler.HTTPError
+// @Security     BearerAuth
+// @Security     ApiKeyAuth
+// @Router       /artists [post]
+func (h *ArtistHandler) Create(ctx *gin.Context) {
+	artist, err := h.readArtistRequest(ctx)
+	if err != nil {
+		error_handler.NewError(ctx, err)
+		return
+	}
+
+	created, err := h.artistUsecase.Create(ctx, &artist)
+	if err != nil {
+		h.lg.Warn("artist handler: create error", zap.Error(err))
+		error_handler.NewError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, toArtistResponse(created))
+}
+
+// Delete godoc
+// @Summary      Delete artist
+// @Description  delete artist with its trashed songs, 409 while it has other songs or albums
+// @Tags         artists
+// @Produce      json
+// @Param        id    path     int  true  "id of artist"
+// @Success      200
+// @Failure      400  {object}  error_handler.HTTPError
+// @Failure      401  {object}  error_handler.HTTPError
+// @Failure      403  {object}  error_handler.HTTPError
+// @Failure      404  {object}  error_handler.HTTPError
+// @Failure      409  {object}  error_handler.HTTPError
+// @Failure      500  {object}  error_handler.HTTPError
+// @Security     BearerAuth
+// @Security     ApiKeyAuth
+// @Router       /artists/{id} [delete]
+func (h *ArtistHandler) Delete(ctx *gin.Context) {
+	id, err := getIDParam(ctx)
+	if err != nil {
+		h.lg.Warn("artist handler: delete error", zap.Error(err))
+		error_handler.NewError(ctx, err)
+		return
+	}
+
+	err = h.artistUsecase.Delete(ctx, id)
+	if err != nil {
+		h.lg.Warn("artist handler: delete error", zap.Error(err))
+		error_handler.NewError(ctx, err)
+		return
+	}
+
+	ctx.Status(http.StatusOK)
+}
+
+// Update godoc
+// @Summary      Update artist
+// @Description  update artist
+// @Tags         artists
+// @Accept       json
+// @Produce      json
+// @Param        id    path     int  true  "id of artist"
+// @Param        artist  body  domain.CreateArtistRequest  true  "artist"
+// @Success      200  {object}  domain.ArtistResponse
+// @Failure      400  {object}  error_handler.HTTPError
+// @Failure      401  {object}  error_handler.HTTPError
+// @Failure      403  {object}  error_handler.HTTPError
+// @Failure      404  {object}  error_handler.HTTPError
+// @Failure      409  {object}  error_handler.HTTPError
+// @Failure      500  {object}  error_handler.HTTPError
+// @Security     BearerAuth
+// @Security     ApiKeyAuth
+// @Router       /artists/{id} [patch]
+func (h *ArtistHandler) Update(ctx *gin.Context) {
+	id, err := getIDParam(ctx)
+	if err != nil {
+		h.lg.Warn("artist handler: update error", zap.Error(err))
+		error_handler.NewError(ctx, err)
+		return
+	}
+
+	artist, err := h.readArtistRequest(ctx)
+	if err != nil {
+		error_handler.NewError(ctx, err)
+		return
+	}
+
+	updated, err := h.artistUsecase.Update(ctx, id, &artist)
+	if err != nil {
+		h.lg.Warn("artist handler: update error", zap.Error(err))
+		error_handler.NewError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, toArtistResponse(updated))
+}
+
+// Get godoc
+// @Summary      Get artist
+// @Description  get artist
+// @Tags         artists
+// @Produce      json
+// @Param        id    path     int  true  "id of artist"
+// @Success      200  {object}  domain.ArtistResponse
+// @Failure      400  {object}  error_handler.HTTPError
+// @Failure      404  {object}  error_handler.HTTPError
+// @Failure      500  {object}  error_handler.HTTPError
+// @Router       /artists/{id} [get]
+func (h *ArtistHandler) Get(ctx *gin.Context) {
+	id, err := getIDParam(ctx)
+	if err != nil {
+		h.lg.Warn("artist handler: get error", zap.Error(err))
+		error_handler.NewError(ctx, err)
+		return
+	}
+
+	artist, err := h.artistUsecase.Get(ctx, id)
+	if err != nil {
+		h.lg.Warn("artist handler: get error", zap.Error(err))
+		error_handler.NewError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, toArtistResponse(artist))
+}
+
+// GetAll godoc
+// @Summary      Get artists with limit and offset
+// @Description  get artists with limit and offset
+// @Tags         artists
+// @Produce      json
+// @Param        limit    query     string  true  "artists on page"
+// @Param        offset    query     string  true  "page"
+// @Success      200  {object}  domain.GetArtistsResponse
+// @Failure      400  {object}  error_handler.HTTPError
+// @Failure      500  {object}  error_handler.HTTPError
+// @Router       /artists [get]
+func (h *ArtistHandler) GetAll(ctx *gin.Context) {
+	limit, offset, err := getPageParams(ctx)
+	if err != nil {
+		h.lg.Warn("artist handler: get all error", zap.Error(err))
+		error_handler.NewError(ctx, err)
+		return
+	}
+
+	artists, err := h.artistUsecase.GetAll(ctx, limit, offset)
+	if err != nil {
+		h.lg.Warn("artist handler: get all error", zap.Error(err))
+		error_handler.NewError(ctx, err)
+		return
+	}
+
+	artistsResponse := domain.GetArtistsResponse{Artists: make([]domain.ArtistResponse, 0)}
+	for _, a := range artists {
+		artistsResponse.Artists = append(artistsResponse.Artists, toArtistResponse(a))
+	}
+
+	ctx.JSON(http.StatusOK, artistsResponse)
+}
+
+// GetSongs godoc
+// @Summary      Get artist songs
+// @Description  get artist songs with limit and offset
+// @Tags         artists
+// @Produce      json
+// @Param        id    path     int  true  "id of artist"
+// @Param        limit    query     string  true  "songs on page"
+// @Param        offset    query     string  true  "page"
+// @Success      200  {object}  domain.GetSongsResponse
+// @Failure      400  {object}  error_handler.HTTPError
+// @Failure      404  {object}  error_handler.HTTPError
+// @Failure      500  {object}  error_handler.HTTPError
+// @Router       /artists/{id}/songs [get]
+func (h *ArtistHandler) GetSongs(ctx *gin.Context) {
+	id, err := getIDParam(ctx)
+	if err != nil {
+		h.lg.Warn("artist handler: get songs error", zap.Error(err))
+		error_handler.NewError(ctx, err)
+		return
+	}
+
+	limit, offset, err := getPageParams(ctx)
+	if err != nil {
+		h.lg.Warn("artist handler: get songs error", zap.Error(err))
+		error_handler.NewError(ctx, err)
+		return
+	}
+
+	songs, err := h.artistUsecase.GetSongs(ctx, id, limit, offset)
+	if err != nil {
+		h.lg.Warn("artist handler: get songs error", zap.Error(err))
+		error_handler.NewError(ctx, err)
+		return
+	}
+
+	songsResponse := domain.GetSongsResponse{Songs: make([]domain.CreateSongResponse, 0)}
+	for _, s := range songs {
+		songsResponse.Songs = append(songsResponse.Songs, toSongResponse(s))
+	}
+
+	ctx.JSON(http.StatusOK, songsResponse)
+}
@@ -0,0 +1,192 @@
+package handlers
+
+import (
+	"github.com/NastyaAR/music_library/internal/domain"
+	"github.com/NastyaAR/music_library/internal/pkg/error_handler"
+	"github.com/NastyaAR/music_library/internal/pkg/ratelimit"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const apiKeyHeader = "X-API-Key"
+
+// AuthMiddleware identifies callers by a bearer access token or by an
+// api key in X-API-Key. Every api key has its own requests per minute,
+// failed sign ins are limited per ip by the failures budget.
+type AuthMiddleware struct {
+	users    domain.UserUsecase
+	apiKeys  domain.APIKeyUsecase
+	limits   ratelimit.Store
+	failures ratelimit.Budget
+	lg       *zap.Logger
+}
+
+func NewAuthMiddleware(users domain.UserUsecase, apiKeys domain.APIKeyUsecase,
+	limits ratelimit.Store, failures ratelimit.Budget, lg *zap.Logger) *AuthMiddleware {
+	return &AuthMiddleware{
+		users:    users,
+		apiKeys:  apiKeys,
+		limits:   limits,
+		failures: failures,
+		lg:       lg,
+	}
+}
+
+func bearerToken(ctx *gin.Context) (string, bool) {
+	header := ctx.GetHeader("Authorization")
+	scheme, token, ok := strings.Cut(header, " ")
+	if !ok || !strings.EqualFold(scheme, "Bearer") || strings.TrimSpace(token) == "" {
+		return "", false
+	}
+	return strings.TrimSpace(token), true
+}
+
+func unauthorized(ctx *gin.Context, err error) {
+	ctx.Header("WWW-Authenticate", `Bearer realm="music_library"`)
+	error_handler.NewError(ctx, err)
+	ctx.Abort()
+}
+
+func failureKey(ctx *gin.Context) string {
+	return "auth_failure:ip:" + ctx.ClientIP()
+}
+
+// blocked rejects requests from an ip that ran out of failed sign ins,
+// before the credentials are checked, so they can not be guessed at the
+// speed of the api.
+func (m *AuthMiddleware) blocked(ctx *gin.Context) bool {
+	res, err := m.limits.Check(ctx, failureKey(ctx), m.failures)
+	if err != nil {
+		// a broken limit store should not stop the api
+		m.lg.Warn("auth middleware: failure limit error", zap.Error(err))
+		return false
+	}
+	if res.Allowed {
+		return false
+	}
+
+	m.lg.Warn("auth middleware: too many failures", zap.String("ip", ctx.ClientIP()))
+	ctx.Header("Retry-After", seconds(res.RetryAfter))
+	error_handler.NewError(ctx, domain.ErrRateLimited)
+	ctx.Abort()
+	return true
+}
+
+// fail counts a failed sign in of the ip and rejects the request.
+func (m *AuthMiddleware) fail(ctx *gin.Context, err error) {
+	if _, takeErr := m.limits.Take(ctx, failureKey(ctx), m.failures); takeErr != nil {
+		m.lg.Warn("auth middleware: failure limit error", zap.Error(takeErr))
+	}
+	unauthorized(ctx, err)
+}
+
+func isRead(method string) bool {
+	return method == http.MethodGet || method == http.MethodHead
+}
+
+func (m *AuthMiddleware) authenticateKey(ctx *gin.Context, key string) (domain.Identity, bool) {
+	identity, err := m.apiKeys.Authenticate(ctx, key)
+	if err != nil {
+		m.lg.Warn("auth middleware: bad api key", zap.Error(err))
+		m.fail(ctx, err)
+		return domain.Identity{}, false
+	}
+
+	res, err := m.limits.Take(ctx, "api_key:"+strconv.FormatInt(identity.APIKeyID, 10),
+		ratelimit.Budget{Limit: identity.RateLimit, Period: time.Minute})
+	if err != nil {
+		// a broken limit store should not stop the api
+		m.lg.Warn("auth middleware: api key rate limit error", zap.Error(err))
+		res.Allowed = true
+	}
+	if !res.Allowed {
+		m.lg.Warn("auth middleware: api key rate limited", zap.Int64("key", identity.APIKeyID))
+		ctx.Header("Retry-After", seconds(res.RetryAfter))
+		error_handler.NewError(ctx, domain.ErrRateLimited)
+		ctx.Abort()
+		return domain.Identity{}, false
+	}
+
+	// writes are checked against the scopes in the usecases
+	if isRead(ctx.Request.Method) && !identity.HasScope(domain.ScopeSongsRead) {
+		m.lg.Warn("auth middleware: api key without read scope", zap.Int64("key", identity.APIKeyID))
+		error_handler.NewError(ctx, domain.ErrForbidden)
+		ctx.Abort()
+		return domain.Identity{}, false
+	}
+
+	return identity, true
+}
+
+// Authenticate puts the identity of a valid access token or api key into
+// the request context. A request without them passes as anonymous, bad
+// ones are rejected.
+func (m *AuthMiddleware) Authenticate() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		var identity domain.Identity
+
+		key := ctx.GetHeader(apiKeyHeader)
+		token, hasToken := bearerToken(ctx)
+		if (key != "" || hasToken) && m.blocked(ctx) {
+			return
+		}
+
+		if key != "" {
+			var ok bool
+			identity, ok = m.authenticateKey(ctx, key)
+			if !ok {
+				return
+			}
+		} else if hasToken {
+			var err error
+			identity, err = m.users.Authenticate(ctx, token)
+			if err != nil {
+				m.lg.Warn("auth middleware: bad token", zap.Error(err))
+				m.fail(ctx, err)
+				return
+			}
+		} else {
+			ctx.Next()
+			return
+		}
+
+		ctx.Request = ctx.Request.WithContext(domain.WithIdentity(ctx.Request.Context(), identity))
+		ctx.Next()
+	}
+}
+
+// SignIn guards the routes that take passwords or refresh tokens with the
+// failures budget of the ip. It rejects a blocked ip before the handler
+// checks anything, and a 401 from the handler counts as a failure.
+func (m *AuthMiddleware) SignIn() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		if m.blocked(ctx) {
+			return
+		}
+
+		ctx.Next()
+
+		if ctx.Writer.Status() == http.StatusUnauthorized {
+			if _, err := m.limits.Take(ctx, failureKey(ctx), m.failures); err != nil {
+				m.lg.Warn("auth middleware: failure limit error", zap.Error(err))
+			}
+		}
+	}
+}
+
+// Required rejects anonymous requests, it goes after Authenticate.
+func (m *AuthMiddleware) Required() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		if _, ok := domain.IdentityFrom(ctx.Request.Context()); !ok {
+			m.lg.Warn("auth middleware: anonymous request")
+			unauthorized(ctx, domain.ErrUnauthorized)
+			return
+		}
+
+		ctx.Next()
+	}
+}
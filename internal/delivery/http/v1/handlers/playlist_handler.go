@@ -0,0 +1,352 @@
+package handlers
+
+import (
+	"github.com/NastyaAR/music_library/internal/domain"
+	"github.com/NastyaAR/music_library/internal/pkg/error_handler"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+type PlaylistHandler struct {
+	playlistUsecase domain.PlaylistUsecase
+	lg              *zap.Logger
+}
+
+func NewPlaylistHandler(u domain.PlaylistUsecase, lg *zap.Logger) *PlaylistHandler {
+	return &PlaylistHandler{
+		playlistUsecase: u,
+		lg:              lg,
+	}
+}
+
+func getItemParam(ctx *gin.Context) (int64, error) {
+	item, err := strconv.ParseInt(ctx.Param("item"), 10, 64)
+	if err != nil || item <= 0 {
+		return 0, domain.ErrBadID
+	}
+
+	return item, nil
+}
+
+func toPlaylistResponse(playlist domain.Playlist) domain.PlaylistResponse {
+	playlistResponse := domain.PlaylistResponse{
+		ID:        playlist.ID,
+		OwnerID:   playlist.UserID,
+		Name:      playlist.Name,
+		Public:    playlist.Public,
+		CreatedAt: playlist.CreatedAt.Format(time.RFC3339),
+		UpdatedAt: playlist.UpdatedAt.Format(time.RFC3339),
+	}
+	for _, item := range playlist.Items {
+		playlistResponse.Items = append(playlistResponse.Items, domain.PlaylistItemResponse{
+			ID:       item.ID,
+			Position: item.Position,
+			AddedAt:  item.AddedAt.Format(time.RFC3339),
+			Song:     toSongResponse(item.Song),
+		})
+	}
+	return playlistResponse
+}
+
+// Create godoc
+// @Summary      Create playlist
+// @Description  create an empty playlist of the current user, private unless public is set
+// @Tags         playlists
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Security     ApiKeyAuth
+// @Param        request body domain.CreatePlaylistRequest true "name and sharing"
+// @Success      201  {object}  domain.PlaylistResponse
+// @Failure      400  {object}  error_handler.HTTPError
+// @Failure      401  {object}  error_handler.HTTPError
+// @Failure      403  {object}  error_handler.HTTPError
+// @Failure      500  {object}  error_handler.HTTPError
+// @Router       /playlists [post]
+func (h *PlaylistHandler) Create(ctx *gin.Context) {
+	var req domain.CreatePlaylistRequest
+	err := ctx.ShouldBindJSON(&req)
+	if err != nil {
+		h.lg.Warn("playlist handler: create error: bad body", zap.Error(err))
+		error_handler.NewError(ctx, domain.ErrBadRequestBody)
+		return
+	}
+
+	playlist, err := h.playlistUsecase.Create(ctx, req.Name, req.Public)
+	if err != nil {
+		h.lg.Warn("playlist handler: create error", zap.Error(err))
+		error_handler.NewError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, toPlaylistResponse(playlist))
+}
+
+// GetAll godoc
+// @Summary      Get own playlists
+// @Description  get playlists of the current user without their items
+// @Tags         playlists
+// @Produce      json
+// @Security     BearerAuth
+// @Security     ApiKeyAuth
+// @Success      200  {object}  domain.GetPlaylistsResponse
+// @Failure      401  {object}  error_handler.HTTPError
+// @Failure      500  {object}  error_handler.HTTPError
+// @Router       /playlists [get]
+func (h *PlaylistHandler) GetAll(ctx *gin.Context) {
+	playlists, err := h.playlistUsecase.GetAll(ctx)
+	if err != nil {
+		h.lg.Warn("playlist handler: get all error", zap.Error(err))
+		error_handler.NewError(ctx, err)
+		return
+	}
+
+	playlistsResponse := domain.GetPlaylistsResponse{Playlists: make([]domain.PlaylistResponse, 0)}
+	for _, p := range playlists {
+		playlistsResponse.Playlists = append(playlistsResponse.Playlists, toPlaylistResponse(p))
+	}
+
+	ctx.JSON(http.StatusOK, playlistsResponse)
+}
+
+// Get godoc
+// @Summary      Get playlist
+// @Description  get a playlist with its songs in order, private playlists only for their owner
+// @Tags         playlists
+// @Produce      json
+// @Param        id    path     int  true  "id of playlist"
+// @Success      200  {object}  domain.PlaylistResponse
+// @Failure      400  {object}  error_handler.HTTPError
+// @Failure      404  {object}  error_handler.HTTPError
+// @Failure      500  {object}  error_handler.HTTPError
+// @Router       /playlists/{id} [get]
+func (h *PlaylistHandler) Get(ctx *gin.Context) {
+	id, err := getIDParam(ctx)
+	if err != nil {
+		h.lg.Warn("playlist handler: get error", zap.Error(err))
+		error_handler.NewError(ctx, err)
+		return
+	}
+
+	playlist, err := h.playlistUsecase.Get(ctx, id)
+	if err != nil {
+		h.lg.Warn("playlist handler: get error", zap.Error(err))
+		error_handler.NewError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, toPlaylistResponse(playlist))
+}
+
+// Update godoc
+// @Summary      Rename or share playlist
+// @Description  change name and public flag of an own playlist, absent fields stay as they are
+// @Tags         playlists
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Security     ApiKeyAuth
+// @Param        id    path     int  true  "id of playlist"
+// @Param        request body domain.UpdatePlaylistRequest true "new name and sharing"
+// @Success      200  {object}  domain.PlaylistResponse
+// @Failure      400  {object}  error_handler.HTTPError
+// @Failure      401  {object}  error_handler.HTTPError
+// @Failure      403  {object}  error_handler.HTTPError
+// @Failure      404  {object}  error_handler.HTTPError
+// @Failure      500  {object}  error_handler.HTTPError
+// @Router       /playlists/{id} [patch]
+func (h *PlaylistHandler) Update(ctx *gin.Context) {
+	id, err := getIDParam(ctx)
+	if err != nil {
+		h.lg.Warn("playlist handler: update error", zap.Error(err))
+		error_handler.NewError(ctx, err)
+		return
+	}
+
+	var req domain.UpdatePlaylistRequest
+	err = ctx.ShouldBindJSON(&req)
+	if err != nil {
+		h.lg.Warn("playlist handler: update error: bad body", zap.Error(err))
+		error_handler.NewError(ctx, domain.ErrBadRequestBody)
+		return
+	}
+
+	playlist, err := h.playlistUsecase.Update(ctx, id, req.Name, req.Public)
+	if err != nil {
+		h.lg.Warn("playlist handler: update error", zap.Error(err))
+		error_handler.NewError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, toPlaylistResponse(playlist))
+}
+
+// Delete godoc
+// @Summary      Delete playlist
+// @Description  delete an own playlist, the songs stay in the library
+// @Tags         playlists
+// @Security     BearerAuth
+// @Security     ApiKeyAuth
+// @Param        id    path     int  true  "id of playlist"
+// @Success      204
+// @Failure      400  {object}  error_handler.HTTPError
+// @Failure      401  {object}  error_handler.HTTPError
+// @Failure      403  {object}  error_handler.HTTPError
+// @Failure      404  {object}  error_handler.HTTPError
+// @Failure      500  {object}  error_handler.HTTPError
+// @Router       /playlists/{id} [delete]
+func (h *PlaylistHandler) Delete(ctx *gin.Context) {
+	id, err := getIDParam(ctx)
+	if err != nil {
+		h.lg.Warn("playlist handler: delete error", zap.Error(err))
+		error_handler.NewError(ctx, err)
+		return
+	}
+
+	err = h.playlistUsecase.Delete(ctx, id)
+	if err != nil {
+		h.lg.Warn("playlist handler: delete error", zap.Error(err))
+		error_handler.NewError(ctx, err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+// AddItem godoc
+// @Summary      Add song to playlist
+// @Description  insert a song at a position (from 1) of an own playlist, at the end when position is absent
+// @Tags         playlists
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Security     ApiKeyAuth
+// @Param        id    path     int  true  "id of playlist"
+// @Param        request body domain.AddPlaylistItemRequest true "song and position"
+// @Success      200  {object}  domain.PlaylistResponse
+// @Failure      400  {object}  error_handler.HTTPError
+// @Failure      401  {object}  error_handler.HTTPError
+// @Failure      403  {object}  error_handler.HTTPError
+// @Failure      404  {object}  error_handler.HTTPError
+// @Failure      500  {object}  error_handler.HTTPError
+// @Router       /playlists/{id}/items [post]
+func (h *PlaylistHandler) AddItem(ctx *gin.Context) {
+	id, err := getIDParam(ctx)
+	if err != nil {
+		h.lg.Warn("playlist handler: add item error", zap.Error(err))
+		error_handler.NewError(ctx, err)
+		return
+	}
+
+	var req domain.AddPlaylistItemRequest
+	err = ctx.ShouldBindJSON(&req)
+	if err != nil {
+		h.lg.Warn("playlist handler: add item error: bad body", zap.Error(err))
+		error_handler.NewError(ctx, domain.ErrBadRequestBody)
+		return
+	}
+
+	playlist, err := h.playlistUsecase.AddItem(ctx, id, req.SongID, req.Position)
+	if err != nil {
+		h.lg.Warn("playlist handler: add item error", zap.Error(err))
+		error_handler.NewError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, toPlaylistResponse(playlist))
+}
+
+// MoveItem godoc
+// @Summary      Move playlist item
+// @Description  move an item of an own playlist to a position (from 1), the items between shift
+// @Tags         playlists
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Security     ApiKeyAuth
+// @Param        id    path     int  true  "id of playlist"
+// @Param        item    path     int  true  "id of playlist item"
+// @Param        request body domain.MovePlaylistItemRequest true "new position"
+// @Success      200  {object}  domain.PlaylistResponse
+// @Failure      400  {object}  error_handler.HTTPError
+// @Failure      401  {object}  error_handler.HTTPError
+// @Failure      403  {object}  error_handler.HTTPError
+// @Failure      404  {object}  error_handler.HTTPError
+// @Failure      500  {object}  error_handler.HTTPError
+// @Router       /playlists/{id}/items/{item} [patch]
+func (h *PlaylistHandler) MoveItem(ctx *gin.Context) {
+	id, err := getIDParam(ctx)
+	if err != nil {
+		h.lg.Warn("playlist handler: move item error", zap.Error(err))
+		error_handler.NewError(ctx, err)
+		return
+	}
+
+	item, err := getItemParam(ctx)
+	if err != nil {
+		h.lg.Warn("playlist handler: move item error", zap.Error(err))
+		error_handler.NewError(ctx, err)
+		return
+	}
+
+	var req domain.MovePlaylistItemRequest
+	err = ctx.ShouldBindJSON(&req)
+	if err != nil {
+		h.lg.Warn("playlist handler: move item error: bad body", zap.Error(err))
+		error_handler.NewError(ctx, domain.ErrBadRequestBody)
+		return
+	}
+
+	playlist, err := h.playlistUsecase.MoveItem(ctx, id, item, req.Position)
+	if err != nil {
+		h.lg.Warn("playlist handler: move item error", zap.Error(err))
+		error_handler.NewError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, toPlaylistResponse(playlist))
+}
+
+// RemoveItem godoc
+// @Summary      Remove song from playlist
+// @Description  remove an item of an own playlist, the items after it move up
+// @Tags         playlists
+// @Produce      json
+// @Security     BearerAuth
+// @Security     ApiKeyAuth
+// @Param        id    path     int  true  "id of playlist"
+// @Param        item    path     int  true  "id of playlist item"
+// @Success      200  {object}  domain.PlaylistResponse
+// @Failure      400  {object}  error_handler.HTTPError
+// @Failure      401  {object}  error_handler.HTTPError
+// @Failure      403  {object}  error_handler.HTTPError
+// @Failure      404  {object}  error_handler.HTTPError
+// @Failure      500  {object}  error_handler.HTTPError
+// @Router       /playlists/{id}/items/{item} [delete]
+func (h *PlaylistHandler) RemoveItem(ctx *gin.Context) {
+	id, err := getIDParam(ctx)
+	if err != nil {
+		h.lg.Warn("playlist handler: remove item error", zap.Error(err))
+		error_handler.NewError(ctx, err)
+		return
+	}
+
+	item, err := getItemParam(ctx)
+	if err != nil {
+		h.lg.Warn("playlist handler: remove item error", zap.Error(err))
+		error_handler.NewError(ctx, err)
+		return
+	}
+
+	playlist, err := h.playlistUsecase.RemoveItem(ctx, id, item)
+	if err != nil {
+		h.lg.Warn("playlist handler: remove item error", zap.Error(err))
+		error_handler.NewError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, toPlaylistResponse(playlist))
+}
@@ -0,0 +1,76 @@
+package handlers
+
+import (
+	"github.com/NastyaAR/music_library/internal/domain"
+	"github.com/NastyaAR/music_library/internal/pkg/error_handler"
+	"github.com/NastyaAR/music_library/internal/pkg/ratelimit"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+	"math"
+	"strconv"
+	"time"
+)
+
+func seconds(d time.Duration) string {
+	return strconv.Itoa(int(math.Ceil(d.Seconds())))
+}
+
+// clientKey is the api key or the user of the request and the ip for
+// anonymous requests.
+func clientKey(ctx *gin.Context) string {
+	identity, ok := domain.IdentityFrom(ctx.Request.Context())
+	switch {
+	case ok && identity.APIKeyID != 0:
+		return "api_key:" + strconv.FormatInt(identity.APIKeyID, 10)
+	case ok:
+		return "user:" + strconv.FormatInt(identity.UserID, 10)
+	default:
+		return "ip:" + ctx.ClientIP()
+	}
+}
+
+// RateLimit gives every client a token bucket per route, routes are
+// "METHOD /path" as registered in the router. Routes missing in routes
+// share the default budget. It goes after the auth middleware, so that
+// signed in clients are counted by user and not by ip.
+func RateLimit(store ratelimit.Store, defaultBudget ratelimit.Budget,
+	routes map[string]ratelimit.Budget, lg *zap.Logger) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		route := ctx.Request.Method + " " + ctx.FullPath()
+		budget, ok := routes[route]
+		if !ok {
+			route = "default"
+			budget = defaultBudget
+		}
+
+		if budget.Unlimited() {
+			ctx.Next()
+			return
+		}
+
+		client := clientKey(ctx)
+		res, err := store.Take(ctx, route+":"+client, budget)
+		if err != nil {
+			// a broken limit store should not stop the api
+			lg.Warn("rate limit middleware: store error", zap.Error(err))
+			ctx.Next()
+			return
+		}
+
+		ctx.Header("RateLimit-Policy", strconv.Itoa(budget.Limit)+";w="+seconds(budget.Period))
+		ctx.Header("RateLimit-Limit", strconv.Itoa(res.Limit))
+		ctx.Header("RateLimit-Remaining", strconv.Itoa(res.Remaining))
+		ctx.Header("RateLimit-Reset", seconds(res.Reset))
+
+		if !res.Allowed {
+			lg.Warn("rate limit middleware: limited", zap.String("route", route),
+				zap.String("client", client))
+			ctx.Header("Retry-After", seconds(res.RetryAfter))
+			error_handler.NewError(ctx, domain.ErrRateLimited)
+			ctx.Abort()
+			return
+		}
+
+		ctx.Next()
+	}
+}
@@ -0,0 +1,290 @@
+package handlers
+
+import (
+	"context"
+	"github.com/NastyaAR/music_library/internal/domain"
+	"github.com/NastyaAR/music_library/internal/pkg/ratelimit"
+	"github.com/alicebob/miniredis/v2"
+	"github.com/gin-gonic/gin"
+	"github.com/redis/go-redis/v9"
+	"go.uber.org/zap"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+// fakeUsers knows one access token, the rest of domain.UserUsecase is not
+// used by the middlewares.
+type fakeUsers struct {
+	domain.UserUsecase
+}
+
+func (fakeUsers) Authenticate(ctx context.Context, accessToken string) (domain.Identity, error) {
+	if accessToken != "user-token" {
+		return domain.Identity{}, domain.ErrInvalidToken
+	}
+	return domain.Identity{UserID: 7, Username: "user", Role: domain.RoleEditor}, nil
+}
+
+func (fakeUsers) Login(ctx context.Context, username string, password string) (domain.TokenPair, error) {
+	if username != "user" || password != "password123" {
+		return domain.TokenPair{}, domain.ErrInvalidCredentials
+	}
+	return domain.TokenPair{AccessToken: "user-token"}, nil
+}
+
+// fakeAPIKeys knows one api key with a limit of 2 requests per minute.
+type fakeAPIKeys struct {
+	domain.APIKeyUsecase
+}
+
+func (fakeAPIKeys) Authenticate(ctx context.Context, key string) (domain.Identity, error) {
+	if key != "mlk_key" {
+		return domain.Identity{}, domain.ErrInvalidAPIKey
+	}
+	return domain.Identity{
+		UserID:    7,
+		Username:  "user",
+		Role:      domain.RoleEditor,
+		APIKeyID:  3,
+		Scopes:    []domain.Scope{domain.ScopeSongsRead},
+		RateLimit: 2,
+	}, nil
+}
+
+func newLimitedRouter(t *testing.T) *gin.Engine {
+	return newLimitedRouterBehind(t, nil)
+}
+
+// newLimitedRouterBehind trusts X-Forwarded-For from proxies only, as the
+// app does.
+func newLimitedRouterBehind(t *testing.T, proxies []string) *gin.Engine {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+
+	server := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: server.Addr()})
+	t.Cleanup(func() { _ = client.Close() })
+
+	store := ratelimit.NewRedisStore(client, "test:")
+	lg := zap.NewNop()
+	auth := NewAuthMiddleware(fakeUsers{}, fakeAPIKeys{}, store,
+		ratelimit.Budget{Limit: 2, Period: time.Minute}, lg)
+
+	router := gin.New()
+	if err := router.SetTrustedProxies(proxies); err != nil {
+		t.Fatal(err)
+	}
+	router.Use(auth.Authenticate(), RateLimit(store, ratelimit.Budget{Limit: 5, Period: time.Minute},
+		map[string]ratelimit.Budget{
+			"GET /search": {Limit: 1, Period: time.Minute},
+		}, lg))
+
+	ok := func(ctx *gin.Context) { ctx.Status(http.StatusOK) }
+	router.GET("/songs", ok)
+	router.GET("/search", ok)
+	router.POST("/auth/login", auth.SignIn(), NewUserHandler(fakeUsers{}, lg).Login)
+	return router
+}
+
+func doRequest(router *gin.Engine, path string, header string, value string) *httptest.ResponseRecorder {
+	headers := map[string]string{}
+	if header != "" {
+		headers[header] = value
+	}
+	return doRequestWith(router, path, headers)
+}
+
+func doRequestWith(router *gin.Engine, path string, headers map[string]string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, path, nil)
+	req.RemoteAddr = "192.0.2.1:1234"
+	for header, value := range headers {
+		req.Header.Set(header, value)
+	}
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestRateLimitDefaultBudget(t *testing.T) {
+	router := newLimitedRouter(t)
+
+	for i := 0; i < 5; i++ {
+		rec := doRequest(router, "/songs", "", "")
+		if rec.Code != http.StatusOK {
+			t.Fatalf("request #%d status = %d, want 200", i, rec.Code)
+		}
+	}
+
+	rec := doRequest(router, "/songs", "", "")
+	if rec.Code != http.StatusTooManyRequests {
+		t.Fatalf("status = %d, want 429", rec.Code)
+	}
+	if got := rec.Header().Get("Retry-After"); got != "12" {
+		t.Errorf("Retry-After = %q, want 12", got)
+	}
+	if got := rec.Header().Get("RateLimit-Policy"); got != "5;w=60" {
+		t.Errorf("RateLimit-Policy = %q, want 5;w=60", got)
+	}
+	if got := rec.Header().Get("RateLimit-Remaining"); got != "0" {
+		t.Errorf("RateLimit-Remaining = %q, want 0", got)
+	}
+
+	// signed in users have their own budget
+	if rec := doRequest(router, "/songs", "Authorization", "Bearer user-token"); rec.Code != http.StatusOK {
+		t.Errorf("user request status = %d, want 200", rec.Code)
+	}
+}
+
+func TestRateLimitRouteBudget(t *testing.T) {
+	router := newLimitedRouter(t)
+
+	if rec := doRequest(router, "/search", "", ""); rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, want 200", rec.Code)
+	}
+
+	rec := doRequest(router, "/search", "", "")
+	if rec.Code != http.StatusTooManyRequests {
+		t.Fatalf("status = %d, want 429", rec.Code)
+	}
+	if got := rec.Header().Get("Retry-After"); got != "60" {
+		t.Errorf("Retry-After = %q, want 60", got)
+	}
+
+	// the route budget does not take from the default one
+	if rec := doRequest(router, "/songs", "", ""); rec.Code != http.StatusOK {
+		t.Errorf("default route status = %d, want 200", rec.Code)
+	}
+}
+
+func TestRateLimitAPIKeyBudget(t *testing.T) {
+	router := newLimitedRouter(t)
+
+	for i := 0; i < 2; i++ {
+		rec := doRequest(router, "/songs", apiKeyHeader, "mlk_key")
+		if rec.Code != http.StatusOK {
+			t.Fatalf("request #%d status = %d, want 200", i, rec.Code)
+		}
+	}
+
+	// the key limit of 2 wins over the default of 5
+	rec := doRequest(router, "/songs", apiKeyHeader, "mlk_key")
+	if rec.Code != http.StatusTooManyRequests {
+		t.Fatalf("status = %d, want 429", rec.Code)
+	}
+	if got := rec.Header().Get("Retry-After"); got != "30" {
+		t.Errorf("Retry-After = %q, want 30", got)
+	}
+}
+
+func TestRateLimitAuthFailures(t *testing.T) {
+	router := newLimitedRouter(t)
+
+	for i := 0; i < 2; i++ {
+		rec := doRequest(router, "/songs", "Authorization", "Bearer wrong-token")
+		if rec.Code != http.StatusUnauthorized {
+			t.Fatalf("request #%d status = %d, want 401", i, rec.Code)
+		}
+	}
+
+	// the ip is out of failures, even a good token is not checked now
+	rec := doRequest(router, "/songs", "Authorization", "Bearer user-token")
+	if rec.Code != http.StatusTooManyRequests {
+		t.Fatalf("status = %d, want 429", rec.Code)
+	}
+	if got := rec.Header().Get("Retry-After"); got != "30" {
+		t.Errorf("Retry-After = %q, want 30", got)
+	}
+
+	rec = doRequest(router, "/songs", apiKeyHeader, "mlk_wrong")
+	if rec.Code != http.StatusTooManyRequests {
+		t.Errorf("api key status = %d, want 429", rec.Code)
+	}
+
+	// anonymous requests only count against the route budgets
+	if rec := doRequest(router, "/songs", "", ""); rec.Code != http.StatusOK {
+		t.Errorf("anonymous status = %d, want 200", rec.Code)
+	}
+}
+
+func TestRateLimitIgnoresForwardedForFromClients(t *testing.T) {
+	router := newLimitedRouter(t)
+
+	if rec := doRequest(router, "/search", "X-Forwarded-For", "198.51.100.1"); rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, want 200", rec.Code)
+	}
+
+	// a made up address does not give a new bucket
+	rec := doRequest(router, "/search", "X-Forwarded-For", "198.51.100.2")
+	if rec.Code != http.StatusTooManyRequests {
+		t.Fatalf("status with another X-Forwarded-For = %d, want 429", rec.Code)
+	}
+
+	for i := 0; i < 2; i++ {
+		doRequestWith(router, "/songs", map[string]string{
+			"Authorization":   "Bearer wrong-token",
+			"X-Forwarded-For": "198.51.100." + strconv.Itoa(10+i),
+		})
+	}
+	rec = doRequestWith(router, "/songs", map[string]string{
+		"Authorization":   "Bearer user-token",
+		"X-Forwarded-For": "198.51.100.20",
+	})
+	if rec.Code != http.StatusTooManyRequests {
+		t.Errorf("sign in after failures status = %d, want 429", rec.Code)
+	}
+}
+
+func TestRateLimitTrustedProxy(t *testing.T) {
+	router := newLimitedRouterBehind(t, []string{"192.0.2.1"})
+
+	for _, client := range []string{"198.51.100.1", "198.51.100.2"} {
+		rec := doRequest(router, "/search", "X-Forwarded-For", client)
+		if rec.Code != http.StatusOK {
+			t.Errorf("client %s status = %d, want 200", client, rec.Code)
+		}
+	}
+}
+
+func login(router *gin.Engine, password string) *httptest.ResponseRecorder {
+	body := `{"username":"user","password":"` + password + `"}`
+	req := httptest.NewRequest(http.MethodPost, "/auth/login", strings.NewReader(body))
+	req.RemoteAddr = "192.0.2.1:1234"
+	req.Header.Set("Content-Type", "application/json")
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestRateLimitWrongPasswords(t *testing.T) {
+	router := newLimitedRouter(t)
+
+	if rec := login(router, "password123"); rec.Code != http.StatusOK {
+		t.Fatalf("login status = %d, want 200", rec.Code)
+	}
+
+	for i := 0; i < 2; i++ {
+		if rec := login(router, "guess"); rec.Code != http.StatusUnauthorized {
+			t.Fatalf("wrong password #%d status = %d, want 401", i, rec.Code)
+		}
+	}
+
+	// the password is not checked once the ip is out of failures
+	rec := login(router, "password123")
+	if rec.Code != http.StatusTooManyRequests {
+		t.Fatalf("login after failures status = %d, want 429", rec.Code)
+	}
+	if rec.Header().Get("Retry-After") == "" {
+		t.Errorf("no Retry-After")
+	}
+
+	// bad tokens share the same budget
+	if rec := doRequest(router, "/songs", "Authorization", "Bearer user-token"); rec.Code != http.StatusTooManyRequests {
+		t.Errorf("token after failed logins status = %d, want 429", rec.Code)
+	}
+}
@@ -0,0 +1,59 @@
+package handlers
+
+import (
+	"github.com/NastyaAR/music_library/internal/domain"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// songETag is a strong validator of the song, the song version in quotes.
+func songETag(song domain.Song) string {
+	return `"` + strconv.Itoa(song.Version) + `"`
+}
+
+func setETag(ctx *gin.Context, song domain.Song) {
+	ctx.Header("ETag", songETag(song))
+}
+
+// ifMatchVersion reads the version the client expects from If-Match, zero
+// means no precondition. Weak or malformed tags can never match.
+func ifMatchVersion(ctx *gin.Context) (int, error) {
+	header := strings.TrimSpace(ctx.GetHeader("If-Match"))
+	if header == "" || header == "*" {
+		return 0, nil
+	}
+
+	if !strings.HasPrefix(header, `"`) || !strings.HasSuffix(header, `"`) || len(header) < 2 {
+		return 0, domain.ErrPreconditionFailed
+	}
+
+	version, err := strconv.Atoi(header[1 : len(header)-1])
+	if err != nil || version <= 0 {
+		return 0, domain.ErrPreconditionFailed
+	}
+
+	return version, nil
+}
+
+// notModified answers 304 when If-None-Match has the current tag of the
+// song, tags are compared weakly.
+func notModified(ctx *gin.Context, song domain.Song) bool {
+	header := ctx.GetHeader("If-None-Match")
+	if header == "" {
+		return false
+	}
+
+	etag := songETag(song)
+	for _, tag := range strings.Split(header, ",") {
+		tag = strings.TrimPrefix(strings.TrimSpace(tag), "W/")
+		if tag == "*" || tag == etag {
+			setETag(ctx, song)
+			ctx.Status(http.StatusNotModified)
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,149 @@
+package handlers
+
+import (
+	"compress/gzip"
+	"github.com/NastyaAR/music_library/internal/domain"
+	"github.com/NastyaAR/music_library/internal/pkg/error_handler"
+	"github.com/NastyaAR/music_library/internal/pkg/export"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// encodingWeight is the q-value of one Accept-Encoding entry, false for
+// a malformed one.
+func encodingWeight(params string) (float64, bool) {
+	q := 1.0
+	for _, param := range strings.Split(params, ";") {
+		name, value, ok := strings.Cut(param, "=")
+		if !ok || !strings.EqualFold(strings.TrimSpace(name), "q") {
+			continue
+		}
+
+		parsed, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+		if err != nil || parsed < 0 || parsed > 1 {
+			return 0, false
+		}
+		q = parsed
+	}
+	return q, true
+}
+
+// acceptsGzip reads an Accept-Encoding header: gzip, or * when gzip is not
+// listed, has to come with a q-value above 0.
+func acceptsGzip(header string) bool {
+	gzipQ, anyQ := -1.0, -1.0
+	for _, entry := range strings.Split(header, ",") {
+		coding, params, _ := strings.Cut(entry, ";")
+		q, ok := encodingWeight(params)
+		if !ok {
+			continue
+		}
+
+		switch strings.ToLower(strings.TrimSpace(coding)) {
+		case "gzip", "x-gzip":
+			gzipQ = q
+		case "*":
+			anyQ = q
+		}
+	}
+
+	if gzipQ >= 0 {
+		return gzipQ > 0
+	}
+	return anyQ > 0
+}
+
+// Export godoc
+// @Summary      Export songs
+// @Description  stream all songs matching the same filters as the songs listing, the body is gzip
+// @Description  compressed when the client sends Accept-Encoding: gzip (except xlsx, which is compressed already).
+// @Description  An error after the first row cuts the body short.
+// @Tags         songs
+// @Produce      json,application/x-ndjson,text/csv,application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+// @Param        format    query     string  false  "json (default), ndjson, csv or xlsx"
+// @Param        group    query     string  false  "group of song"
+// @Param        name    query     string  false  "name of song"
+// @Param        release_date    query     string  false  "release date, dd.mm.yyyy"
+// @Param        text    query     string  false  "text of song"
+// @Param        link    query     string  false  "link of song"
+// @Param        album_id    query     int  false  "id of album"
+// @Param        group_match    query     string  false  "group match mode: exact, prefix, contains, icase, similar"
+// @Param        name_match    query     string  false  "name match mode: exact, prefix, contains, icase, similar"
+// @Param        text_match    query     string  false  "text match mode: exact, prefix, contains, icase, similar"
+// @Param        link_match    query     string  false  "link match mode: exact, prefix, contains, icase, similar"
+// @Param        released_from    query     string  false  "release date lower bound, dd.mm.yyyy"
+// @Param        released_to    query     string  false  "release date upper bound, dd.mm.yyyy"
+// @Param        year    query     int  false  "release year"
+// @Param        decade    query     int  false  "release decade, e.g. 1990"
+// @Param        sort    query     string  false  "comma separated sort keys (id, group, name, release_date), minus for descending, e.g. -release_date,name"
+// @Success      200  {array}  domain.CreateSongResponse
+// @Failure      400  {object}  error_handler.HTTPError
+// @Failure      500  {object}  error_handler.HTTPError
+// @Router       /songs:export [get]
+func (h *SongHandler) Export(ctx *gin.Context) {
+	format := export.Format(ctx.DefaultQuery("format", string(export.JSON)))
+	if !format.IsValid() {
+		h.lg.Warn("song handler: export error: bad format")
+		error_handler.NewError(ctx, domain.ErrBadExportFormat)
+		return
+	}
+
+	filter, err := songFilterFromQuery(ctx.Request.URL.Query())
+	if err != nil {
+		h.lg.Warn("song handler: export error", zap.Error(err))
+		error_handler.NewError(ctx, err)
+		return
+	}
+
+	// The response starts with the first song, so that a failure before
+	// it still gets a proper error status.
+	var gz *gzip.Writer
+	var writer export.Writer
+	start := func() {
+		ctx.Header("Content-Type", format.ContentType())
+		ctx.Header("Content-Disposition", `attachment; filename="songs.`+string(format)+`"`)
+		ctx.Header("Vary", "Accept-Encoding")
+
+		var out io.Writer = ctx.Writer
+		if !format.Compressed() && acceptsGzip(ctx.GetHeader("Accept-Encoding")) {
+			ctx.Header("Content-Encoding", "gzip")
+			gz = gzip.NewWriter(ctx.Writer)
+			out = gz
+		}
+
+		ctx.Status(http.StatusOK)
+		writer = export.NewWriter(format, out)
+	}
+
+	err = h.songUsecase.Export(ctx, &filter, func(song domain.Song) error {
+		if writer == nil {
+			start()
+		}
+		return writer.Write(toSongResponse(song))
+	})
+	if err != nil && writer == nil {
+		h.lg.Warn("song handler: export error", zap.Error(err))
+		error_handler.NewError(ctx, err)
+		return
+	}
+	if err != nil {
+		h.lg.Warn("song handler: export interrupted", zap.Error(err))
+		ctx.Abort()
+		return
+	}
+
+	if writer == nil {
+		start()
+	}
+	err = writer.Close()
+	if err == nil && gz != nil {
+		err = gz.Close()
+	}
+	if err != nil {
+		h.lg.Warn("song handler: export error", zap.Error(err))
+	}
+}
@@ -0,0 +1,148 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"github.com/NastyaAR/music_library/internal/domain"
+	"github.com/NastyaAR/music_library/internal/pkg/date_validate"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+	"io"
+	"net/url"
+	"strconv"
+)
+
+var songFilterParams = []string{"group", "name", "release_date", "text", "link", "album_id"}
+
+func hasFilterParams(query url.Values) bool {
+	for _, param := range songFilterParams {
+		if query.Has(param) {
+			return true
+		}
+	}
+	return false
+}
+
+// readSongFilter builds the listing filter from query parameters. The JSON
+// body of a GET request is still accepted for old clients, but only when
+// no filter query parameter is given.
+func (h *SongHandler) readSongFilter(ctx *gin.Context) (domain.SongFilter, error) {
+	query := ctx.Request.URL.Query()
+
+	if !hasFilterParams(query) {
+		body, err := io.ReadAll(ctx.Request.Body)
+		if err != nil {
+			h.lg.Warn("song handler: read filter body error", zap.Error(err))
+			return domain.SongFilter{}, domain.ErrInternalServer
+		}
+
+		body = bytes.TrimSpace(body)
+		if len(body) > 0 {
+			var songRequest domain.UpdateSongRequest
+			err = json.Unmarshal(body, &songRequest)
+			if err != nil {
+				h.lg.Warn("song handler: bad filter body", zap.Error(err))
+				return domain.SongFilter{}, domain.ErrBadRequestBody
+			}
+
+			h.lg.Warn("song handler: deprecated filter in request body")
+			ctx.Header("Deprecation", "true")
+			ctx.Header("Warning", `299 - "filters in request body are deprecated, use query parameters"`)
+
+			query = withBodyFilter(query, &songRequest)
+		}
+	}
+
+	return songFilterFromQuery(query)
+}
+
+func withBodyFilter(query url.Values, songRequest *domain.UpdateSongRequest) url.Values {
+	merged := url.Values{}
+	for k, v := range query {
+		merged[k] = v
+	}
+
+	set := func(key string, value string) {
+		if value != "" {
+			merged.Set(key, value)
+		}
+	}
+	set("group", songRequest.Group)
+	set("name", songRequest.Name)
+	set("release_date", songRequest.ReleaseDate)
+	set("text", songRequest.Text)
+	set("link", songRequest.Link)
+	if songRequest.AlbumID != 0 {
+		merged.Set("album_id", strconv.FormatInt(songRequest.AlbumID, 10))
+	}
+
+	return merged
+}
+
+func songFilterFromQuery(query url.Values) (domain.SongFilter, error) {
+	filter := domain.SongFilter{
+		Group: domain.StringFilter{
+			Value: query.Get("group"),
+			Match: domain.MatchMode(query.Get("group_match")),
+		},
+		Name: domain.StringFilter{
+			Value: query.Get("name"),
+			Match: domain.MatchMode(query.Get("name_match")),
+		},
+		Text: domain.StringFilter{
+			Value: query.Get("text"),
+			Match: domain.MatchMode(query.Get("text_match")),
+		},
+		Link: domain.StringFilter{
+			Value: query.Get("link"),
+			Match: domain.MatchMode(query.Get("link_match")),
+		},
+		Sort: domain.ParseSort(query.Get("sort")),
+	}
+
+	var err error
+
+	if date := query.Get("release_date"); date != "" {
+		filter.ReleaseDate, err = date_validate.ParseDate(date)
+		if err != nil {
+			return domain.SongFilter{}, err
+		}
+	}
+
+	if albumID := query.Get("album_id"); albumID != "" {
+		filter.AlbumID, err = strconv.ParseInt(albumID, 10, 64)
+		if err != nil || filter.AlbumID <= 0 {
+			return domain.SongFilter{}, domain.ErrQueryParams
+		}
+	}
+
+	if from := query.Get("released_from"); from != "" {
+		filter.ReleasedFrom, err = date_validate.ParseDate(from)
+		if err != nil {
+			return domain.SongFilter{}, err
+		}
+	}
+
+	if to := query.Get("released_to"); to != "" {
+		filter.ReleasedTo, err = date_validate.ParseDate(to)
+		if err != nil {
+			return domain.SongFilter{}, err
+		}
+	}
+
+	if year := query.Get("year"); year != "" {
+		filter.Year, err = strconv.Atoi(year)
+		if err != nil {
+			return domain.SongFilter{}, domain.ErrBadReleaseRange
+		}
+	}
+
+	if decade := query.Get("decade"); decade != "" {
+		filter.Decade, err = strconv.Atoi(decade)
+		if err != nil {
+			return domain.SongFilter{}, domain.ErrBadReleaseRange
+		}
+	}
+
+	return filter, nil
+}
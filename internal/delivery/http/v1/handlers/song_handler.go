@@ -10,48 +10,56 @@ import (
 	"io"
 	"net/http"
 	"strconv"
-	"strings"
 	"time"
 )
 
 type SongHandler struct {
-	songUsecase domain.SongUsecase
-	lg          *zap.Logger
+	songUsecase    domain.SongUsecase
+	maxImportBytes int64
+	lg             *zap.Logger
 }
 
-func NewSongHandler(s domain.SongUsecase, lg *zap.Logger) *SongHandler {
+// NewSongHandler: import bodies over maxImportBytes are rejected with 413.
+func NewSongHandler(s domain.SongUsecase, maxImportBytes int64, lg *zap.Logger) *SongHandler {
 	return &SongHandler{
-		songUsecase: s,
-		lg:          lg,
+		songUsecase:    s,
+		maxImportBytes: maxImportBytes,
+		lg:             lg,
 	}
 }
 
-func getDate(timestamp time.Time) string {
-	date := strings.Split(timestamp.String(), " ")
-	parts := strings.Split(date[0], "-")
-
-	result := strings.Join([]string{parts[2], parts[1], parts[0]}, ".")
-	return result
+func toSongResponse(song domain.Song) domain.CreateSongResponse {
+	return domain.CreateSongResponse{
+		ID:          song.ID,
+		ArtistID:    song.ArtistID,
+		Group:       song.Group,
+		Name:        song.Name,
+		ReleaseDate: date_validate.FormatDate(song.ReleaseDate),
+		Text:        song.Text,
+		Link:        song.Link,
+		AlbumID:     song.AlbumID,
+		TrackNumber: song.TrackNumber,
+		Version:     song.Version,
+	}
 }
 
-func getDateFromUser(date string) (time.Time, error) {
-	if !date_validate.IsValidDate(date) {
-		return time.Time{}, domain.ErrBadReleaseDate
+func toCoupletResponse(c domain.Couplet) domain.GetCoupletResponse {
+	couplet := domain.GetCoupletResponse{Couplet: c.Text}
+	if c.Synced {
+		couplet.StartMs = &c.StartMs
+		couplet.EndMs = &c.EndMs
 	}
 
-	parts := strings.Split(date, ".")
+	return couplet
+}
 
-	numOfDate := make([]int, 3)
-	var err error
-	for i, part := range parts {
-		numOfDate[i], err = strconv.Atoi(part)
-		if err != nil {
-			return time.Time{}, domain.ErrBadReleaseDate
-		}
+func getIDParam(ctx *gin.Context) (int64, error) {
+	id, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil || id <= 0 {
+		return 0, domain.ErrBadID
 	}
 
-	res := time.Date(numOfDate[2], time.Month(numOfDate[1]), numOfDate[0], 0, 0, 0, 0, time.UTC)
-	return res, nil
+	return id, nil
 }
 
 // Create godoc
@@ -61,8 +69,14 @@ func getDateFromUser(date string) (time.Time, error) {
 // @Accept       json
 // @Produce      json
 // @Success      200  {object}  domain.Song
+// @Header       200  {string}  ETag  "song version"
 // @Failure      400  {object}  error_handler.HTTPError
+// @Failure      401  {object}  error_handler.HTTPError
+// @Failure      403  {object}  error_handler.HTTPError
+// @Failure      409  {object}  error_handler.HTTPError
 // @Failure      500  {object}  error_handler.HTTPError
+// @Security     BearerAuth
+// @Security     ApiKeyAuth
 // @Router       /songs [post]
 func (h *SongHandler) Create(ctx *gin.Context) {
 	var songRequest domain.CreateSongRequest
@@ -76,14 +90,14 @@ func (h *SongHandler) Create(ctx *gin.Context) {
 	err = json.Unmarshal(body, &songRequest)
 	if err != nil {
 		h.lg.Warn("song handler: create error: unmarsh", zap.Error(err), zap.Any("req", ctx.Request.Body))
-		error_handler.NewError(ctx, domain.ErrInternalServer)
+		error_handler.NewError(ctx, domain.ErrBadRequestBody)
 		return
 	}
 
 	var date time.Time
 
 	if songRequest.ReleaseDate != "" {
-		date, err = getDateFromUser(songRequest.ReleaseDate)
+		date, err = date_validate.ParseDate(songRequest.ReleaseDate)
 		if err != nil {
 			h.lg.Warn("song handler: create error: unmarsh", zap.Error(err))
 			error_handler.NewError(ctx, err)
@@ -97,6 +111,8 @@ func (h *SongHandler) Create(ctx *gin.Context) {
 		ReleaseDate: date,
 		Text:        songRequest.Text,
 		Link:        songRequest.Link,
+		AlbumID:     songRequest.AlbumID,
+		TrackNumber: songRequest.TrackNumber,
 	}
 
 	created, err := h.songUsecase.Create(ctx, &song)
@@ -106,27 +122,27 @@ func (h *SongHandler) Create(ctx *gin.Context) {
 		return
 	}
 
-	createdResponse := domain.CreateSongResponse{
-		Group:       created.Group,
-		Name:        created.Name,
-		ReleaseDate: getDate(created.ReleaseDate),
-		Text:        created.Text,
-		Link:        created.Link,
-	}
-
-	ctx.JSON(http.StatusOK, createdResponse)
+	setETag(ctx, created)
+	ctx.JSON(http.StatusOK, toSongResponse(created))
 }
 
 // Delete godoc
 // @Summary      Delete song
-// @Description  delete song
+// @Description  move song to the trash
 // @Tags         songs
 // @Produce      json
 // @Param        group    query     string  false  "group of song"
 // @Param        name    query     string  false  "name of song"
+// @Param        If-Match    header     string  false  "ETag of the version being changed"
 // @Success      200
 // @Failure      400  {object}  error_handler.HTTPError
+// @Failure      401  {object}  error_handler.HTTPError
+// @Failure      403  {object}  error_handler.HTTPError
+// @Failure      404  {object}  error_handler.HTTPError
+// @Failure      412  {object}  error_handler.HTTPError
 // @Failure      500  {object}  error_handler.HTTPError
+// @Security     BearerAuth
+// @Security     ApiKeyAuth
 // @Router       /songs [delete]
 func (h *SongHandler) Delete(ctx *gin.Context) {
 	group := ctx.Request.URL.Query().Get("group")
@@ -143,7 +159,14 @@ func (h *SongHandler) Delete(ctx *gin.Context) {
 		return
 	}
 
-	err := h.songUsecase.Delete(ctx, group, name)
+	version, err := ifMatchVersion(ctx)
+	if err != nil {
+		h.lg.Warn("song handler: delete error", zap.Error(err))
+		error_handler.NewError(ctx, err)
+		return
+	}
+
+	err = h.songUsecase.Delete(ctx, group, name, version)
 	if err != nil {
 		h.lg.Warn("song handler: delete error", zap.Error(err))
 		error_handler.NewError(ctx, err)
@@ -155,15 +178,27 @@ func (h *SongHandler) Delete(ctx *gin.Context) {
 
 // Update godoc
 // @Summary      Update song
-// @Description  update song
+// @Description  partially update song: a merge patch (RFC 7396) changes only the fields it has and null clears a field,
+// @Description  application/json-patch+json body is a JSON Patch (RFC 6902) applied to the song fields
 // @Tags         songs
-// @Accept 		 json
+// @Accept       json,application/merge-patch+json,application/json-patch+json
 // @Produce      json
 // @Param        group    query     string  false  "group of song"
 // @Param        name    query     string  false  "name of song"
+// @Param        song    body     domain.UpdateSongRequest  true  "fields to change"
+// @Param        If-Match    header     string  false  "ETag of the version being changed"
 // @Success      200  {object}  domain.Song
+// @Header       200  {string}  ETag  "song version"
 // @Failure      400  {object}  error_handler.HTTPError
+// @Failure      401  {object}  error_handler.HTTPError
+// @Failure      403  {object}  error_handler.HTTPError
+// @Failure      404  {object}  error_handler.HTTPError
+// @Failure      409  {object}  error_handler.HTTPError
+// @Failure      412  {object}  error_handler.HTTPError
+// @Failure      415  {object}  error_handler.HTTPError
 // @Failure      500  {object}  error_handler.HTTPError
+// @Security     BearerAuth
+// @Security     ApiKeyAuth
 // @Router       /songs [patch]
 func (h *SongHandler) Update(ctx *gin.Context) {
 	group := ctx.Request.URL.Query().Get("group")
@@ -180,141 +215,103 @@ func (h *SongHandler) Update(ctx *gin.Context) {
 		return
 	}
 
-	var songRequest domain.UpdateSongRequest
-
-	body, err := io.ReadAll(ctx.Request.Body)
+	patch, err := h.readSongPatch(ctx, func() (domain.Song, error) {
+		return h.songUsecase.Get(ctx, group, name)
+	})
 	if err != nil {
-		h.lg.Warn("song handler: update error", zap.Error(err))
-		error_handler.NewError(ctx, domain.ErrInternalServer)
-		return
-	}
-	err = json.Unmarshal(body, &songRequest)
-	if err != nil {
-		h.lg.Warn("song handler: update error", zap.Error(err))
-		error_handler.NewError(ctx, domain.ErrInternalServer)
+		error_handler.NewError(ctx, err)
 		return
 	}
 
-	var date time.Time
-
-	if songRequest.ReleaseDate != "" {
-		date, err = getDateFromUser(songRequest.ReleaseDate)
-		if err != nil {
-			h.lg.Warn("song handler: create error: unmarsh", zap.Error(err))
-			error_handler.NewError(ctx, err)
-			return
-		}
-	}
-
-	song := domain.Song{
-		Group:       songRequest.Group,
-		Name:        songRequest.Name,
-		ReleaseDate: date,
-		Text:        songRequest.Text,
-		Link:        songRequest.Link,
-	}
-
-	updated, err := h.songUsecase.Update(ctx, group, name, &song)
+	updated, err := h.songUsecase.Update(ctx, group, name, &patch)
 	if err != nil {
 		h.lg.Warn("song handler: update error", zap.Error(err))
-		error_handler.NewError(ctx, err)
+		error_handler.NewError(ctx, patchError(ctx, err))
 		return
 	}
 
-	createdResponse := domain.CreateSongResponse{
-		Group:       updated.Group,
-		Name:        updated.Name,
-		ReleaseDate: getDate(updated.ReleaseDate),
-		Text:        updated.Text,
-		Link:        updated.Link,
-	}
-
-	ctx.JSON(http.StatusOK, createdResponse)
+	setETag(ctx, updated)
+	ctx.JSON(http.StatusOK, toSongResponse(updated))
 }
 
 // GetSongs godoc
 // @Summary      Get songs with filter, limit and offset
-// @Description  get songs with filter, limit and offset
+// @Description  get songs with filter, limit and either page number (offset) or cursor.
+// @Description  The total is counted only for requests without a cursor.
+// @Description  Filters in a JSON request body are deprecated and read only when no filter query parameter is given.
 // @Tags         songs
-// @Accept 		 json
 // @Produce      json
 // @Param        limit    query     string  false  "songs on page"
-// @Param        offset    query     string  false  "page"
-// @Success      200  {array}  domain.Song
+// @Param        offset    query     string  false  "page number, enables page mode"
+// @Param        cursor    query     string  false  "next_cursor or prev_cursor of a previous response"
+// @Param        group    query     string  false  "group of song"
+// @Param        name    query     string  false  "name of song"
+// @Param        release_date    query     string  false  "release date, dd.mm.yyyy"
+// @Param        text    query     string  false  "text of song"
+// @Param        link    query     string  false  "link of song"
+// @Param        album_id    query     int  false  "id of album"
+// @Param        group_match    query     string  false  "group match mode: exact, prefix, contains, icase, similar"
+// @Param        name_match    query     string  false  "name match mode: exact, prefix, contains, icase, similar"
+// @Param        text_match    query     string  false  "text match mode: exact, prefix, contains, icase, similar"
+// @Param        link_match    query     string  false  "link match mode: exact, prefix, contains, icase, similar"
+// @Param        released_from    query     string  false  "release date lower bound, dd.mm.yyyy"
+// @Param        released_to    query     string  false  "release date upper bound, dd.mm.yyyy"
+// @Param        year    query     int  false  "release year"
+// @Param        decade    query     int  false  "release decade, e.g. 1990"
+// @Param        sort    query     string  false  "comma separated sort keys (id, group, name, release_date), minus for descending, e.g. -release_date,name"
+// @Success      200  {object}  domain.GetSongsResponse
 // @Failure      400  {object}  error_handler.HTTPError
 // @Failure      500  {object}  error_handler.HTTPError
 // @Router       /songs [get]
 func (h *SongHandler) GetSongs(ctx *gin.Context) {
-	limitStr := ctx.Request.URL.Query().Get("limit")
-	if limitStr == "" {
-		h.lg.Warn("song handler: getsongs error")
-		error_handler.NewError(ctx, domain.ErrBadLimit)
-		return
-	}
-
-	limit, _ := strconv.Atoi(limitStr)
+	query := ctx.Request.URL.Query()
 
-	offsetStr := ctx.Request.URL.Query().Get("offset")
-	if offsetStr == "" {
-		h.lg.Warn("song handler: getsongs error")
-		error_handler.NewError(ctx, domain.ErrBadOffset)
-		return
-	}
-
-	offset, _ := strconv.Atoi(offsetStr)
-
-	var songRequest domain.UpdateSongRequest
-
-	body, err := io.ReadAll(ctx.Request.Body)
+	limit, err := strconv.Atoi(query.Get("limit"))
 	if err != nil {
-		h.lg.Warn("song handler: getsongs error", zap.Error(err))
-		error_handler.NewError(ctx, domain.ErrInternalServer)
-		return
-	}
-	err = json.Unmarshal(body, &songRequest)
-	if err != nil {
-		h.lg.Warn("song handler: getsongs error", zap.Error(err))
-		error_handler.NewError(ctx, domain.ErrInternalServer)
+		h.lg.Warn("song handler: getsongs error: bad limit", zap.Error(err))
+		error_handler.NewError(ctx, domain.ErrBadLimit)
 		return
 	}
 
-	var date time.Time
+	page := domain.Pagination{
+		Limit:  limit,
+		Cursor: query.Get("cursor"),
+	}
 
-	if songRequest.ReleaseDate != "" {
-		date, err = getDateFromUser(songRequest.ReleaseDate)
-		if err != nil {
-			h.lg.Warn("song handler: create error: unmarsh", zap.Error(err))
-			error_handler.NewError(ctx, err)
+	offsetStr := query.Get("offset")
+	if offsetStr != "" {
+		offset, err := strconv.Atoi(offsetStr)
+		if err != nil || offset < 1 {
+			h.lg.Warn("song handler: getsongs error: bad offset")
+			error_handler.NewError(ctx, domain.ErrBadOffset)
 			return
 		}
+		page.Page = offset
 	}
 
-	filter := domain.Song{
-		Group:       songRequest.Group,
-		Name:        songRequest.Name,
-		ReleaseDate: date,
-		Text:        songRequest.Text,
-		Link:        songRequest.Link,
+	filter, err := h.readSongFilter(ctx)
+	if err != nil {
+		h.lg.Warn("song handler: getsongs error", zap.Error(err))
+		error_handler.NewError(ctx, err)
+		return
 	}
 
-	songs, err := h.songUsecase.GetSongs(ctx, &filter,
-		limit, offset)
+	result, err := h.songUsecase.GetSongs(ctx, &filter, &page)
 	if err != nil {
 		h.lg.Warn("song handler: getsongs error", zap.Error(err))
 		error_handler.NewError(ctx, err)
 		return
 	}
 
-	songsResponse := domain.GetSongsResponse{Songs: make([]domain.CreateSongResponse, 0)}
-	for _, s := range songs {
-		song := domain.CreateSongResponse{
-			Group:       s.Group,
-			Name:        s.Name,
-			ReleaseDate: getDate(s.ReleaseDate),
-			Text:        s.Text,
-			Link:        s.Link,
-		}
-		songsResponse.Songs = append(songsResponse.Songs, song)
+	songsResponse := domain.GetSongsResponse{
+		Songs:      make([]domain.CreateSongResponse, 0),
+		Total:      result.Total,
+		HasMore:    result.HasMore,
+		NextCursor: result.NextCursor,
+		PrevCursor: result.PrevCursor,
+	}
+	for _, s := range result.Songs {
+		songsResponse.Songs = append(songsResponse.Songs, toSongResponse(s))
 	}
 
 	ctx.JSON(http.StatusOK, songsResponse)
@@ -328,8 +325,12 @@ func (h *SongHandler) GetSongs(ctx *gin.Context) {
 // @Produce      json
 // @Param        group    query     string  false  "group of song"
 // @Param        name    query     string  false  "name of song"
+// @Param        If-None-Match    header     string  false  "ETag of the cached version"
 // @Success      200  {object}  domain.GetSongResponse
+// @Success      304
+// @Header       200  {string}  ETag  "song version"
 // @Failure      400  {object}  error_handler.HTTPError
+// @Failure      404  {object}  error_handler.HTTPError
 // @Failure      500  {object}  error_handler.HTTPError
 // @Router       /info [get]
 func (h *SongHandler) Get(ctx *gin.Context) {
@@ -354,12 +355,18 @@ func (h *SongHandler) Get(ctx *gin.Context) {
 		return
 	}
 
+	if notModified(ctx, song) {
+		return
+	}
+
 	got := domain.GetSongResponse{
-		ReleaseDate: getDate(song.ReleaseDate),
+		ID:          song.ID,
+		ReleaseDate: date_validate.FormatDate(song.ReleaseDate),
 		Text:        song.Text,
 		Link:        song.Link,
 	}
 
+	setETag(ctx, song)
 	ctx.JSON(http.StatusOK, got)
 }
 
@@ -374,6 +381,7 @@ func (h *SongHandler) Get(ctx *gin.Context) {
 // @Param        offset    query     string  false  "number of couplet"
 // @Success      200  {object}  domain.GetCoupletResponse
 // @Failure      400  {object}  error_handler.HTTPError
+// @Failure      404  {object}  error_handler.HTTPError
 // @Failure      500  {object}  error_handler.HTTPError
 // @Router       /songs/couplet [get]
 func (h *SongHandler) GetCouplet(ctx *gin.Context) {
@@ -407,7 +415,305 @@ func (h *SongHandler) GetCouplet(ctx *gin.Context) {
 		return
 	}
 
-	couplet := domain.GetCoupletResponse{Couplet: c}
+	ctx.JSON(http.StatusOK, toCoupletResponse(c))
+}
+
+// GetByID godoc
+// @Summary      Get song by id
+// @Description  get song by id
+// @Tags         songs
+// @Produce      json
+// @Param        id    path     int  true  "id of song"
+// @Param        If-None-Match    header     string  false  "ETag of the cached version"
+// @Success      200  {object}  domain.CreateSongResponse
+// @Success      304
+// @Header       200  {string}  ETag  "song version"
+// @Failure      400  {object}  error_handler.HTTPError
+// @Failure      404  {object}  error_handler.HTTPError
+// @Failure      500  {object}  error_handler.HTTPError
+// @Router       /songs/{id} [get]
+func (h *SongHandler) GetByID(ctx *gin.Context) {
+	id, err := getIDParam(ctx)
+	if err != nil {
+		h.lg.Warn("song handler: get by id error", zap.Error(err))
+		error_handler.NewError(ctx, err)
+		return
+	}
+
+	song, err := h.songUsecase.GetByID(ctx, id)
+	if err != nil {
+		h.lg.Warn("song handler: get by id error", zap.Error(err))
+		error_handler.NewError(ctx, err)
+		return
+	}
+
+	if notModified(ctx, song) {
+		return
+	}
+
+	setETag(ctx, song)
+	ctx.JSON(http.StatusOK, toSongResponse(song))
+}
+
+// UpdateByID godoc
+// @Summary      Update song by id
+// @Description  partially update song: a merge patch (RFC 7396) changes only the fields it has and null clears a field,
+// @Description  application/json-patch+json body is a JSON Patch (RFC 6902) applied to the song fields
+// @Tags         songs
+// @Accept       json,application/merge-patch+json,application/json-patch+json
+// @Produce      json
+// @Param        id    path     int  true  "id of song"
+// @Param        song    body     domain.UpdateSongRequest  true  "fields to change"
+// @Param        If-Match    header     string  false  "ETag of the version being changed"
+// @Success      200  {object}  domain.CreateSongResponse
+// @Header       200  {string}  ETag  "song version"
+// @Failure      400  {object}  error_handler.HTTPError
+// @Failure      401  {object}  error_handler.HTTPError
+// @Failure      403  {object}  error_handler.HTTPError
+// @Failure      404  {object}  error_handler.HTTPError
+// @Failure      409  {object}  error_handler.HTTPError
+// @Failure      412  {object}  error_handler.HTTPError
+// @Failure      415  {object}  error_handler.HTTPError
+// @Failure      500  {object}  error_handler.HTTPError
+// @Security     BearerAuth
+// @Security     ApiKeyAuth
+// @Router       /songs/{id} [patch]
+func (h *SongHandler) UpdateByID(ctx *gin.Context) {
+	id, err := getIDParam(ctx)
+	if err != nil {
+		h.lg.Warn("song handler: update by id error", zap.Error(err))
+		error_handler.NewError(ctx, err)
+		return
+	}
+
+	patch, err := h.readSongPatch(ctx, func() (domain.Song, error) {
+		return h.songUsecase.GetByID(ctx, id)
+	})
+	if err != nil {
+		error_handler.NewError(ctx, err)
+		return
+	}
+
+	updated, err := h.songUsecase.UpdateByID(ctx, id, &patch)
+	if err != nil {
+		h.lg.Warn("song handler: update by id error", zap.Error(err))
+		error_handler.NewError(ctx, patchError(ctx, err))
+		return
+	}
+
+	setETag(ctx, updated)
+	ctx.JSON(http.StatusOK, toSongResponse(updated))
+}
+
+// DeleteByID godoc
+// @Summary      Delete song by id
+// @Description  move song with the given id to the trash
+// @Tags         songs
+// @Produce      json
+// @Param        id    path     int  true  "id of song"
+// @Param        If-Match    header     string  false  "ETag of the version being changed"
+// @Success      200
+// @Failure      400  {object}  error_handler.HTTPError
+// @Failure      401  {object}  error_handler.HTTPError
+// @Failure      403  {object}  error_handler.HTTPError
+// @Failure      404  {object}  error_handler.HTTPError
+// @Failure      412  {object}  error_handler.HTTPError
+// @Failure      500  {object}  error_handler.HTTPError
+// @Security     BearerAuth
+// @Security     ApiKeyAuth
+// @Router       /songs/{id} [delete]
+func (h *SongHandler) DeleteByID(ctx *gin.Context) {
+	id, err := getIDParam(ctx)
+	if err != nil {
+		h.lg.Warn("song handler: delete by id error", zap.Error(err))
+		error_handler.NewError(ctx, err)
+		return
+	}
+
+	version, err := ifMatchVersion(ctx)
+	if err != nil {
+		h.lg.Warn("song handler: delete by id error", zap.Error(err))
+		error_handler.NewError(ctx, err)
+		return
+	}
+
+	err = h.songUsecase.DeleteByID(ctx, id, version)
+	if err != nil {
+		h.lg.Warn("song handler: delete by id error", zap.Error(err))
+		error_handler.NewError(ctx, err)
+		return
+	}
+
+	ctx.Status(http.StatusOK)
+}
+
+// GetCoupletByID godoc
+// @Summary      Get couplet of song by id
+// @Description  get couplet of song by id
+// @Tags         songs
+// @Produce      json
+// @Param        id    path     int  true  "id of song"
+// @Param        n    path     int  true  "number of couplet"
+// @Success      200  {object}  domain.GetCoupletResponse
+// @Failure      400  {object}  error_handler.HTTPError
+// @Failure      404  {object}  error_handler.HTTPError
+// @Failure      500  {object}  error_handler.HTTPError
+// @Router       /songs/{id}/couplets/{n} [get]
+func (h *SongHandler) GetCoupletByID(ctx *gin.Context) {
+	id, err := getIDParam(ctx)
+	if err != nil {
+		h.lg.Warn("song handler: getcouplet by id error", zap.Error(err))
+		error_handler.NewError(ctx, err)
+		return
+	}
+
+	offset, err := strconv.Atoi(ctx.Param("n"))
+	if err != nil {
+		h.lg.Warn("song handler: getcouplet by id error", zap.Error(err))
+		error_handler.NewError(ctx, domain.ErrBadOffset)
+		return
+	}
+
+	c, err := h.songUsecase.GetCoupletByID(ctx, id, offset)
+	if err != nil {
+		h.lg.Warn("song handler: getcouplet by id error", zap.Error(err))
+		error_handler.NewError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, toCoupletResponse(c))
+}
+
+// Search godoc
+// @Summary      Full-text search of songs
+// @Description  search songs by name and lyrics, results are ranked and have highlighted snippets
+// @Tags         songs
+// @Produce      json
+// @Param        q    query     string  true  "search query"
+// @Param        lang    query     string  false  "search language: russian or english"
+// @Param        limit    query     string  true  "songs on page"
+// @Param        offset    query     string  true  "page"
+// @Success      200  {object}  domain.SearchSongsResponse
+// @Failure      400  {object}  error_handler.HTTPError
+// @Failure      500  {object}  error_handler.HTTPError
+// @Router       /songs/search [get]
+func (h *SongHandler) Search(ctx *gin.Context) {
+	query := ctx.Request.URL.Query().Get("q")
+	if query == "" {
+		h.lg.Warn("song handler: search error")
+		error_handler.NewError(ctx, domain.ErrBadSearchQuery)
+		return
+	}
+
+	lang := domain.SearchLanguage(ctx.Request.URL.Query().Get("lang"))
+
+	limit, offset, err := getPageParams(ctx)
+	if err != nil {
+		h.lg.Warn("song handler: search error", zap.Error(err))
+		error_handler.NewError(ctx, err)
+		return
+	}
+
+	results, err := h.songUsecase.Search(ctx, query, lang, limit, offset)
+	if err != nil {
+		h.lg.Warn("song handler: search error", zap.Error(err))
+		error_handler.NewError(ctx, err)
+		return
+	}
+
+	searchResponse := domain.SearchSongsResponse{Results: make([]domain.SearchSongResponse, 0)}
+	for _, r := range results {
+		searchResponse.Results = append(searchResponse.Results, domain.SearchSongResponse{
+			ID:       r.Song.ID,
+			ArtistID: r.Song.ArtistID,
+			Group:    r.Song.Group,
+			Name:     r.Song.Name,
+			Rank:     r.Rank,
+			Snippet:  r.Snippet,
+		})
+	}
+
+	ctx.JSON(http.StatusOK, searchResponse)
+}
+
+func toSectionResponse(section domain.LyricsSection) domain.LyricsSectionResponse {
+	return domain.LyricsSectionResponse{
+		Type:  string(section.Type),
+		Index: section.Index,
+		Order: section.Order,
+		Lines: section.Lines,
+		Text:  section.Text(),
+	}
+}
+
+// GetSections godoc
+// @Summary      Get lyrics sections
+// @Description  get all sections (verses, choruses, bridges...) of song lyrics in order
+// @Tags         songs
+// @Produce      json
+// @Param        id    path     int  true  "id of song"
+// @Success      200  {object}  domain.GetSectionsResponse
+// @Failure      400  {object}  error_handler.HTTPError
+// @Failure      404  {object}  error_handler.HTTPError
+// @Failure      500  {object}  error_handler.HTTPError
+// @Router       /songs/{id}/sections [get]
+func (h *SongHandler) GetSections(ctx *gin.Context) {
+	id, err := getIDParam(ctx)
+	if err != nil {
+		h.lg.Warn("song handler: get sections error", zap.Error(err))
+		error_handler.NewError(ctx, err)
+		return
+	}
+
+	sections, err := h.songUsecase.GetSections(ctx, id)
+	if err != nil {
+		h.lg.Warn("song handler: get sections error", zap.Error(err))
+		error_handler.NewError(ctx, err)
+		return
+	}
+
+	sectionsResponse := domain.GetSectionsResponse{Sections: make([]domain.LyricsSectionResponse, 0)}
+	for _, s := range sections {
+		sectionsResponse.Sections = append(sectionsResponse.Sections, toSectionResponse(s))
+	}
+
+	ctx.JSON(http.StatusOK, sectionsResponse)
+}
+
+// GetSection godoc
+// @Summary      Get lyrics section
+// @Description  get n-th section of the given type, e.g. the second chorus
+// @Tags         songs
+// @Produce      json
+// @Param        id    path     int  true  "id of song"
+// @Param        type    path     string  true  "section type: intro, verse, pre-chorus, chorus, hook, bridge, outro"
+// @Param        n    path     int  true  "number of section of this type"
+// @Success      200  {object}  domain.LyricsSectionResponse
+// @Failure      400  {object}  error_handler.HTTPError
+// @Failure      404  {object}  error_handler.HTTPError
+// @Failure      500  {object}  error_handler.HTTPError
+// @Router       /songs/{id}/sections/{type}/{n} [get]
+func (h *SongHandler) GetSection(ctx *gin.Context) {
+	id, err := getIDParam(ctx)
+	if err != nil {
+		h.lg.Warn("song handler: get section error", zap.Error(err))
+		error_handler.NewError(ctx, err)
+		return
+	}
+
+	index, err := strconv.Atoi(ctx.Param("n"))
+	if err != nil {
+		h.lg.Warn("song handler: get section error", zap.Error(err))
+		error_handler.NewError(ctx, domain.ErrBadOffset)
+		return
+	}
+
+	section, err := h.songUsecase.GetSection(ctx, id, domain.SectionType(ctx.Param("type")), index)
+	if err != nil {
+		h.lg.Warn("song handler: get section error", zap.Error(err))
+		error_handler.NewError(ctx, err)
+		return
+	}
 
-	ctx.JSON(http.StatusOK, couplet)
+	ctx.JSON(http.StatusOK, toSectionResponse(section))
 }
@@ -0,0 +1,348 @@
+package handlers
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"github.com/NastyaAR/music_library/internal/domain"
+	"github.com/NastyaAR/music_library/internal/pkg/date_validate"
+	"github.com/NastyaAR/music_library/internal/pkg/error_handler"
+	"github.com/NastyaAR/music_library/internal/pkg/export"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// importAsyncRows is the size above which an import runs as a background
+// job even when the client did not ask for it.
+const importAsyncRows = 1000
+
+const maxNDJSONLine = 4 << 20
+
+var importColumns = []string{"group", "name", "release_date", "text", "link", "album_id", "track_number"}
+
+// SongsAction routes custom methods such as /songs:import. Gin only knows
+// the colon as a wildcard, so the route is registered as /songs:action and
+// the action arrives with its colon.
+func SongsAction(actions map[string]gin.HandlerFunc) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		handler, ok := actions[ctx.Param("action")]
+		if !ok {
+			ctx.AbortWithStatus(http.StatusNotFound)
+			return
+		}
+		handler(ctx)
+	}
+}
+
+func importRow(row int, req domain.CreateSongRequest) domain.ImportRow {
+	song := domain.Song{
+		Group:       req.Group,
+		Name:        req.Name,
+		Text:        req.Text,
+		Link:        req.Link,
+		AlbumID:     req.AlbumID,
+		TrackNumber: req.TrackNumber,
+	}
+
+	if req.ReleaseDate != "" {
+		date, err := date_validate.ParseDate(req.ReleaseDate)
+		if err != nil {
+			return domain.ImportRow{Row: row, Err: err}
+		}
+		song.ReleaseDate = date
+	}
+
+	return domain.ImportRow{Row: row, Song: song}
+}
+
+// bodyError is ErrImportTooLarge when the body went over the limit of the
+// handler, other read errors mean a bad body.
+func bodyError(err error) error {
+	var tooLarge *http.MaxBytesError
+	if errors.As(err, &tooLarge) {
+		return domain.ErrImportTooLarge
+	}
+	return domain.ErrBadRequestBody
+}
+
+func decodeImportRow(row int, raw []byte) domain.ImportRow {
+	var req domain.CreateSongRequest
+	err := json.Unmarshal(raw, &req)
+	if err != nil {
+		return domain.ImportRow{Row: row, Err: domain.ErrBadRequestBody}
+	}
+	return importRow(row, req)
+}
+
+// readJSONRows reads a JSON array, an element that is not a song only
+// fails its own row.
+func readJSONRows(body io.Reader) ([]domain.ImportRow, error) {
+	var raws []json.RawMessage
+	err := json.NewDecoder(body).Decode(&raws)
+	if err != nil {
+		return nil, bodyError(err)
+	}
+
+	rows := make([]domain.ImportRow, 0, len(raws))
+	for i, raw := range raws {
+		rows = append(rows, decodeImportRow(i+1, raw))
+	}
+	return rows, nil
+}
+
+// readNDJSONRows reads one song per line, rows are numbered by line and
+// blank lines are skipped.
+func readNDJSONRows(body io.Reader) ([]domain.ImportRow, error) {
+	scanner := bufio.NewScanner(body)
+	scanner.Buffer(make([]byte, 64*1024), maxNDJSONLine)
+
+	rows := make([]domain.ImportRow, 0)
+	for line := 1; scanner.Scan(); line++ {
+		raw := bytes.TrimSpace(scanner.Bytes())
+		if len(raw) == 0 {
+			continue
+		}
+		rows = append(rows, decodeImportRow(line, raw))
+	}
+	if scanner.Err() != nil {
+		return nil, bodyError(scanner.Err())
+	}
+
+	return rows, nil
+}
+
+// readCSVRows reads a CSV with a header of importColumns, rows are
+// numbered by record with the header being 1. The read-only columns of
+// an export, such as id and version, are ignored so an exported file can
+// be imported back.
+func readCSVRows(body io.Reader) ([]domain.ImportRow, error) {
+	reader := csv.NewReader(body)
+	reader.FieldsPerRecord = -1
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, bodyError(err)
+	}
+
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		name = strings.TrimSpace(strings.TrimPrefix(name, "\uFEFF"))
+		known, exported := false, false
+		for _, c := range importColumns {
+			known = known || c == name
+		}
+		for _, c := range export.Columns {
+			exported = exported || c == name
+		}
+		if !known && exported {
+			continue
+		}
+		if !known {
+			return nil, domain.ErrBadRequestBody
+		}
+		columns[name] = i
+	}
+	if _, ok := columns["group"]; !ok {
+		return nil, domain.ErrBadRequestBody
+	}
+	if _, ok := columns["name"]; !ok {
+		return nil, domain.ErrBadRequestBody
+	}
+
+	rows := make([]domain.ImportRow, 0)
+	for row := 2; ; row++ {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			var parseErr *csv.ParseError
+			if !errors.As(err, &parseErr) {
+				return nil, bodyError(err)
+			}
+			rows = append(rows, domain.ImportRow{Row: row, Err: domain.ErrBadRequestBody})
+			continue
+		}
+
+		field := func(name string) string {
+			i, ok := columns[name]
+			if !ok || i >= len(record) {
+				return ""
+			}
+			return record[i]
+		}
+
+		req := domain.CreateSongRequest{
+			Group:       field("group"),
+			Name:        field("name"),
+			ReleaseDate: field("release_date"),
+			Text:        field("text"),
+			Link:        field("link"),
+		}
+
+		if v := field("album_id"); v != "" {
+			req.AlbumID, err = strconv.ParseInt(v, 10, 64)
+			if err != nil {
+				rows = append(rows, domain.ImportRow{Row: row, Err: domain.ErrBadAlbumID})
+				continue
+			}
+		}
+		if v := field("track_number"); v != "" {
+			req.TrackNumber, err = strconv.Atoi(v)
+			if err != nil {
+				rows = append(rows, domain.ImportRow{Row: row, Err: domain.ErrBadTrackNumber})
+				continue
+			}
+		}
+
+		rows = append(rows, importRow(row, req))
+	}
+
+	return rows, nil
+}
+
+func toImportReportResponse(report domain.ImportReport) domain.ImportReportResponse {
+	reportResponse := domain.ImportReportResponse{
+		Created: report.Created,
+		Updated: report.Updated,
+		Skipped: report.Skipped,
+		Failed:  report.Failed,
+		Aborted: report.Aborted,
+		Rows:    make([]domain.ImportRowResponse, 0, len(report.Rows)),
+	}
+	for _, r := range report.Rows {
+		reportResponse.Rows = append(reportResponse.Rows, domain.ImportRowResponse{
+			Row:    r.Row,
+			Status: string(r.Status),
+			SongID: r.SongID,
+			Error:  r.Error,
+		})
+	}
+
+	return reportResponse
+}
+
+func toImportJobResponse(job domain.ImportJob) domain.ImportJobResponse {
+	jobResponse := domain.ImportJobResponse{
+		ID:        job.ID,
+		Status:    string(job.Status),
+		Mode:      string(job.Mode),
+		Total:     job.Total,
+		CreatedAt: job.CreatedAt.Format(time.RFC3339),
+		Error:     job.Error,
+	}
+	if !job.FinishedAt.IsZero() {
+		jobResponse.FinishedAt = job.FinishedAt.Format(time.RFC3339)
+	}
+	if job.Report != nil {
+		report := toImportReportResponse(*job.Report)
+		jobResponse.Report = &report
+	}
+
+	return jobResponse
+}
+
+// Import godoc
+// @Summary      Import songs
+// @Description  import songs from a JSON array, NDJSON (application/x-ndjson) or CSV with a header
+// @Description  of group, name, release_date, text, link, album_id, track_number columns.
+// @Description  Imports of more than 1000 rows or with async=true run in the background and answer 202 with a job.
+// @Tags         songs
+// @Accept       json,application/x-ndjson,text/csv
+// @Produce      json
+// @Param        mode    query     string  false  "existing songs: skip, overwrite or fail (default), fail writes nothing when any row fails"
+// @Param        async    query     bool  false  "run as a background job"
+// @Success      200  {object}  domain.ImportReportResponse
+// @Success      202  {object}  domain.ImportJobResponse
+// @Failure      400  {object}  error_handler.HTTPError
+// @Failure      401  {object}  error_handler.HTTPError
+// @Failure      403  {object}  error_handler.HTTPError
+// @Failure      413  {object}  error_handler.HTTPError
+// @Failure      415  {object}  error_handler.HTTPError
+// @Failure      500  {object}  error_handler.HTTPError
+// @Security     BearerAuth
+// @Security     ApiKeyAuth
+// @Router       /songs:import [post]
+func (h *SongHandler) Import(ctx *gin.Context) {
+	mode := domain.ImportMode(ctx.DefaultQuery("mode", string(domain.ImportFail)))
+	if !mode.IsValid() {
+		h.lg.Warn("song handler: import error: bad mode")
+		error_handler.NewError(ctx, domain.ErrBadImportMode)
+		return
+	}
+
+	if h.maxImportBytes > 0 {
+		ctx.Request.Body = http.MaxBytesReader(ctx.Writer, ctx.Request.Body, h.maxImportBytes)
+	}
+
+	var rows []domain.ImportRow
+	var err error
+	switch ctx.ContentType() {
+	case gin.MIMEJSON:
+		rows, err = readJSONRows(ctx.Request.Body)
+	case "application/x-ndjson", "application/ndjson":
+		rows, err = readNDJSONRows(ctx.Request.Body)
+	case "text/csv":
+		rows, err = readCSVRows(ctx.Request.Body)
+	default:
+		err = domain.ErrUnsupportedImportType
+	}
+	if err != nil {
+		h.lg.Warn("song handler: import error", zap.Error(err))
+		error_handler.NewError(ctx, err)
+		return
+	}
+
+	if ctx.Query("async") == "true" || len(rows) > importAsyncRows {
+		job, err := h.songUsecase.StartImport(ctx, rows, mode)
+		if err != nil {
+			h.lg.Warn("song handler: import error", zap.Error(err))
+			error_handler.NewError(ctx, err)
+			return
+		}
+
+		ctx.Header("Location", "/songs/imports/"+job.ID)
+		ctx.JSON(http.StatusAccepted, toImportJobResponse(job))
+		return
+	}
+
+	report, err := h.songUsecase.Import(ctx, rows, mode)
+	if err != nil {
+		h.lg.Warn("song handler: import error", zap.Error(err))
+		error_handler.NewError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, toImportReportResponse(report))
+}
+
+// GetImportJob godoc
+// @Summary      Get import job
+// @Description  get status of a background import, the report is there once it has finished.
+// @Description  Only the user who started the import sees it.
+// @Tags         songs
+// @Produce      json
+// @Param        id    path     string  true  "id of import job"
+// @Success      200  {object}  domain.ImportJobResponse
+// @Failure      401  {object}  error_handler.HTTPError
+// @Failure      404  {object}  error_handler.HTTPError
+// @Security     BearerAuth
+// @Security     ApiKeyAuth
+// @Router       /songs/imports/{id} [get]
+func (h *SongHandler) GetImportJob(ctx *gin.Context) {
+	job, err := h.songUsecase.GetImportJob(ctx, ctx.Param("id"))
+	if err != nil {
+		h.lg.Warn("song handler: get import job error", zap.Error(err))
+		error_handler.NewError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, toImportJobResponse(job))
+}
@@ -0,0 +1,138 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"github.com/NastyaAR/music_library/internal/domain"
+	"github.com/NastyaAR/music_library/internal/pkg/export"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// fakeSongs keeps imported rows, the rest of domain.SongUsecase is not
+// used by these tests.
+type fakeSongs struct {
+	domain.SongUsecase
+	imported int
+	rows     []domain.ImportRow
+}
+
+func (f *fakeSongs) Import(ctx context.Context, rows []domain.ImportRow, mode domain.ImportMode) (domain.ImportReport, error) {
+	f.imported += len(rows)
+	f.rows = append(f.rows, rows...)
+	return domain.ImportReport{Created: len(rows)}, nil
+}
+
+func importRequest(handler *SongHandler, contentType string, body string) *httptest.ResponseRecorder {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.POST("/songs:action", handler.Import)
+
+	req := httptest.NewRequest(http.MethodPost, "/songs:action", strings.NewReader(body))
+	req.Header.Set("Content-Type", contentType)
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestImportBodyLimit(t *testing.T) {
+	ndjson := strings.Repeat(`{"group":"Muse","name":"Uprising"}`+"\n", 10)
+	csv := "group,name\n" + strings.Repeat("Muse,Uprising\n", 30)
+	json := "[" + strings.Repeat(`{"group":"Muse","name":"Uprising"},`, 10) + `{"group":"Muse","name":"Uprising"}]`
+
+	tests := []struct {
+		contentType string
+		body        string
+	}{
+		{"application/json", json},
+		{"application/x-ndjson", ndjson},
+		{"text/csv", csv},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.contentType, func(t *testing.T) {
+			songs := &fakeSongs{}
+
+			rec := importRequest(NewSongHandler(songs, 100, zap.NewNop()), tt.contentType, tt.body)
+			if rec.Code != http.StatusRequestEntityTooLarge {
+				t.Fatalf("status = %d, want 413, body %s", rec.Code, rec.Body)
+			}
+			if songs.imported != 0 {
+				t.Errorf("imported %d rows of a rejected body", songs.imported)
+			}
+
+			rec = importRequest(NewSongHandler(songs, 1<<20, zap.NewNop()), tt.contentType, tt.body)
+			if rec.Code != http.StatusOK {
+				t.Errorf("status under the limit = %d, want 200, body %s", rec.Code, rec.Body)
+			}
+		})
+	}
+}
+
+func TestImportCSVNumberErrors(t *testing.T) {
+	body := "group,name,album_id,track_number\n" +
+		"Muse,Uprising,x,1\n" +
+		"Muse,Resistance,1,x\n" +
+		"Muse,Exogenesis,1,3\n"
+
+	songs := &fakeSongs{}
+	rec := importRequest(NewSongHandler(songs, 1<<20, zap.NewNop()), "text/csv", body)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, want 200, body %s", rec.Code, rec.Body)
+	}
+
+	want := []error{domain.ErrBadAlbumID, domain.ErrBadTrackNumber, nil}
+	if len(songs.rows) != len(want) {
+		t.Fatalf("got %d rows, want %d", len(songs.rows), len(want))
+	}
+	for i, row := range songs.rows {
+		if row.Err != want[i] {
+			t.Errorf("row %d error = %v, want %v", row.Row, row.Err, want[i])
+		}
+	}
+}
+
+func TestImportCSVExport(t *testing.T) {
+	var buf bytes.Buffer
+	writer := export.NewWriter(export.CSV, &buf)
+	song := domain.CreateSongResponse{ID: 7, ArtistID: 3, Group: "Muse", Name: "Uprising",
+		ReleaseDate: "07.09.2009", Text: "Paranoia is in bloom,\nthe PR transmissions will resume",
+		Link: "https://example.com", AlbumID: 2, TrackNumber: 1, Version: 4}
+	if err := writer.Write(song); err != nil {
+		t.Fatal(err)
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	rows, err := readCSVRows(&buf)
+	if err != nil {
+		t.Fatalf("readCSVRows of an export: %v", err)
+	}
+	if len(rows) != 1 {
+		t.Fatalf("got %d rows, want 1", len(rows))
+	}
+
+	row := rows[0]
+	if row.Err != nil {
+		t.Fatalf("row error = %v", row.Err)
+	}
+	got := row.Song
+	if got.Group != song.Group || got.Name != song.Name || got.Text != song.Text ||
+		got.Link != song.Link || got.AlbumID != song.AlbumID || got.TrackNumber != song.TrackNumber ||
+		got.ReleaseDate.Format("02.01.2006") != song.ReleaseDate {
+		t.Errorf("imported %+v, want the fields of %+v", got, song)
+	}
+}
+
+func TestImportCSVUnknownColumn(t *testing.T) {
+	_, err := readCSVRows(strings.NewReader("group,name,genre\nMuse,Uprising,rock\n"))
+	if err != domain.ErrBadRequestBody {
+		t.Errorf("err = %v, want %v", err, domain.ErrBadRequestBody)
+	}
+}
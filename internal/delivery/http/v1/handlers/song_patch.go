@@ -0,0 +1,206 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"github.com/NastyaAR/music_library/internal/domain"
+	"github.com/NastyaAR/music_library/internal/pkg/date_validate"
+	"github.com/NastyaAR/music_library/internal/pkg/jsonpatch"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+	"io"
+	"reflect"
+	"time"
+)
+
+const (
+	mergePatchType = "application/merge-patch+json"
+	jsonPatchType  = "application/json-patch+json"
+)
+
+func patchField[T any](raw json.RawMessage) (domain.PatchField[T], error) {
+	if string(bytes.TrimSpace(raw)) == "null" {
+		return domain.PatchField[T]{Set: true}, nil
+	}
+
+	var value T
+	err := json.Unmarshal(raw, &value)
+	if err != nil {
+		return domain.PatchField[T]{}, domain.ErrBadRequestBody
+	}
+
+	return domain.PatchField[T]{Set: true, Value: &value}, nil
+}
+
+// parseMergePatch reads an RFC 7396 merge patch: absent fields stay as
+// they are and null clears a field.
+func parseMergePatch(body []byte) (domain.SongPatch, error) {
+	var fields map[string]json.RawMessage
+	err := json.Unmarshal(body, &fields)
+	if err != nil || fields == nil {
+		return domain.SongPatch{}, domain.ErrBadRequestBody
+	}
+
+	var patch domain.SongPatch
+	for key, raw := range fields {
+		switch key {
+		case "group":
+			patch.Group, err = patchField[string](raw)
+		case "name":
+			patch.Name, err = patchField[string](raw)
+		case "release_date":
+			var date domain.PatchField[string]
+			date, err = patchField[string](raw)
+			patch.ReleaseDate.Set = date.Set
+			if err == nil && date.Value != nil {
+				var releaseDate time.Time
+				releaseDate, err = date_validate.ParseDate(*date.Value)
+				patch.ReleaseDate.Value = &releaseDate
+			}
+		case "text":
+			patch.Text, err = patchField[string](raw)
+		case "link":
+			patch.Link, err = patchField[string](raw)
+		case "album_id":
+			patch.AlbumID, err = patchField[int64](raw)
+		case "track_number":
+			patch.TrackNumber, err = patchField[int](raw)
+		default:
+			err = domain.ErrBadRequestBody
+		}
+
+		if err != nil {
+			return domain.SongPatch{}, err
+		}
+	}
+
+	return patch, nil
+}
+
+// nullable is nil for the zero value, so that an unset field is a null
+// in the patch document instead of a missing or made up value.
+func nullable[T comparable](value T) interface{} {
+	var zero T
+	if value == zero {
+		return nil
+	}
+	return value
+}
+
+// songDocument is the song as a JSON Patch target. It has every field of
+// domain.UpdateSongRequest, unset ones are null, so that RFC 6902
+// operations can address all of them.
+func songDocument(song domain.Song) map[string]interface{} {
+	var releaseDate interface{}
+	if !song.ReleaseDate.IsZero() {
+		releaseDate = date_validate.FormatDate(song.ReleaseDate)
+	}
+
+	body, _ := json.Marshal(map[string]interface{}{
+		"group":        song.Group,
+		"name":         song.Name,
+		"release_date": releaseDate,
+		"text":         nullable(song.Text),
+		"link":         nullable(song.Link),
+		"album_id":     nullable(song.AlbumID),
+		"track_number": nullable(song.TrackNumber),
+	})
+
+	// numbers come back as float64 like the values of the operations
+	var doc map[string]interface{}
+	_ = json.Unmarshal(body, &doc)
+	return doc
+}
+
+// parseJSONPatch applies RFC 6902 operations to the current song and
+// turns the difference into a merge patch.
+func parseJSONPatch(body []byte, current domain.Song) (domain.SongPatch, error) {
+	ops, err := jsonpatch.Decode(body)
+	if err != nil {
+		return domain.SongPatch{}, domain.ErrBadPatch
+	}
+
+	patched, err := jsonpatch.Apply(songDocument(current), ops)
+	if errors.Is(err, jsonpatch.ErrTestFailed) {
+		return domain.SongPatch{}, domain.ErrPatchTestFailed
+	}
+	if err != nil {
+		return domain.SongPatch{}, domain.ErrBadPatch
+	}
+
+	after, ok := patched.(map[string]interface{})
+	if !ok {
+		return domain.SongPatch{}, domain.ErrBadPatch
+	}
+
+	before := songDocument(current)
+	merge := make(map[string]interface{})
+	for key, value := range after {
+		if !reflect.DeepEqual(before[key], value) {
+			merge[key] = value
+		}
+	}
+	for key := range before {
+		if _, ok := after[key]; !ok {
+			merge[key] = nil
+		}
+	}
+
+	mergeBody, err := json.Marshal(merge)
+	if err != nil {
+		return domain.SongPatch{}, domain.ErrBadPatch
+	}
+
+	return parseMergePatch(mergeBody)
+}
+
+// readSongPatch reads a merge patch or, for application/json-patch+json,
+// a JSON Patch applied to the song returned by load. Without If-Match a
+// JSON Patch is bound to the version it was applied to.
+func (h *SongHandler) readSongPatch(ctx *gin.Context, load func() (domain.Song, error)) (domain.SongPatch, error) {
+	version, err := ifMatchVersion(ctx)
+	if err != nil {
+		return domain.SongPatch{}, err
+	}
+
+	body, err := io.ReadAll(ctx.Request.Body)
+	if err != nil {
+		h.lg.Warn("song handler: read patch error", zap.Error(err))
+		return domain.SongPatch{}, domain.ErrInternalServer
+	}
+
+	var patch domain.SongPatch
+	switch ctx.ContentType() {
+	case "", gin.MIMEJSON, mergePatchType:
+		patch, err = parseMergePatch(body)
+	case jsonPatchType:
+		var current domain.Song
+		current, err = load()
+		if err != nil {
+			return domain.SongPatch{}, err
+		}
+		if version == 0 {
+			version = current.Version
+		}
+		patch, err = parseJSONPatch(body, current)
+	default:
+		err = domain.ErrUnsupportedPatchType
+	}
+	if err != nil {
+		h.lg.Warn("song handler: read patch error", zap.Error(err))
+		return domain.SongPatch{}, err
+	}
+
+	patch.Version = version
+	return patch, nil
+}
+
+// patchError reports a version mismatch the client did not ask to check
+// as a conflict.
+func patchError(ctx *gin.Context, err error) error {
+	if errors.Is(err, domain.ErrPreconditionFailed) && ctx.GetHeader("If-Match") == "" {
+		return domain.ErrConflict
+	}
+	return err
+}
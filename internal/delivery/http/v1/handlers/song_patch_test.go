@@ -0,0 +1,78 @@
+package handlers
+
+import (
+	"errors"
+	"github.com/NastyaAR/music_library/internal/domain"
+	"testing"
+	"time"
+)
+
+func TestSongDocumentHasEveryField(t *testing.T) {
+	doc := songDocument(domain.Song{Group: "Muse", Name: "Uprising"})
+
+	for _, key := range []string{"group", "name", "release_date", "text", "link", "album_id", "track_number"} {
+		value, ok := doc[key]
+		if !ok {
+			t.Errorf("document has no %q", key)
+			continue
+		}
+		if key != "group" && key != "name" && value != nil {
+			t.Errorf("%q = %v, want null", key, value)
+		}
+	}
+}
+
+func TestJSONPatchOnEmptyFields(t *testing.T) {
+	current := domain.Song{ID: 1, Group: "Muse", Name: "Uprising", Version: 3}
+
+	patch, err := parseJSONPatch([]byte(`[
+		{"op": "test", "path": "/text", "value": null},
+		{"op": "test", "path": "/release_date", "value": null},
+		{"op": "replace", "path": "/text", "value": "Paranoia is in bloom"},
+		{"op": "replace", "path": "/album_id", "value": 7},
+		{"op": "add", "path": "/track_number", "value": 2},
+		{"op": "remove", "path": "/link"}
+	]`), current)
+	if err != nil {
+		t.Fatalf("parseJSONPatch() error = %v", err)
+	}
+
+	if !patch.Text.Set || patch.Text.Value == nil || *patch.Text.Value != "Paranoia is in bloom" {
+		t.Errorf("Text = %+v", patch.Text)
+	}
+	if !patch.AlbumID.Set || patch.AlbumID.Value == nil || *patch.AlbumID.Value != 7 {
+		t.Errorf("AlbumID = %+v", patch.AlbumID)
+	}
+	if !patch.TrackNumber.Set || patch.TrackNumber.Value == nil || *patch.TrackNumber.Value != 2 {
+		t.Errorf("TrackNumber = %+v", patch.TrackNumber)
+	}
+	if !patch.Link.Set || patch.Link.Value != nil {
+		t.Errorf("Link = %+v, want cleared", patch.Link)
+	}
+	if patch.Group.Set || patch.Name.Set || patch.ReleaseDate.Set {
+		t.Errorf("untouched fields are set: %+v", patch)
+	}
+}
+
+func TestJSONPatchTestOnSetFields(t *testing.T) {
+	current := domain.Song{
+		Group:       "Muse",
+		Name:        "Uprising",
+		ReleaseDate: time.Date(2009, 9, 7, 0, 0, 0, 0, time.UTC),
+		AlbumID:     7,
+		TrackNumber: 1,
+	}
+
+	_, err := parseJSONPatch([]byte(`[
+		{"op": "test", "path": "/release_date", "value": "07.09.2009"},
+		{"op": "test", "path": "/album_id", "value": 7}
+	]`), current)
+	if err != nil {
+		t.Fatalf("parseJSONPatch() error = %v", err)
+	}
+
+	_, err = parseJSONPatch([]byte(`[{"op": "test", "path": "/text", "value": ""}]`), current)
+	if !errors.Is(err, domain.ErrPatchTestFailed) {
+		t.Errorf("test of an empty text against \"\" error = %v, want %v", err, domain.ErrPatchTestFailed)
+	}
+}
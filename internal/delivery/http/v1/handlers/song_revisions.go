@@ -0,0 +1,206 @@
+package handlers
+
+import (
+	"github.com/NastyaAR/music_library/internal/domain"
+	"github.com/NastyaAR/music_library/internal/pkg/date_validate"
+	"github.com/NastyaAR/music_library/internal/pkg/error_handler"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+func toRevisionResponse(rev domain.SongRevision, withText bool) domain.SongRevisionResponse {
+	revResponse := domain.SongRevisionResponse{
+		Revision:    rev.Revision,
+		Action:      string(rev.Action),
+		CreatedAt:   rev.CreatedAt.Format(time.RFC3339),
+		Group:       rev.Song.Group,
+		Name:        rev.Song.Name,
+		ReleaseDate: date_validate.FormatDate(rev.Song.ReleaseDate),
+		Link:        rev.Song.Link,
+	}
+	if withText {
+		revResponse.Text = rev.Song.Text
+	}
+
+	return revResponse
+}
+
+func getRevisionParam(value string) (int, error) {
+	revision, err := strconv.Atoi(value)
+	if err != nil || revision < 1 {
+		return 0, domain.ErrBadRevision
+	}
+
+	return revision, nil
+}
+
+// GetRevisions godoc
+// @Summary      Get song revisions
+// @Description  get history of song changes, deleted songs keep their history
+// @Tags         songs
+// @Produce      json
+// @Param        id    path     int  true  "id of song"
+// @Success      200  {object}  domain.GetRevisionsResponse
+// @Failure      400  {object}  error_handler.HTTPError
+// @Failure      404  {object}  error_handler.HTTPError
+// @Failure      500  {object}  error_handler.HTTPError
+// @Router       /songs/{id}/revisions [get]
+func (h *SongHandler) GetRevisions(ctx *gin.Context) {
+	id, err := getIDParam(ctx)
+	if err != nil {
+		h.lg.Warn("song handler: get revisions error", zap.Error(err))
+		error_handler.NewError(ctx, err)
+		return
+	}
+
+	revisions, err := h.songUsecase.GetRevisions(ctx, id)
+	if err != nil {
+		h.lg.Warn("song handler: get revisions error", zap.Error(err))
+		error_handler.NewError(ctx, err)
+		return
+	}
+
+	revisionsResponse := domain.GetRevisionsResponse{Revisions: make([]domain.SongRevisionResponse, 0)}
+	for _, rev := range revisions {
+		revisionsResponse.Revisions = append(revisionsResponse.Revisions, toRevisionResponse(rev, false))
+	}
+
+	ctx.JSON(http.StatusOK, revisionsResponse)
+}
+
+// GetRevision godoc
+// @Summary      Get song revision
+// @Description  get state of song at the given revision
+// @Tags         songs
+// @Produce      json
+// @Param        id    path     int  true  "id of song"
+// @Param        rev    path     int  true  "revision"
+// @Success      200  {object}  domain.SongRevisionResponse
+// @Failure      400  {object}  error_handler.HTTPError
+// @Failure      404  {object}  error_handler.HTTPError
+// @Failure      500  {object}  error_handler.HTTPError
+// @Router       /songs/{id}/revisions/{rev} [get]
+func (h *SongHandler) GetRevision(ctx *gin.Context) {
+	id, err := getIDParam(ctx)
+	if err != nil {
+		h.lg.Warn("song handler: get revision error", zap.Error(err))
+		error_handler.NewError(ctx, err)
+		return
+	}
+
+	revision, err := getRevisionParam(ctx.Param("rev"))
+	if err != nil {
+		h.lg.Warn("song handler: get revision error", zap.Error(err))
+		error_handler.NewError(ctx, err)
+		return
+	}
+
+	rev, err := h.songUsecase.GetRevision(ctx, id, revision)
+	if err != nil {
+		h.lg.Warn("song handler: get revision error", zap.Error(err))
+		error_handler.NewError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, toRevisionResponse(rev, true))
+}
+
+// DiffRevisions godoc
+// @Summary      Diff song revisions
+// @Description  get line-level diff of song text between two revisions, 400 when the changed part is over 2000 lines
+// @Tags         songs
+// @Produce      json
+// @Param        id    path     int  true  "id of song"
+// @Param        from    query     int  true  "old revision"
+// @Param        to    query     int  true  "new revision"
+// @Success      200  {object}  domain.RevisionDiffResponse
+// @Failure      400  {object}  error_handler.HTTPError
+// @Failure      404  {object}  error_handler.HTTPError
+// @Failure      500  {object}  error_handler.HTTPError
+// @Router       /songs/{id}/revisions/diff [get]
+func (h *SongHandler) DiffRevisions(ctx *gin.Context) {
+	id, err := getIDParam(ctx)
+	if err != nil {
+		h.lg.Warn("song handler: diff revisions error", zap.Error(err))
+		error_handler.NewError(ctx, err)
+		return
+	}
+
+	from, err := getRevisionParam(ctx.Request.URL.Query().Get("from"))
+	if err != nil {
+		h.lg.Warn("song handler: diff revisions error", zap.Error(err))
+		error_handler.NewError(ctx, err)
+		return
+	}
+
+	to, err := getRevisionParam(ctx.Request.URL.Query().Get("to"))
+	if err != nil {
+		h.lg.Warn("song handler: diff revisions error", zap.Error(err))
+		error_handler.NewError(ctx, err)
+		return
+	}
+
+	lines, err := h.songUsecase.DiffRevisions(ctx, id, from, to)
+	if err != nil {
+		h.lg.Warn("song handler: diff revisions error", zap.Error(err))
+		error_handler.NewError(ctx, err)
+		return
+	}
+
+	diffResponse := domain.RevisionDiffResponse{From: from, To: to,
+		Lines: make([]domain.DiffLineResponse, 0)}
+	for _, line := range lines {
+		diffResponse.Lines = append(diffResponse.Lines, domain.DiffLineResponse{
+			Op:   string(line.Op),
+			Text: line.Text,
+		})
+	}
+
+	ctx.JSON(http.StatusOK, diffResponse)
+}
+
+// RestoreRevision godoc
+// @Summary      Restore song revision
+// @Description  restore song to the state of the given revision, deleted songs are recreated
+// @Tags         songs
+// @Produce      json
+// @Param        id    path     int  true  "id of song"
+// @Param        rev    path     int  true  "revision"
+// @Success      200  {object}  domain.CreateSongResponse
+// @Failure      400  {object}  error_handler.HTTPError
+// @Failure      401  {object}  error_handler.HTTPError
+// @Failure      403  {object}  error_handler.HTTPError
+// @Failure      404  {object}  error_handler.HTTPError
+// @Failure      409  {object}  error_handler.HTTPError
+// @Failure      500  {object}  error_handler.HTTPError
+// @Security     BearerAuth
+// @Security     ApiKeyAuth
+// @Router       /songs/{id}/revisions/{rev}/restore [post]
+func (h *SongHandler) RestoreRevision(ctx *gin.Context) {
+	id, err := getIDParam(ctx)
+	if err != nil {
+		h.lg.Warn("song handler: restore revision error", zap.Error(err))
+		error_handler.NewError(ctx, err)
+		return
+	}
+
+	revision, err := getRevisionParam(ctx.Param("rev"))
+	if err != nil {
+		h.lg.Warn("song handler: restore revision error", zap.Error(err))
+		error_handler.NewError(ctx, err)
+		return
+	}
+
+	song, err := h.songUsecase.RestoreRevision(ctx, id, revision)
+	if err != nil {
+		h.lg.Warn("song handler: restore revision error", zap.Error(err))
+		error_handler.NewError(ctx, err)
+		return
+	}
+
+	setETag(ctx, song)
+	ctx.JSON(http.StatusOK, toSongResponse(song))
+}
@@ -0,0 +1,153 @@
+package handlers
+
+import (
+	"github.com/NastyaAR/music_library/internal/domain"
+	"github.com/NastyaAR/music_library/internal/pkg/error_handler"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+	"io"
+	"net/http"
+	"strconv"
+)
+
+const defaultNextLines = 2
+
+func toSyncedLineResponse(line domain.SyncedLine) domain.SyncedLineResponse {
+	return domain.SyncedLineResponse{
+		TimeMs: line.TimeMs,
+		Text:   line.Text,
+	}
+}
+
+// ImportLRC godoc
+// @Summary      Import synced lyrics
+// @Description  replace synced lyrics of song with lyrics in LRC format
+// @Tags         songs
+// @Accept       plain
+// @Produce      json
+// @Param        id    path     int  true  "id of song"
+// @Param        lrc    body     string  true  "lyrics in LRC format"
+// @Success      200  {object}  domain.SyncedLyricsResponse
+// @Failure      400  {object}  error_handler.HTTPError
+// @Failure      401  {object}  error_handler.HTTPError
+// @Failure      403  {object}  error_handler.HTTPError
+// @Failure      404  {object}  error_handler.HTTPError
+// @Failure      500  {object}  error_handler.HTTPError
+// @Security     BearerAuth
+// @Security     ApiKeyAuth
+// @Router       /songs/{id}/lyrics/lrc [put]
+func (h *SongHandler) ImportLRC(ctx *gin.Context) {
+	id, err := getIDParam(ctx)
+	if err != nil {
+		h.lg.Warn("song handler: import lrc error", zap.Error(err))
+		error_handler.NewError(ctx, err)
+		return
+	}
+
+	body, err := io.ReadAll(ctx.Request.Body)
+	if err != nil {
+		h.lg.Warn("song handler: import lrc error", zap.Error(err))
+		error_handler.NewError(ctx, domain.ErrBadRequestBody)
+		return
+	}
+
+	lines, err := h.songUsecase.ImportLRC(ctx, id, string(body))
+	if err != nil {
+		h.lg.Warn("song handler: import lrc error", zap.Error(err))
+		error_handler.NewError(ctx, err)
+		return
+	}
+
+	lyricsResponse := domain.SyncedLyricsResponse{Lines: make([]domain.SyncedLineResponse, 0)}
+	for _, line := range lines {
+		lyricsResponse.Lines = append(lyricsResponse.Lines, toSyncedLineResponse(line))
+	}
+
+	ctx.JSON(http.StatusOK, lyricsResponse)
+}
+
+// ExportLRC godoc
+// @Summary      Export synced lyrics
+// @Description  get synced lyrics of song in LRC format
+// @Tags         songs
+// @Produce      plain
+// @Param        id    path     int  true  "id of song"
+// @Success      200  {string}  string
+// @Failure      400  {object}  error_handler.HTTPError
+// @Failure      404  {object}  error_handler.HTTPError
+// @Failure      500  {object}  error_handler.HTTPError
+// @Router       /songs/{id}/lyrics/lrc [get]
+func (h *SongHandler) ExportLRC(ctx *gin.Context) {
+	id, err := getIDParam(ctx)
+	if err != nil {
+		h.lg.Warn("song handler: export lrc error", zap.Error(err))
+		error_handler.NewError(ctx, err)
+		return
+	}
+
+	text, err := h.songUsecase.ExportLRC(ctx, id)
+	if err != nil {
+		h.lg.Warn("song handler: export lrc error", zap.Error(err))
+		error_handler.NewError(ctx, err)
+		return
+	}
+
+	ctx.Data(http.StatusOK, "text/plain; charset=utf-8", []byte(text))
+}
+
+// GetLyricsAt godoc
+// @Summary      Get lyrics at playback position
+// @Description  get the line playing at the given position and the lines after it
+// @Tags         songs
+// @Produce      json
+// @Param        id    path     int  true  "id of song"
+// @Param        ms    query     int  true  "playback position in milliseconds"
+// @Param        next    query     int  false  "number of next lines, 2 by default"
+// @Success      200  {object}  domain.LyricsAtResponse
+// @Failure      400  {object}  error_handler.HTTPError
+// @Failure      404  {object}  error_handler.HTTPError
+// @Failure      500  {object}  error_handler.HTTPError
+// @Router       /songs/{id}/lyrics/at [get]
+func (h *SongHandler) GetLyricsAt(ctx *gin.Context) {
+	id, err := getIDParam(ctx)
+	if err != nil {
+		h.lg.Warn("song handler: get lyrics at error", zap.Error(err))
+		error_handler.NewError(ctx, err)
+		return
+	}
+
+	ms, err := strconv.Atoi(ctx.Request.URL.Query().Get("ms"))
+	if err != nil {
+		h.lg.Warn("song handler: get lyrics at error", zap.Error(err))
+		error_handler.NewError(ctx, domain.ErrBadPosition)
+		return
+	}
+
+	next := defaultNextLines
+	if nextStr := ctx.Request.URL.Query().Get("next"); nextStr != "" {
+		next, err = strconv.Atoi(nextStr)
+		if err != nil {
+			h.lg.Warn("song handler: get lyrics at error", zap.Error(err))
+			error_handler.NewError(ctx, domain.ErrBadLimit)
+			return
+		}
+	}
+
+	at, err := h.songUsecase.GetLyricsAt(ctx, id, ms, next)
+	if err != nil {
+		h.lg.Warn("song handler: get lyrics at error", zap.Error(err))
+		error_handler.NewError(ctx, err)
+		return
+	}
+
+	atResponse := domain.LyricsAtResponse{Next: make([]domain.SyncedLineResponse, 0)}
+	if at.Current != nil {
+		current := toSyncedLineResponse(*at.Current)
+		atResponse.Current = &current
+	}
+	for _, line := range at.Next {
+		atResponse.Next = append(atResponse.Next, toSyncedLineResponse(line))
+	}
+
+	ctx.JSON(http.StatusOK, atResponse)
+}
@@ -0,0 +1,86 @@
+package handlers
+
+import (
+	"github.com/NastyaAR/music_library/internal/domain"
+	"github.com/NastyaAR/music_library/internal/pkg/error_handler"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+	"net/http"
+	"time"
+)
+
+// GetTrash godoc
+// @Summary      Get deleted songs
+// @Description  get songs in the trash, recently deleted first, for moderators
+// @Tags         trash
+// @Produce      json
+// @Param        limit    query     string  true  "songs on page"
+// @Param        offset    query     string  true  "page"
+// @Success      200  {object}  domain.GetTrashResponse
+// @Failure      400  {object}  error_handler.HTTPError
+// @Failure      401  {object}  error_handler.HTTPError
+// @Failure      403  {object}  error_handler.HTTPError
+// @Failure      500  {object}  error_handler.HTTPError
+// @Security     BearerAuth
+// @Security     ApiKeyAuth
+// @Router       /trash [get]
+func (h *SongHandler) GetTrash(ctx *gin.Context) {
+	limit, offset, err := getPageParams(ctx)
+	if err != nil {
+		h.lg.Warn("song handler: get trash error", zap.Error(err))
+		error_handler.NewError(ctx, err)
+		return
+	}
+
+	songs, err := h.songUsecase.GetTrash(ctx, limit, offset)
+	if err != nil {
+		h.lg.Warn("song handler: get trash error", zap.Error(err))
+		error_handler.NewError(ctx, err)
+		return
+	}
+
+	trashResponse := domain.GetTrashResponse{Songs: make([]domain.TrashedSongResponse, 0)}
+	for _, trashed := range songs {
+		trashResponse.Songs = append(trashResponse.Songs, domain.TrashedSongResponse{
+			CreateSongResponse: toSongResponse(trashed.Song),
+			DeletedAt:          trashed.DeletedAt.Format(time.RFC3339),
+		})
+	}
+
+	ctx.JSON(http.StatusOK, trashResponse)
+}
+
+// RestoreFromTrash godoc
+// @Summary      Restore deleted song
+// @Description  move song back from the trash
+// @Tags         trash
+// @Produce      json
+// @Param        id    path     int  true  "id of song"
+// @Success      200  {object}  domain.CreateSongResponse
+// @Failure      400  {object}  error_handler.HTTPError
+// @Failure      401  {object}  error_handler.HTTPError
+// @Failure      403  {object}  error_handler.HTTPError
+// @Failure      404  {object}  error_handler.HTTPError
+// @Failure      409  {object}  error_handler.HTTPError
+// @Failure      500  {object}  error_handler.HTTPError
+// @Security     BearerAuth
+// @Security     ApiKeyAuth
+// @Router       /trash/{id}/restore [post]
+func (h *SongHandler) RestoreFromTrash(ctx *gin.Context) {
+	id, err := getIDParam(ctx)
+	if err != nil {
+		h.lg.Warn("song handler: restore from trash error", zap.Error(err))
+		error_handler.NewError(ctx, err)
+		return
+	}
+
+	song, err := h.songUsecase.RestoreFromTrash(ctx, id)
+	if err != nil {
+		h.lg.Warn("song handler: restore from trash error", zap.Error(err))
+		error_handler.NewError(ctx, err)
+		return
+	}
+
+	setETag(ctx, song)
+	ctx.JSON(http.StatusOK, toSongResponse(song))
+}
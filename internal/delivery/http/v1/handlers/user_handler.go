@@ -0,0 +1,275 @@
+package handlers
+
+import (
+	"github.com/NastyaAR/music_library/internal/domain"
+	"github.com/NastyaAR/music_library/internal/pkg/error_handler"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+	"net/http"
+	"time"
+)
+
+type UserHandler struct {
+	userUsecase domain.UserUsecase
+	lg          *zap.Logger
+}
+
+func NewUserHandler(u domain.UserUsecase, lg *zap.Logger) *UserHandler {
+	return &UserHandler{
+		userUsecase: u,
+		lg:          lg,
+	}
+}
+
+func toUserResponse(user domain.User) domain.UserResponse {
+	userResponse := domain.UserResponse{
+		ID:       user.ID,
+		Username: user.Username,
+		Role:     string(user.Role),
+	}
+	if !user.CreatedAt.IsZero() {
+		userResponse.CreatedAt = user.CreatedAt.Format(time.RFC3339)
+	}
+	return userResponse
+}
+
+func toTokenResponse(pair domain.TokenPair) domain.TokenResponse {
+	return domain.TokenResponse{
+		AccessToken:  pair.AccessToken,
+		TokenType:    "Bearer",
+		ExpiresIn:    int(time.Until(pair.AccessExpiresAt).Seconds()),
+		RefreshToken: pair.RefreshToken,
+	}
+}
+
+// Register godoc
+// @Summary      Register
+// @Description  create a user, the username is 3 to 32 letters, digits, '.', '_' or '-' and the password 8 to 72 bytes
+// @Tags         auth
+// @Accept       json
+// @Produce      json
+// @Param        request body domain.CredentialsRequest true "username and password"
+// @Success      201  {object}  domain.UserResponse
+// @Failure      400  {object}  error_handler.HTTPError
+// @Failure      409  {object}  error_handler.HTTPError
+// @Failure      500  {object}  error_handler.HTTPError
+// @Router       /auth/register [post]
+func (h *UserHandler) Register(ctx *gin.Context) {
+	var req domain.CredentialsRequest
+	err := ctx.ShouldBindJSON(&req)
+	if err != nil {
+		h.lg.Warn("user handler: register error: bad body", zap.Error(err))
+		error_handler.NewError(ctx, domain.ErrBadRequestBody)
+		return
+	}
+
+	user, err := h.userUsecase.Register(ctx, req.Username, req.Password)
+	if err != nil {
+		h.lg.Warn("user handler: register error", zap.Error(err))
+		error_handler.NewError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, toUserResponse(user))
+}
+
+// Login godoc
+// @Summary      Login
+// @Description  trade username and password for an access token and a refresh token
+// @Tags         auth
+// @Accept       json
+// @Produce      json
+// @Param        request body domain.CredentialsRequest true "username and password"
+// @Success      200  {object}  domain.TokenResponse
+// @Failure      400  {object}  error_handler.HTTPError
+// @Failure      401  {object}  error_handler.HTTPError
+// @Failure      429  {object}  error_handler.HTTPError
+// @Failure      500  {object}  error_handler.HTTPError
+// @Router       /auth/login [post]
+func (h *UserHandler) Login(ctx *gin.Context) {
+	var req domain.CredentialsRequest
+	err := ctx.ShouldBindJSON(&req)
+	if err != nil {
+		h.lg.Warn("user handler: login error: bad body", zap.Error(err))
+		error_handler.NewError(ctx, domain.ErrBadRequestBody)
+		return
+	}
+
+	pair, err := h.userUsecase.Login(ctx, req.Username, req.Password)
+	if err != nil {
+		h.lg.Warn("user handler: login error", zap.Error(err))
+		error_handler.NewError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, toTokenResponse(pair))
+}
+
+// Refresh godoc
+// @Summary      Refresh tokens
+// @Description  trade a refresh token for a new pair of tokens, every refresh token works once
+// @Tags         auth
+// @Accept       json
+// @Produce      json
+// @Param        request body domain.RefreshRequest true "refresh token"
+// @Success      200  {object}  domain.TokenResponse
+// @Failure      400  {object}  error_handler.HTTPError
+// @Failure      401  {object}  error_handler.HTTPError
+// @Failure      429  {object}  error_handler.HTTPError
+// @Failure      500  {object}  error_handler.HTTPError
+// @Router       /auth/refresh [post]
+func (h *UserHandler) Refresh(ctx *gin.Context) {
+	var req domain.RefreshRequest
+	err := ctx.ShouldBindJSON(&req)
+	if err != nil {
+		h.lg.Warn("user handler: refresh error: bad body", zap.Error(err))
+		error_handler.NewError(ctx, domain.ErrBadRequestBody)
+		return
+	}
+
+	pair, err := h.userUsecase.Refresh(ctx, req.RefreshToken)
+	if err != nil {
+		h.lg.Warn("user handler: refresh error", zap.Error(err))
+		error_handler.NewError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, toTokenResponse(pair))
+}
+
+// Logout godoc
+// @Summary      Logout
+// @Description  revoke a refresh token, access tokens stay valid until they expire
+// @Tags         auth
+// @Accept       json
+// @Param        request body domain.RefreshRequest true "refresh token"
+// @Success      204
+// @Failure      400  {object}  error_handler.HTTPError
+// @Failure      401  {object}  error_handler.HTTPError
+// @Failure      500  {object}  error_handler.HTTPError
+// @Router       /auth/logout [post]
+func (h *UserHandler) Logout(ctx *gin.Context) {
+	var req domain.RefreshRequest
+	err := ctx.ShouldBindJSON(&req)
+	if err != nil {
+		h.lg.Warn("user handler: logout error: bad body", zap.Error(err))
+		error_handler.NewError(ctx, domain.ErrBadRequestBody)
+		return
+	}
+
+	err = h.userUsecase.Logout(ctx, req.RefreshToken)
+	if err != nil {
+		h.lg.Warn("user handler: logout error", zap.Error(err))
+		error_handler.NewError(ctx, err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+// Me godoc
+// @Summary      Current user
+// @Description  get the user of the access token
+// @Tags         auth
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200  {object}  domain.UserResponse
+// @Failure      401  {object}  error_handler.HTTPError
+// @Failure      404  {object}  error_handler.HTTPError
+// @Router       /auth/me [get]
+func (h *UserHandler) Me(ctx *gin.Context) {
+	identity, ok := domain.IdentityFrom(ctx.Request.Context())
+	if !ok {
+		h.lg.Warn("user handler: me error: anonymous")
+		unauthorized(ctx, domain.ErrUnauthorized)
+		return
+	}
+
+	user, err := h.userUsecase.Get(ctx, identity.UserID)
+	if err != nil {
+		h.lg.Warn("user handler: me error", zap.Error(err))
+		error_handler.NewError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, toUserResponse(user))
+}
+
+// GetAll godoc
+// @Summary      Get users
+// @Description  get users with limit and offset, only for admins
+// @Tags         users
+// @Produce      json
+// @Security     BearerAuth
+// @Param        limit    query     string  true  "users on page"
+// @Param        offset    query     string  true  "page"
+// @Success      200  {object}  domain.GetUsersResponse
+// @Failure      400  {object}  error_handler.HTTPError
+// @Failure      401  {object}  error_handler.HTTPError
+// @Failure      403  {object}  error_handler.HTTPError
+// @Failure      500  {object}  error_handler.HTTPError
+// @Router       /users [get]
+func (h *UserHandler) GetAll(ctx *gin.Context) {
+	limit, offset, err := getPageParams(ctx)
+	if err != nil {
+		h.lg.Warn("user handler: get all error", zap.Error(err))
+		error_handler.NewError(ctx, err)
+		return
+	}
+
+	users, err := h.userUsecase.GetAll(ctx, limit, offset)
+	if err != nil {
+		h.lg.Warn("user handler: get all error", zap.Error(err))
+		error_handler.NewError(ctx, err)
+		return
+	}
+
+	usersResponse := domain.GetUsersResponse{Users: make([]domain.UserResponse, 0)}
+	for _, u := range users {
+		usersResponse.Users = append(usersResponse.Users, toUserResponse(u))
+	}
+
+	ctx.JSON(http.StatusOK, usersResponse)
+}
+
+// SetRole godoc
+// @Summary      Set user role
+// @Description  set the role of a user: viewer, editor, moderator or admin, only for admins. The role is in the access token, so it works after the user refreshes the token
+// @Tags         users
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id    path     int  true  "id of user"
+// @Param        request body domain.SetRoleRequest true "new role"
+// @Success      200  {object}  domain.UserResponse
+// @Failure      400  {object}  error_handler.HTTPError
+// @Failure      401  {object}  error_handler.HTTPError
+// @Failure      403  {object}  error_handler.HTTPError
+// @Failure      404  {object}  error_handler.HTTPError
+// @Failure      500  {object}  error_handler.HTTPError
+// @Router       /users/{id}/role [put]
+func (h *UserHandler) SetRole(ctx *gin.Context) {
+	id, err := getIDParam(ctx)
+	if err != nil {
+		h.lg.Warn("user handler: set role error", zap.Error(err))
+		error_handler.NewError(ctx, err)
+		return
+	}
+
+	var req domain.SetRoleRequest
+	err = ctx.ShouldBindJSON(&req)
+	if err != nil {
+		h.lg.Warn("user handler: set role error: bad body", zap.Error(err))
+		error_handler.NewError(ctx, domain.ErrBadRequestBody)
+		return
+	}
+
+	user, err := h.userUsecase.SetRole(ctx, id, domain.Role(req.Role))
+	if err != nil {
+		h.lg.Warn("user handler: set role error", zap.Error(err))
+		error_handler.NewError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, toUserResponse(user))
+}
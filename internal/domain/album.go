@@ -0,0 +1,67 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+var ErrAddAlbumDB = errors.New("error while adding new album")
+var ErrDeleteAlbumDB = errors.New("error while deleting album")
+var ErrGetAlbumDB = errors.New("error while getting album")
+var ErrGetAllAlbumsDB = errors.New("error while getting albums")
+var ErrUpdateAlbumDB = errors.New("error while updating album")
+var ErrAlbumNotFound = errors.New("album not found")
+var ErrUnknownAlbumArtist = errors.New("album artist does not exist")
+var ErrNilAlbumRequest = errors.New("bad nil album request")
+var ErrBadAlbumTitle = errors.New("bad album title")
+var ErrBadArtistID = errors.New("bad artist id")
+var ErrBadTrackNumber = errors.New("bad track number")
+var ErrBadAlbumID = errors.New("bad album id")
+
+type Album struct {
+	ID          int64
+	Title       string
+	ArtistID    int64
+	ReleaseDate time.Time
+	CoverLink   string
+}
+
+type CreateAlbumRequest struct {
+	Title       string `json:"title"`
+	ArtistID    int64  `json:"artist_id"`
+	ReleaseDate string `json:"release_date,omitempty"`
+	CoverLink   string `json:"cover_link,omitempty"`
+}
+
+type AlbumResponse struct {
+	ID          int64  `json:"id"`
+	Title       string `json:"title"`
+	ArtistID    int64  `json:"artist_id"`
+	ReleaseDate string `json:"release_date"`
+	CoverLink   string `json:"cover_link"`
+}
+
+type GetAlbumsResponse struct {
+	Albums []AlbumResponse `json:"albums"`
+}
+
+type AlbumUsecase interface {
+	Create(ctx context.Context, createReq *Album) (Album, error)
+	Delete(ctx context.Context, id int64) error
+	Update(ctx context.Context, id int64, updReq *Album) (Album, error)
+	Get(ctx context.Context, id int64) (Album, error)
+	GetAll(ctx context.Context, limit int, offset int) ([]Album, error)
+	GetTracks(ctx context.Context, id int64) ([]Song, error)
+	GetByIDs(ctx context.Context, ids []int64) ([]Album, error)
+}
+
+type AlbumRepo interface {
+	Add(ctx context.Context, new *Album) (Album, error)
+	Delete(ctx context.Context, id int64) error
+	Update(ctx context.Context, id int64, upd *Album) (Album, error)
+	Get(ctx context.Context, id int64) (Album, error)
+	GetAll(ctx context.Context, limit int, offset int) ([]Album, error)
+	GetTracks(ctx context.Context, id int64) ([]Song, error)
+	GetByIDs(ctx context.Context, ids []int64) ([]Album, error)
+}
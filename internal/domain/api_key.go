@@ -0,0 +1,111 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+var ErrBadKeyName = errors.New("bad api key name")
+var ErrBadScope = errors.New("bad scope, expected songs:read, songs:write or songs:import")
+var ErrBadRateLimit = errors.New("bad rate limit")
+var ErrAPIKeyNotFound = errors.New("api key not found")
+var ErrInvalidAPIKey = errors.New("invalid or revoked api key")
+var ErrRateLimited = errors.New("too many requests")
+var ErrAddAPIKeyDB = errors.New("error while adding api key")
+var ErrGetAPIKeyDB = errors.New("error while getting api key")
+var ErrRevokeAPIKeyDB = errors.New("error while revoking api key")
+
+type Scope string
+
+const (
+	ScopeSongsRead   Scope = "songs:read"
+	ScopeSongsWrite  Scope = "songs:write"
+	ScopeSongsImport Scope = "songs:import"
+)
+
+func (s Scope) IsValid() bool {
+	switch s {
+	case ScopeSongsRead, ScopeSongsWrite, ScopeSongsImport:
+		return true
+	}
+	return false
+}
+
+// permissionScopes is the scope an api key needs on top of the role of its
+// owner, permissions missing here are not for api keys.
+var permissionScopes = map[Permission]Scope{
+	PermCreateSong:    ScopeSongsWrite,
+	PermUpdateSong:    ScopeSongsWrite,
+	PermDeleteSong:    ScopeSongsWrite,
+	PermRestoreSong:   ScopeSongsWrite,
+	PermImportSongs:   ScopeSongsImport,
+	PermEditPlaylists: ScopeSongsWrite,
+	PermEditArtists:   ScopeSongsWrite,
+	PermDeleteArtists: ScopeSongsWrite,
+	PermEditAlbums:    ScopeSongsWrite,
+	PermDeleteAlbums:  ScopeSongsWrite,
+}
+
+func PermissionScope(permission Permission) (Scope, bool) {
+	scope, ok := permissionScopes[permission]
+	return scope, ok
+}
+
+// APIKey lets a service act as the user who created it, limited to
+// the scopes of the key. Only the hash of the key is stored.
+type APIKey struct {
+	ID         int64
+	UserID     int64
+	Name       string
+	Prefix     string
+	Hash       string
+	Scopes     []Scope
+	RateLimit  int
+	CreatedAt  time.Time
+	LastUsedAt *time.Time
+	RevokedAt  *time.Time
+}
+
+type CreateAPIKeyRequest struct {
+	Name      string   `json:"name"`
+	Scopes    []string `json:"scopes"`
+	RateLimit int      `json:"rate_limit,omitempty"`
+}
+
+type APIKeyResponse struct {
+	ID         int64    `json:"id"`
+	Name       string   `json:"name"`
+	Prefix     string   `json:"prefix"`
+	Scopes     []string `json:"scopes"`
+	RateLimit  int      `json:"rate_limit"`
+	CreatedAt  string   `json:"created_at"`
+	LastUsedAt string   `json:"last_used_at,omitempty"`
+	RevokedAt  string   `json:"revoked_at,omitempty"`
+}
+
+type CreateAPIKeyResponse struct {
+	APIKeyResponse
+	Key string `json:"key"`
+}
+
+type GetAPIKeysResponse struct {
+	APIKeys []APIKeyResponse `json:"api_keys"`
+}
+
+type APIKeyUsecase interface {
+	// Create returns the key with the only copy of the plain key.
+	Create(ctx context.Context, name string, scopes []Scope, rateLimit int) (APIKey, string, error)
+	GetAll(ctx context.Context) ([]APIKey, error)
+	Revoke(ctx context.Context, id int64) error
+	Authenticate(ctx context.Context, key string) (Identity, error)
+}
+
+type APIKeyRepo interface {
+	Add(ctx context.Context, key *APIKey) (APIKey, error)
+	Get(ctx context.Context, id int64) (APIKey, error)
+	GetByUser(ctx context.Context, userID int64) ([]APIKey, error)
+	Revoke(ctx context.Context, id int64) error
+	// Use marks a valid key as used and returns it with its owner.
+	Use(ctx context.Context, hash string) (APIKey, User, error)
+}
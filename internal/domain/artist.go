@@ -0,0 +1,70 @@
+package domain
+
+import (
+	"context"
+	"errors"
+)
+
+var ErrAddArtistDB = errors.New("error while adding new artist")
+var ErrDeleteArtistDB = errors.New("error while deleting artist")
+var ErrGetArtistDB = errors.New("error while getting artist")
+var ErrGetAllArtistsDB = errors.New("error while getting artists")
+var ErrUpdateArtistDB = errors.New("error while updating artist")
+var ErrArtistNotFound = errors.New("artist not found")
+var ErrArtistExists = errors.New("artist with this name already exists")
+var ErrArtistInUse = errors.New("artist still has songs or albums")
+var ErrNilArtistRequest = errors.New("bad nil artist request")
+var ErrBadArtistName = errors.New("bad artist name")
+var ErrBadFormedYear = errors.New("bad formed year")
+
+type Artist struct {
+	ID          int64
+	Name        string
+	Country     string
+	FormedYear  int
+	Description string
+	Aliases     []string
+}
+
+type CreateArtistRequest struct {
+	Name        string   `json:"name"`
+	Country     string   `json:"country,omitempty"`
+	FormedYear  int      `json:"formed_year,omitempty"`
+	Description string   `json:"description,omitempty"`
+	Aliases     []string `json:"aliases,omitempty"`
+}
+
+type ArtistResponse struct {
+	ID          int64    `json:"id"`
+	Name        string   `json:"name"`
+	Country     string   `json:"country"`
+	FormedYear  int      `json:"formed_year"`
+	Description string   `json:"description"`
+	Aliases     []string `json:"aliases"`
+}
+
+type GetArtistsResponse struct {
+	Artists []ArtistResponse `json:"artists"`
+}
+
+type ArtistUsecase interface {
+	Create(ctx context.Context, createReq *Artist) (Artist, error)
+	Delete(ctx context.Context, id int64) error
+	Update(ctx context.Context, id int64, updReq *Artist) (Artist, error)
+	Get(ctx context.Context, id int64) (Artist, error)
+	GetAll(ctx context.Context, limit int, offset int) ([]Artist, error)
+	GetSongs(ctx context.Context, id int64, limit int, offset int) ([]Song, error)
+	GetByIDs(ctx context.Context, ids []int64) ([]Artist, error)
+	GetSongsByArtists(ctx context.Context, ids []int64, limit int) (map[int64][]Song, error)
+}
+
+type ArtistRepo interface {
+	Add(ctx context.Context, new *Artist) (Artist, error)
+	Delete(ctx context.Context, id int64) error
+	Update(ctx context.Context, id int64, upd *Artist) (Artist, error)
+	Get(ctx context.Context, id int64) (Artist, error)
+	GetAll(ctx context.Context, limit int, offset int) ([]Artist, error)
+	GetSongs(ctx context.Context, id int64, limit int, offset int) ([]Song, error)
+	GetByIDs(ctx context.Context, ids []int64) ([]Artist, error)
+	GetSongsByArtists(ctx context.Context, ids []int64, limit int) (map[int64][]Song, error)
+}
@@ -0,0 +1,55 @@
+package domain
+
+import (
+	"errors"
+	"strings"
+)
+
+var ErrBadSectionType = errors.New("bad section type")
+var ErrSectionNotFound = errors.New("section not found")
+
+type SectionType string
+
+const (
+	SectionIntro     SectionType = "intro"
+	SectionVerse     SectionType = "verse"
+	SectionPreChorus SectionType = "pre-chorus"
+	SectionChorus    SectionType = "chorus"
+	SectionHook      SectionType = "hook"
+	SectionBridge    SectionType = "bridge"
+	SectionOutro     SectionType = "outro"
+)
+
+func (t SectionType) IsValid() bool {
+	switch t {
+	case SectionIntro, SectionVerse, SectionPreChorus, SectionChorus,
+		SectionHook, SectionBridge, SectionOutro:
+		return true
+	}
+	return false
+}
+
+// LyricsSection is one block of the lyrics. Order is the position in the
+// song and Index the number among sections of the same type, both from 1.
+type LyricsSection struct {
+	Type  SectionType
+	Index int
+	Order int
+	Lines []string
+}
+
+func (s LyricsSection) Text() string {
+	return strings.Join(s.Lines, "\n")
+}
+
+type LyricsSectionResponse struct {
+	Type  string   `json:"type"`
+	Index int      `json:"index"`
+	Order int      `json:"order"`
+	Lines []string `json:"lines"`
+	Text  string   `json:"text"`
+}
+
+type GetSectionsResponse struct {
+	Sections []LyricsSectionResponse `json:"sections"`
+}
@@ -0,0 +1,107 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+var ErrBadPlaylistName = errors.New("bad playlist name")
+var ErrBadPlaylistPosition = errors.New("bad playlist position")
+var ErrPlaylistNotFound = errors.New("playlist not found")
+var ErrPlaylistItemNotFound = errors.New("playlist item not found")
+var ErrAddPlaylistDB = errors.New("error while adding new playlist")
+var ErrGetPlaylistDB = errors.New("error while getting playlist")
+var ErrUpdatePlaylistDB = errors.New("error while updating playlist")
+var ErrDeletePlaylistDB = errors.New("error while deleting playlist")
+var ErrPlaylistItemDB = errors.New("error while changing playlist items")
+
+// Playlist is an ordered list of songs of a user. Private playlists are
+// seen only by their owner.
+type Playlist struct {
+	ID        int64
+	UserID    int64
+	Name      string
+	Public    bool
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	Items     []PlaylistItem
+}
+
+// PlaylistItem is a song at a position, positions go from 1 without gaps.
+// A song can be in a playlist more than once.
+type PlaylistItem struct {
+	ID       int64
+	SongID   int64
+	Position int
+	AddedAt  time.Time
+	Song     Song
+}
+
+type CreatePlaylistRequest struct {
+	Name   string `json:"name"`
+	Public bool   `json:"public"`
+}
+
+// UpdatePlaylistRequest renames and shares, absent fields stay as they are.
+type UpdatePlaylistRequest struct {
+	Name   *string `json:"name,omitempty"`
+	Public *bool   `json:"public,omitempty"`
+}
+
+type AddPlaylistItemRequest struct {
+	SongID int64 `json:"song_id"`
+	// Position to insert at, the end of the playlist when absent
+	Position int `json:"position,omitempty"`
+}
+
+type MovePlaylistItemRequest struct {
+	Position int `json:"position"`
+}
+
+type PlaylistItemResponse struct {
+	ID       int64              `json:"id"`
+	Position int                `json:"position"`
+	AddedAt  string             `json:"added_at"`
+	Song     CreateSongResponse `json:"song"`
+}
+
+type PlaylistResponse struct {
+	ID        int64                  `json:"id"`
+	OwnerID   int64                  `json:"owner_id"`
+	Name      string                 `json:"name"`
+	Public    bool                   `json:"public"`
+	CreatedAt string                 `json:"created_at"`
+	UpdatedAt string                 `json:"updated_at"`
+	Items     []PlaylistItemResponse `json:"items,omitempty"`
+}
+
+type GetPlaylistsResponse struct {
+	Playlists []PlaylistResponse `json:"playlists"`
+}
+
+type PlaylistUsecase interface {
+	Create(ctx context.Context, name string, public bool) (Playlist, error)
+	Update(ctx context.Context, id int64, name *string, public *bool) (Playlist, error)
+	Delete(ctx context.Context, id int64) error
+	Get(ctx context.Context, id int64) (Playlist, error)
+	GetAll(ctx context.Context) ([]Playlist, error)
+	AddItem(ctx context.Context, id int64, songID int64, position int) (Playlist, error)
+	RemoveItem(ctx context.Context, id int64, itemID int64) (Playlist, error)
+	MoveItem(ctx context.Context, id int64, itemID int64, position int) (Playlist, error)
+}
+
+type PlaylistRepo interface {
+	Add(ctx context.Context, new *Playlist) (Playlist, error)
+	Update(ctx context.Context, id int64, upd *Playlist) (Playlist, error)
+	Delete(ctx context.Context, id int64) error
+	// Get returns the playlist with its items in order.
+	Get(ctx context.Context, id int64) (Playlist, error)
+	GetByUser(ctx context.Context, userID int64) ([]Playlist, error)
+	// AddItem inserts at position and moves the items from it down,
+	// position 0 or past the end appends.
+	AddItem(ctx context.Context, id int64, songID int64, position int) error
+	RemoveItem(ctx context.Context, id int64, itemID int64) error
+	// MoveItem puts the item at position, past the end means last.
+	MoveItem(ctx context.Context, id int64, itemID int64, position int) error
+}
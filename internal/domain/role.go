@@ -0,0 +1,67 @@
+package domain
+
+import "errors"
+
+var ErrBadRole = errors.New("bad role, expected viewer, editor, moderator or admin")
+var ErrForbidden = errors.New("not enough rights")
+
+// Role of a user, every role can do all that the roles before it can.
+type Role string
+
+const (
+	RoleViewer    Role = "viewer"
+	RoleEditor    Role = "editor"
+	RoleModerator Role = "moderator"
+	RoleAdmin     Role = "admin"
+)
+
+var roleRanks = map[Role]int{
+	RoleViewer:    1,
+	RoleEditor:    2,
+	RoleModerator: 3,
+	RoleAdmin:     4,
+}
+
+func (r Role) IsValid() bool {
+	_, ok := roleRanks[r]
+	return ok
+}
+
+type Permission string
+
+const (
+	PermCreateSong    Permission = "songs:create"
+	PermUpdateSong    Permission = "songs:update"
+	PermDeleteSong    Permission = "songs:delete"
+	PermRestoreSong   Permission = "songs:restore"
+	PermImportSongs   Permission = "songs:import"
+	PermManageUsers   Permission = "users:manage"
+	PermEditPlaylists Permission = "playlists:edit"
+	PermEditArtists   Permission = "artists:edit"
+	PermDeleteArtists Permission = "artists:delete"
+	PermEditAlbums    Permission = "albums:edit"
+	PermDeleteAlbums  Permission = "albums:delete"
+)
+
+// permissionRoles is the lowest role that has a permission.
+var permissionRoles = map[Permission]Role{
+	PermCreateSong:    RoleEditor,
+	PermUpdateSong:    RoleEditor,
+	PermDeleteSong:    RoleModerator,
+	PermRestoreSong:   RoleModerator,
+	PermImportSongs:   RoleEditor,
+	PermManageUsers:   RoleAdmin,
+	PermEditPlaylists: RoleViewer,
+	PermEditArtists:   RoleEditor,
+	PermDeleteArtists: RoleModerator,
+	PermEditAlbums:    RoleEditor,
+	PermDeleteAlbums:  RoleModerator,
+}
+
+func (r Role) Can(permission Permission) bool {
+	required, ok := permissionRoles[permission]
+	if !ok {
+		return false
+	}
+	return roleRanks[r] >= roleRanks[required]
+}
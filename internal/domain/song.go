@@ -17,15 +17,34 @@ var ErrBadLimit = errors.New("bad limit")
 var ErrBadOffset = errors.New("bad offset")
 var ErrInternalServer = errors.New("something wrong while creating song")
 var ErrQueryParams = errors.New("bad query params")
+var ErrBadID = errors.New("bad id")
+var ErrBadRequestBody = errors.New("bad request body")
+var ErrSongNotFound = errors.New("song not found")
+var ErrSongExists = errors.New("song already exists")
+var ErrTrackTaken = errors.New("track number is taken on the album")
+var ErrConflict = errors.New("song was changed concurrently or refers to missing data")
+var ErrGetSongDB = errors.New("error while getting song")
+var ErrUpdateSongDB = errors.New("error while updating song")
+var ErrPreconditionFailed = errors.New("song version does not match")
+var ErrTooManyIDs = errors.New("too many ids")
 
 var TimeLayout = "16.07.2006"
 
+// MaxBatchIDs bounds the ids of one batched get.
+const MaxBatchIDs = 500
+
 type Song struct {
+	ID          int64
+	ArtistID    int64
 	Group       string
 	Name        string
 	ReleaseDate time.Time
 	Text        string
 	Link        string
+	AlbumID     int64
+	TrackNumber int
+	Sections    []LyricsSection
+	Version     int
 }
 
 type UpdateSongRequest struct {
@@ -34,14 +53,21 @@ type UpdateSongRequest struct {
 	ReleaseDate string `json:"release_date,omitempty"`
 	Text        string `json:"text,omitempty"`
 	Link        string `json:"link,omitempty"`
+	AlbumID     int64  `json:"album_id,omitempty"`
+	TrackNumber int    `json:"track_number,omitempty"`
 }
 
 type CreateSongResponse struct {
+	ID          int64  `json:"id"`
+	ArtistID    int64  `json:"artist_id"`
 	Group       string `json:"group"`
 	Name        string `json:"name"`
 	ReleaseDate string `json:"release_date"`
 	Text        string `json:"text"`
 	Link        string `json:"link"`
+	AlbumID     int64  `json:"album_id,omitempty"`
+	TrackNumber int    `json:"track_number,omitempty"`
+	Version     int    `json:"version"`
 }
 
 type CreateSongRequest struct {
@@ -50,35 +76,85 @@ type CreateSongRequest struct {
 	ReleaseDate string `json:"release_date,omitempty"`
 	Text        string `json:"text,omitempty"`
 	Link        string `json:"link,omitempty"`
+	AlbumID     int64  `json:"album_id,omitempty"`
+	TrackNumber int    `json:"track_number,omitempty"`
 }
 
 type GetSongResponse struct {
+	ID          int64  `json:"id"`
 	ReleaseDate string `json:"release_date"`
 	Text        string `json:"text"`
 	Link        string `json:"link"`
 }
 
 type GetSongsResponse struct {
-	Songs []CreateSongResponse `json:"songs"`
+	Songs      []CreateSongResponse `json:"songs"`
+	Total      *int                 `json:"total,omitempty"`
+	HasMore    bool                 `json:"has_more,omitempty"`
+	NextCursor string               `json:"next_cursor,omitempty"`
+	PrevCursor string               `json:"prev_cursor,omitempty"`
 }
 
 type GetCoupletResponse struct {
 	Couplet string `json:"couplet"`
+	StartMs *int   `json:"start_ms,omitempty"`
+	EndMs   *int   `json:"end_ms,omitempty"`
 }
 
 type SongUsecase interface {
 	Create(ctx context.Context, createReq *Song) (Song, error)
-	Delete(ctx context.Context, group string, name string) error
-	Update(ctx context.Context, group string, name string, updReq *Song) (Song, error)
-	GetSongs(ctx context.Context, filter *Song, limit int, offset int) ([]Song, error)
+	Delete(ctx context.Context, group string, name string, version int) error
+	Update(ctx context.Context, group string, name string, patch *SongPatch) (Song, error)
+	GetSongs(ctx context.Context, filter *SongFilter, page *Pagination) (SongsPage, error)
 	Get(ctx context.Context, group string, name string) (Song, error)
-	GetСouplet(ctx context.Context, group string, name string, offset int) (string, error)
+	GetСouplet(ctx context.Context, group string, name string, offset int) (Couplet, error)
+	DeleteByID(ctx context.Context, id int64, version int) error
+	UpdateByID(ctx context.Context, id int64, patch *SongPatch) (Song, error)
+	GetByID(ctx context.Context, id int64) (Song, error)
+	GetCoupletByID(ctx context.Context, id int64, offset int) (Couplet, error)
+	Search(ctx context.Context, query string, lang SearchLanguage, limit int, offset int) ([]SongSearchResult, error)
+	GetSections(ctx context.Context, id int64) ([]LyricsSection, error)
+	GetSection(ctx context.Context, id int64, sectionType SectionType, index int) (LyricsSection, error)
+	ImportLRC(ctx context.Context, id int64, lrc string) ([]SyncedLine, error)
+	ExportLRC(ctx context.Context, id int64) (string, error)
+	GetLyricsAt(ctx context.Context, id int64, ms int, next int) (LyricsAt, error)
+	GetRevisions(ctx context.Context, id int64) ([]SongRevision, error)
+	GetRevision(ctx context.Context, id int64, revision int) (SongRevision, error)
+	DiffRevisions(ctx context.Context, id int64, from int, to int) ([]DiffLine, error)
+	RestoreRevision(ctx context.Context, id int64, revision int) (Song, error)
+	GetTrash(ctx context.Context, limit int, offset int) ([]TrashedSong, error)
+	RestoreFromTrash(ctx context.Context, id int64) (Song, error)
+	PurgeTrash(ctx context.Context, retention time.Duration) (int64, error)
+	Import(ctx context.Context, rows []ImportRow, mode ImportMode) (ImportReport, error)
+	StartImport(ctx context.Context, rows []ImportRow, mode ImportMode) (ImportJob, error)
+	GetImportJob(ctx context.Context, id string) (ImportJob, error)
+	Export(ctx context.Context, filter *SongFilter, fn ExportFunc) error
+	GetByIDs(ctx context.Context, ids []int64) ([]Song, error)
+	GetCoupletsByIDs(ctx context.Context, ids []int64, offset int) (map[int64]Couplet, error)
 }
 
 type SongRepo interface {
 	Add(ctx context.Context, new *Song) (Song, error)
-	Delete(ctx context.Context, group string, name string) error
-	Update(ctx context.Context, group string, name string, upd *Song) (Song, error)
+	Delete(ctx context.Context, group string, name string, version int) error
+	Update(ctx context.Context, group string, name string, patch *SongPatch) (Song, error)
 	Get(ctx context.Context, group string, name string) (Song, error)
-	GetAll(ctx context.Context, filter *Song, limit int, offset int) ([]Song, error)
+	GetAll(ctx context.Context, filter *SongFilter, limit int, offset int) ([]Song, error)
+	GetByCursor(ctx context.Context, filter *SongFilter, cursor *SongCursor, limit int) ([]Song, error)
+	Count(ctx context.Context, filter *SongFilter) (int, error)
+	DeleteByID(ctx context.Context, id int64, version int) error
+	UpdateByID(ctx context.Context, id int64, patch *SongPatch) (Song, error)
+	GetByID(ctx context.Context, id int64) (Song, error)
+	Search(ctx context.Context, query string, lang SearchLanguage, limit int, offset int) ([]SongSearchResult, error)
+	SetSyncedLyrics(ctx context.Context, id int64, lines []SyncedLine) error
+	GetSyncedLyrics(ctx context.Context, id int64) ([]SyncedLine, error)
+	GetRevisions(ctx context.Context, id int64) ([]SongRevision, error)
+	GetRevision(ctx context.Context, id int64, revision int) (SongRevision, error)
+	Restore(ctx context.Context, song *Song) (Song, error)
+	GetDeleted(ctx context.Context, limit int, offset int) ([]TrashedSong, error)
+	RestoreDeleted(ctx context.Context, id int64) (Song, error)
+	PurgeDeleted(ctx context.Context, before time.Time) (int64, error)
+	Import(ctx context.Context, rows []ImportRow, mode ImportMode) ([]ImportRowResult, error)
+	Export(ctx context.Context, filter *SongFilter, fn ExportFunc) error
+	GetByIDs(ctx context.Context, ids []int64) ([]Song, error)
+	GetSyncedLyricsByIDs(ctx context.Context, ids []int64) (map[int64][]SyncedLine, error)
 }
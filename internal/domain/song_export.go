@@ -0,0 +1,10 @@
+package domain
+
+import "errors"
+
+var ErrBadExportFormat = errors.New("bad export format, expected json, ndjson, csv or xlsx")
+var ErrExportSongsDB = errors.New("error while exporting songs")
+
+// ExportFunc receives exported songs one by one, an error stops the export
+// and is returned as is.
+type ExportFunc func(song Song) error
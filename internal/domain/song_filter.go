@@ -0,0 +1,139 @@
+package domain
+
+import (
+	"errors"
+	"strings"
+	"time"
+)
+
+var ErrBadMatchMode = errors.New("bad match mode")
+var ErrBadSort = errors.New("bad sort")
+var ErrBadReleaseRange = errors.New("bad release date range")
+
+// MatchMode defines how a string filter value is compared with a column.
+// Prefix and contains matching ignore case.
+type MatchMode string
+
+const (
+	MatchExact       MatchMode = "exact"
+	MatchPrefix      MatchMode = "prefix"
+	MatchContains    MatchMode = "contains"
+	MatchInsensitive MatchMode = "icase"
+	MatchSimilar     MatchMode = "similar"
+)
+
+func (m MatchMode) IsValid() bool {
+	switch m {
+	case "", MatchExact, MatchPrefix, MatchContains, MatchInsensitive, MatchSimilar:
+		return true
+	}
+	return false
+}
+
+type StringFilter struct {
+	Value string
+	Match MatchMode
+}
+
+type SortField string
+
+const (
+	SortByID          SortField = "id"
+	SortByGroup       SortField = "group"
+	SortByName        SortField = "name"
+	SortByReleaseDate SortField = "release_date"
+)
+
+type SortKey struct {
+	Field SortField
+	Desc  bool
+}
+
+// ParseSort splits a sort parameter like "-release_date,name" into keys,
+// a leading minus means descending order. Keys are not validated here.
+func ParseSort(sort string) []SortKey {
+	keys := make([]SortKey, 0)
+	for _, part := range strings.Split(sort, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+
+		key := SortKey{Field: SortField(part)}
+		if strings.HasPrefix(part, "-") {
+			key = SortKey{Field: SortField(part[1:]), Desc: true}
+		}
+		keys = append(keys, key)
+	}
+
+	return keys
+}
+
+type SongFilter struct {
+	Group        StringFilter
+	Name         StringFilter
+	ReleaseDate  time.Time
+	ReleasedFrom time.Time
+	ReleasedTo   time.Time
+	Year         int
+	Decade       int
+	Text         StringFilter
+	Link         StringFilter
+	AlbumID      int64
+	Sort         []SortKey
+}
+
+func (f *SongFilter) StringFilters() []StringFilter {
+	return []StringFilter{f.Group, f.Name, f.Text, f.Link}
+}
+
+var ErrBadCursor = errors.New("bad cursor")
+var ErrCountSongsDB = errors.New("error while counting songs")
+
+// Pagination selects page-number mode when Page is set and keyset mode
+// otherwise. Cursor is the opaque value returned in a previous page.
+type Pagination struct {
+	Limit  int
+	Page   int
+	Cursor string
+}
+
+// SongCursor is a decoded keyset cursor. Only the sort column values of
+// Boundary are used; Backward asks for the rows before it.
+type SongCursor struct {
+	Boundary Song
+	Backward bool
+}
+
+// SongsPage is one page of songs. Total is counted on the first page only
+// and is nil on the pages reached by a cursor.
+type SongsPage struct {
+	Songs      []Song
+	Total      *int
+	HasMore    bool
+	NextCursor string
+	PrevCursor string
+}
+
+func (f *SongFilter) HasSimilarity() bool {
+	for _, sf := range f.StringFilters() {
+		if sf.Value != "" && sf.Match == MatchSimilar {
+			return true
+		}
+	}
+	return false
+}
+
+// SortSignature is the canonical form of the sort keys, used to tie
+// a cursor to the ordering it was issued for.
+func (f *SongFilter) SortSignature() string {
+	parts := make([]string, 0, len(f.Sort))
+	for _, key := range f.Sort {
+		if key.Desc {
+			parts = append(parts, "-"+string(key.Field))
+		} else {
+			parts = append(parts, string(key.Field))
+		}
+	}
+	return strings.Join(parts, ",")
+}
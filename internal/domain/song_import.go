@@ -0,0 +1,112 @@
+package domain
+
+import (
+	"errors"
+	"time"
+)
+
+var ErrBadImportMode = errors.New("bad import mode")
+var ErrUnsupportedImportType = errors.New("unsupported import content type")
+var ErrImportSongsDB = errors.New("error while importing songs")
+var ErrImportJobNotFound = errors.New("import job not found")
+var ErrImportTooLarge = errors.New("import body is too large")
+
+// ImportMode tells what to do with a row whose song already exists.
+type ImportMode string
+
+const (
+	ImportSkip      ImportMode = "skip"
+	ImportOverwrite ImportMode = "overwrite"
+	ImportFail      ImportMode = "fail"
+)
+
+func (m ImportMode) IsValid() bool {
+	switch m {
+	case ImportSkip, ImportOverwrite, ImportFail:
+		return true
+	}
+	return false
+}
+
+type ImportStatus string
+
+const (
+	ImportCreated ImportStatus = "created"
+	ImportUpdated ImportStatus = "updated"
+	ImportSkipped ImportStatus = "skipped"
+	ImportFailed  ImportStatus = "failed"
+)
+
+// ImportRow is a song read from row Row of the import, Err is set when
+// the row could not be read.
+type ImportRow struct {
+	Row  int
+	Song Song
+	Err  error
+}
+
+type ImportRowResult struct {
+	Row    int
+	Status ImportStatus
+	SongID int64
+	Error  string
+}
+
+// ImportReport has a result for every row. In fail mode a single failed
+// row aborts the import and nothing is written.
+type ImportReport struct {
+	Rows    []ImportRowResult
+	Created int
+	Updated int
+	Skipped int
+	Failed  int
+	Aborted bool
+}
+
+type ImportJobStatus string
+
+const (
+	ImportJobRunning  ImportJobStatus = "running"
+	ImportJobFinished ImportJobStatus = "finished"
+	ImportJobFailed   ImportJobStatus = "failed"
+)
+
+// ImportJob is seen only by OwnerID, the user who started it.
+type ImportJob struct {
+	ID         string
+	OwnerID    int64
+	Status     ImportJobStatus
+	Mode       ImportMode
+	Total      int
+	Report     *ImportReport
+	Error      string
+	CreatedAt  time.Time
+	FinishedAt time.Time
+}
+
+type ImportRowResponse struct {
+	Row    int    `json:"row"`
+	Status string `json:"status"`
+	SongID int64  `json:"song_id,omitempty"`
+	Error  string `json:"error,omitempty"`
+}
+
+type ImportReportResponse struct {
+	Created int                 `json:"created"`
+	Updated int                 `json:"updated"`
+	Skipped int                 `json:"skipped"`
+	Failed  int                 `json:"failed"`
+	Aborted bool                `json:"aborted"`
+	Rows    []ImportRowResponse `json:"rows"`
+}
+
+type ImportJobResponse struct {
+	ID         string                `json:"id"`
+	Status     string                `json:"status"`
+	Mode       string                `json:"mode"`
+	Total      int                   `json:"total"`
+	CreatedAt  string                `json:"created_at"`
+	FinishedAt string                `json:"finished_at,omitempty"`
+	Error      string                `json:"error,omitempty"`
+	Report     *ImportReportResponse `json:"report,omitempty"`
+}
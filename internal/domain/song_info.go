@@ -0,0 +1,20 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+var ErrSongInfoNotFound = errors.New("song info not found")
+var ErrSongInfoUnavailable = errors.New("song info provider unavailable")
+
+type SongDetail struct {
+	ReleaseDate time.Time
+	Text        string
+	Link        string
+}
+
+type SongInfoProvider interface {
+	GetInfo(ctx context.Context, group string, name string) (SongDetail, error)
+}
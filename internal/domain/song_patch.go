@@ -0,0 +1,70 @@
+package domain
+
+import (
+	"errors"
+	"time"
+)
+
+var ErrBadPatch = errors.New("bad patch")
+var ErrPatchTestFailed = errors.New("patch test operation failed")
+var ErrUnsupportedPatchType = errors.New("unsupported patch content type")
+
+// PatchField is a field of a partial update. Set reports the field was
+// present in the patch, a set field with nil Value clears it.
+type PatchField[T any] struct {
+	Set   bool
+	Value *T
+}
+
+func (f PatchField[T]) Cleared() bool {
+	return f.Set && f.Value == nil
+}
+
+func (f PatchField[T]) apply(current T) T {
+	if !f.Set {
+		return current
+	}
+	if f.Value == nil {
+		var zero T
+		return zero
+	}
+	return *f.Value
+}
+
+// SongPatch changes only the fields present in it. Sections go along with
+// a changed text. Version is the version the song is expected to have,
+// zero skips the check.
+type SongPatch struct {
+	Group       PatchField[string]
+	Name        PatchField[string]
+	ReleaseDate PatchField[time.Time]
+	Text        PatchField[string]
+	Link        PatchField[string]
+	AlbumID     PatchField[int64]
+	TrackNumber PatchField[int]
+	Sections    []LyricsSection
+	Version     int
+}
+
+// Apply returns the song with the patch applied. Cleared fields get the
+// value of a song created without them, clearing the album also clears
+// the track number.
+func (p *SongPatch) Apply(song Song) Song {
+	song.Group = p.Group.apply(song.Group)
+	song.Name = p.Name.apply(song.Name)
+	song.ReleaseDate = p.ReleaseDate.apply(song.ReleaseDate)
+	song.Link = p.Link.apply(song.Link)
+	song.AlbumID = p.AlbumID.apply(song.AlbumID)
+	song.TrackNumber = p.TrackNumber.apply(song.TrackNumber)
+
+	if p.Text.Set {
+		song.Text = p.Text.apply(song.Text)
+		song.Sections = p.Sections
+	}
+
+	if song.AlbumID == 0 && !p.TrackNumber.Set {
+		song.TrackNumber = 0
+	}
+
+	return song
+}
@@ -0,0 +1,69 @@
+package domain
+
+import (
+	"errors"
+	"time"
+)
+
+var ErrBadRevision = errors.New("bad revision")
+var ErrRevisionNotFound = errors.New("revision not found")
+var ErrDiffTooLarge = errors.New("texts are too large to diff")
+var ErrGetRevisionsDB = errors.New("error while getting song revisions")
+var ErrRestoreSongDB = errors.New("error while restoring song")
+
+type RevisionAction string
+
+const (
+	RevisionCreate  RevisionAction = "create"
+	RevisionUpdate  RevisionAction = "update"
+	RevisionDelete  RevisionAction = "delete"
+	RevisionRestore RevisionAction = "restore"
+)
+
+// SongRevision is the state of the song after the action, for a delete
+// it is the state the song had when it was deleted.
+type SongRevision struct {
+	Revision  int
+	Action    RevisionAction
+	Song      Song
+	CreatedAt time.Time
+}
+
+type DiffOp string
+
+const (
+	DiffEqual  DiffOp = "equal"
+	DiffInsert DiffOp = "insert"
+	DiffDelete DiffOp = "delete"
+)
+
+type DiffLine struct {
+	Op   DiffOp
+	Text string
+}
+
+type SongRevisionResponse struct {
+	Revision    int    `json:"revision"`
+	Action      string `json:"action"`
+	CreatedAt   string `json:"created_at"`
+	Group       string `json:"group"`
+	Name        string `json:"name"`
+	ReleaseDate string `json:"release_date"`
+	Text        string `json:"text,omitempty"`
+	Link        string `json:"link"`
+}
+
+type GetRevisionsResponse struct {
+	Revisions []SongRevisionResponse `json:"revisions"`
+}
+
+type DiffLineResponse struct {
+	Op   string `json:"op"`
+	Text string `json:"text"`
+}
+
+type RevisionDiffResponse struct {
+	From  int                `json:"from"`
+	To    int                `json:"to"`
+	Lines []DiffLineResponse `json:"lines"`
+}
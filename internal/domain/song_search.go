@@ -0,0 +1,37 @@
+package domain
+
+import "errors"
+
+var ErrSearchSongsDB = errors.New("error while searching songs")
+var ErrBadSearchQuery = errors.New("bad search query")
+var ErrBadSearchLanguage = errors.New("bad search language")
+
+type SearchLanguage string
+
+const (
+	SearchEnglish SearchLanguage = "english"
+	SearchRussian SearchLanguage = "russian"
+)
+
+func (l SearchLanguage) IsValid() bool {
+	return l == SearchEnglish || l == SearchRussian
+}
+
+type SongSearchResult struct {
+	Song    Song
+	Rank    float64
+	Snippet string
+}
+
+type SearchSongResponse struct {
+	ID       int64   `json:"id"`
+	ArtistID int64   `json:"artist_id"`
+	Group    string  `json:"group"`
+	Name     string  `json:"name"`
+	Rank     float64 `json:"rank"`
+	Snippet  string  `json:"snippet"`
+}
+
+type SearchSongsResponse struct {
+	Results []SearchSongResponse `json:"results"`
+}
@@ -0,0 +1,45 @@
+package domain
+
+import "errors"
+
+var ErrBadLRC = errors.New("bad lrc lyrics")
+var ErrBadPosition = errors.New("bad playback position")
+var ErrSyncedLyricsNotFound = errors.New("song has no synced lyrics")
+var ErrGetSyncedLyricsDB = errors.New("error while getting synced lyrics")
+var ErrSetSyncedLyricsDB = errors.New("error while saving synced lyrics")
+
+// SyncedLine is a lyrics line with the playback time it starts at.
+type SyncedLine struct {
+	TimeMs int
+	Text   string
+}
+
+// LyricsAt is the line playing at a position and the lines after it.
+// Current is nil before the first line starts.
+type LyricsAt struct {
+	Current *SyncedLine
+	Next    []SyncedLine
+}
+
+// Couplet carries the time range of the couplet when the song has
+// synced lyrics, EndMs is the start of the line after the couplet.
+type Couplet struct {
+	Text    string
+	Synced  bool
+	StartMs int
+	EndMs   int
+}
+
+type SyncedLineResponse struct {
+	TimeMs int    `json:"time_ms"`
+	Text   string `json:"text"`
+}
+
+type SyncedLyricsResponse struct {
+	Lines []SyncedLineResponse `json:"lines"`
+}
+
+type LyricsAtResponse struct {
+	Current *SyncedLineResponse  `json:"current"`
+	Next    []SyncedLineResponse `json:"next"`
+}
@@ -0,0 +1,25 @@
+package domain
+
+import (
+	"errors"
+	"time"
+)
+
+var ErrGetTrashDB = errors.New("error while getting deleted songs")
+var ErrRestoreTrashDB = errors.New("error while restoring deleted song")
+var ErrPurgeTrashDB = errors.New("error while purging deleted songs")
+var ErrBadRetention = errors.New("bad trash retention period")
+
+type TrashedSong struct {
+	Song      Song
+	DeletedAt time.Time
+}
+
+type TrashedSongResponse struct {
+	CreateSongResponse
+	DeletedAt string `json:"deleted_at"`
+}
+
+type GetTrashResponse struct {
+	Songs []TrashedSongResponse `json:"songs"`
+}
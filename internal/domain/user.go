@@ -0,0 +1,128 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+var ErrBadUsername = errors.New("bad username, expected 3 to 32 letters, digits, '.', '_' or '-'")
+var ErrBadPassword = errors.New("bad password, expected 8 to 72 bytes")
+var ErrUserExists = errors.New("user already exists")
+var ErrUserNotFound = errors.New("user not found")
+var ErrInvalidCredentials = errors.New("invalid username or password")
+var ErrInvalidToken = errors.New("invalid or expired token")
+var ErrUnauthorized = errors.New("authentication required")
+var ErrAddUserDB = errors.New("error while adding user")
+var ErrGetUserDB = errors.New("error while getting user")
+var ErrRefreshTokenDB = errors.New("error while storing refresh token")
+var ErrGetAllUsersDB = errors.New("error while getting users")
+var ErrSetRoleDB = errors.New("error while setting role")
+var ErrOwnRole = errors.New("admins can not change their own role")
+var ErrBadPasswordHash = errors.New("bad password hash, expected a bcrypt hash")
+var ErrSeedAdminDB = errors.New("error while seeding admin")
+
+type User struct {
+	ID           int64
+	Username     string
+	PasswordHash string
+	Role         Role
+	CreatedAt    time.Time
+}
+
+// Identity is the authenticated caller, transports put it into the
+// request context. APIKeyID is set when the caller came with an api key.
+type Identity struct {
+	UserID    int64
+	Username  string
+	Role      Role
+	APIKeyID  int64
+	Scopes    []Scope
+	RateLimit int
+}
+
+func (i Identity) HasScope(scope Scope) bool {
+	for _, s := range i.Scopes {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
+
+type identityKey struct{}
+
+func WithIdentity(ctx context.Context, identity Identity) context.Context {
+	return context.WithValue(ctx, identityKey{}, identity)
+}
+
+func IdentityFrom(ctx context.Context) (Identity, bool) {
+	identity, ok := ctx.Value(identityKey{}).(Identity)
+	return identity, ok
+}
+
+type TokenPair struct {
+	AccessToken      string
+	AccessExpiresAt  time.Time
+	RefreshToken     string
+	RefreshExpiresAt time.Time
+}
+
+type CredentialsRequest struct {
+	Username string `json:"username"`
+	Password string `json:"password"`
+}
+
+type RefreshRequest struct {
+	RefreshToken string `json:"refresh_token"`
+}
+
+type TokenResponse struct {
+	AccessToken  string `json:"access_token"`
+	TokenType    string `json:"token_type"`
+	ExpiresIn    int    `json:"expires_in"`
+	RefreshToken string `json:"refresh_token"`
+}
+
+type UserResponse struct {
+	ID        int64  `json:"id"`
+	Username  string `json:"username"`
+	Role      string `json:"role"`
+	CreatedAt string `json:"created_at,omitempty"`
+}
+
+type GetUsersResponse struct {
+	Users []UserResponse `json:"users"`
+}
+
+type SetRoleRequest struct {
+	Role string `json:"role"`
+}
+
+type UserUsecase interface {
+	Register(ctx context.Context, username string, password string) (User, error)
+	Login(ctx context.Context, username string, password string) (TokenPair, error)
+	Refresh(ctx context.Context, refreshToken string) (TokenPair, error)
+	Logout(ctx context.Context, refreshToken string) error
+	Authenticate(ctx context.Context, accessToken string) (Identity, error)
+	Get(ctx context.Context, id int64) (User, error)
+	GetAll(ctx context.Context, limit int, offset int) ([]User, error)
+	SetRole(ctx context.Context, id int64, role Role) (User, error)
+	SeedAdmin(ctx context.Context, username string, passwordHash string) (User, error)
+}
+
+type UserRepo interface {
+	Add(ctx context.Context, user *User) (User, error)
+	GetByUsername(ctx context.Context, username string) (User, error)
+	GetByID(ctx context.Context, id int64) (User, error)
+	GetAll(ctx context.Context, limit int, offset int) ([]User, error)
+	SetRole(ctx context.Context, id int64, role Role) (User, error)
+	// SeedAdmin creates the admin or makes an existing user with its name
+	// the admin with its password, ending the sessions of the old password.
+	SeedAdmin(ctx context.Context, user *User) (User, error)
+	AddRefreshToken(ctx context.Context, userID int64, tokenHash string, expiresAt time.Time) error
+	// UseRefreshToken revokes a valid refresh token and returns its user,
+	// so that every refresh token works once.
+	UseRefreshToken(ctx context.Context, tokenHash string) (int64, error)
+	RevokeRefreshToken(ctx context.Context, tokenHash string) error
+}
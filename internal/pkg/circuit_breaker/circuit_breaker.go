@@ -0,0 +1,87 @@
+package circuit_breaker
+
+import (
+	"errors"
+	"sync"
+	"time"
+)
+
+var ErrOpen = errors.New("circuit breaker is open")
+
+type state int
+
+const (
+	closed state = iota
+	open
+	halfOpen
+)
+
+// CircuitBreaker stops calls to a failing dependency after threshold
+// consecutive failures and lets a single probe call through once
+// cooldown has passed.
+type CircuitBreaker struct {
+	mu        sync.Mutex
+	state     state
+	failures  int
+	threshold int
+	cooldown  time.Duration
+	openedAt  time.Time
+}
+
+func NewCircuitBreaker(threshold int, cooldown time.Duration) *CircuitBreaker {
+	if threshold < 1 {
+		threshold = 1
+	}
+	return &CircuitBreaker{
+		threshold: threshold,
+		cooldown:  cooldown,
+	}
+}
+
+func (c *CircuitBreaker) allow() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	switch c.state {
+	case open:
+		if time.Since(c.openedAt) < c.cooldown {
+			return ErrOpen
+		}
+		c.state = halfOpen
+		return nil
+	case halfOpen:
+		return ErrOpen
+	default:
+		return nil
+	}
+}
+
+func (c *CircuitBreaker) done(success bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if success {
+		c.state = closed
+		c.failures = 0
+		return
+	}
+
+	c.failures += 1
+	if c.state == halfOpen || c.failures >= c.threshold {
+		c.state = open
+		c.openedAt = time.Now()
+	}
+}
+
+// Execute runs fn unless the breaker is open. Errors for which
+// isFailure returns false do not count against the breaker.
+func (c *CircuitBreaker) Execute(fn func() error, isFailure func(error) bool) error {
+	err := c.allow()
+	if err != nil {
+		return err
+	}
+
+	err = fn()
+	c.done(err == nil || !isFailure(err))
+	return err
+}
@@ -1,10 +1,44 @@
 package date_validate
 
 import (
+	"github.com/NastyaAR/music_library/internal/domain"
 	"regexp"
+	"strconv"
+	"strings"
+	"time"
 )
 
 func IsValidDate(date string) bool {
 	re := regexp.MustCompile("^(0[1-9]|[12][0-9]|3[01]).(0[1-9]|1[0-2]).(\\d{4})$")
 	return re.MatchString(date)
 }
+
+// FormatDate formats a date as dd.mm.yyyy.
+func FormatDate(timestamp time.Time) string {
+	date := strings.Split(timestamp.String(), " ")
+	parts := strings.Split(date[0], "-")
+
+	result := strings.Join([]string{parts[2], parts[1], parts[0]}, ".")
+	return result
+}
+
+// ParseDate parses a dd.mm.yyyy date given by a client.
+func ParseDate(date string) (time.Time, error) {
+	if !IsValidDate(date) {
+		return time.Time{}, domain.ErrBadReleaseDate
+	}
+
+	parts := strings.Split(date, ".")
+
+	numOfDate := make([]int, 3)
+	var err error
+	for i, part := range parts {
+		numOfDate[i], err = strconv.Atoi(part)
+		if err != nil {
+			return time.Time{}, domain.ErrBadReleaseDate
+		}
+	}
+
+	res := time.Date(numOfDate[2], time.Month(numOfDate[1]), numOfDate[0], 0, 0, 0, 0, time.UTC)
+	return res, nil
+}
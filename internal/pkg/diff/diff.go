@@ -0,0 +1,87 @@
+package diff
+
+import (
+	"github.com/NastyaAR/music_library/internal/domain"
+	"strings"
+)
+
+func splitLines(text string) []string {
+	text = strings.ReplaceAll(text, "\r\n", "\n")
+	if text == "" {
+		return nil
+	}
+	return strings.Split(strings.TrimSuffix(text, "\n"), "\n")
+}
+
+// MaxLines limits the changed lines of each text, the LCS table grows
+// with their product.
+const MaxLines = 2000
+
+// Lines returns a line-level diff turning from into to, built on the
+// longest common subsequence of lines. The common head and tail are
+// matched first, the lines between them must fit MaxLines.
+func Lines(from string, to string) ([]domain.DiffLine, error) {
+	a, b := splitLines(from), splitLines(to)
+
+	head := 0
+	for head < len(a) && head < len(b) && a[head] == b[head] {
+		head++
+	}
+	tail := 0
+	for tail < len(a)-head && tail < len(b)-head && a[len(a)-1-tail] == b[len(b)-1-tail] {
+		tail++
+	}
+
+	result := make([]domain.DiffLine, 0, max(len(a), len(b)))
+	for _, line := range a[:head] {
+		result = append(result, domain.DiffLine{Op: domain.DiffEqual, Text: line})
+	}
+	suffix := a[len(a)-tail:]
+	a, b = a[head:len(a)-tail], b[head:len(b)-tail]
+	if len(a) > MaxLines || len(b) > MaxLines {
+		return nil, domain.ErrDiffTooLarge
+	}
+
+	// lcs[i][j] is the length of the common subsequence of a[i:] and b[j:]
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		switch {
+		case a[i] == b[j]:
+			result = append(result, domain.DiffLine{Op: domain.DiffEqual, Text: a[i]})
+			i++
+			j++
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			result = append(result, domain.DiffLine{Op: domain.DiffDelete, Text: a[i]})
+			i++
+		default:
+			result = append(result, domain.DiffLine{Op: domain.DiffInsert, Text: b[j]})
+			j++
+		}
+	}
+
+	for ; i < len(a); i++ {
+		result = append(result, domain.DiffLine{Op: domain.DiffDelete, Text: a[i]})
+	}
+	for ; j < len(b); j++ {
+		result = append(result, domain.DiffLine{Op: domain.DiffInsert, Text: b[j]})
+	}
+	for _, line := range suffix {
+		result = append(result, domain.DiffLine{Op: domain.DiffEqual, Text: line})
+	}
+
+	return result, nil
+}
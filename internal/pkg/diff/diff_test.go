@@ -0,0 +1,64 @@
+package diff
+
+import (
+	"github.com/NastyaAR/music_library/internal/domain"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestLines(t *testing.T) {
+	eq := func(text string) domain.DiffLine { return domain.DiffLine{Op: domain.DiffEqual, Text: text} }
+	del := func(text string) domain.DiffLine { return domain.DiffLine{Op: domain.DiffDelete, Text: text} }
+	ins := func(text string) domain.DiffLine { return domain.DiffLine{Op: domain.DiffInsert, Text: text} }
+
+	tests := []struct {
+		name string
+		from string
+		to   string
+		want []domain.DiffLine
+	}{
+		{"empty", "", "", []domain.DiffLine{}},
+		{"insert all", "", "a\nb", []domain.DiffLine{ins("a"), ins("b")}},
+		{"delete all", "a\nb\n", "", []domain.DiffLine{del("a"), del("b")}},
+		{"same", "a\nb", "a\r\nb\r\n", []domain.DiffLine{eq("a"), eq("b")}},
+		{"middle", "a\nb\nc", "a\nx\nc", []domain.DiffLine{eq("a"), del("b"), ins("x"), eq("c")}},
+		{"moved", "a\nb\nc", "b\nc\na", []domain.DiffLine{del("a"), eq("b"), eq("c"), ins("a")}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Lines(tt.from, tt.to)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Lines = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLinesLimit(t *testing.T) {
+	large := func(prefix string, n int) string {
+		var sb strings.Builder
+		for i := 0; i < n; i++ {
+			sb.WriteString(prefix + strings.Repeat("x", i%7) + "\n")
+		}
+		return sb.String()
+	}
+
+	_, err := Lines(large("a", MaxLines+1), large("b", MaxLines+1))
+	if err != domain.ErrDiffTooLarge {
+		t.Errorf("err = %v, want %v", err, domain.ErrDiffTooLarge)
+	}
+
+	text := large("a", 3*MaxLines)
+	lines, err := Lines(text, "new\n"+text)
+	if err != nil {
+		t.Fatalf("a small edit of a large text: %v", err)
+	}
+	if len(lines) != 3*MaxLines+1 || lines[0].Op != domain.DiffInsert {
+		t.Errorf("got %d lines starting with %v", len(lines), lines[0])
+	}
+}
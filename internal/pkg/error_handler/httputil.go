@@ -8,8 +8,56 @@ import (
 )
 
 type HTTPError struct {
-	Code    int    `json:"code" example:"400"`
-	Message string `json:"message" example:"status bad request"`
+	Code      int    `json:"code" example:"400"`
+	ErrorCode string `json:"error_code" example:"bad_request"`
+	Message   string `json:"message" example:"status bad request"`
+}
+
+const (
+	CodeBadRequest = "bad_request"
+	CodeConflict   = "conflict"
+	CodeInternal   = "internal_error"
+)
+
+type errorCode struct {
+	err    error
+	status int
+	code   string
+}
+
+// errorCodes gives errors a status and a stable code clients can rely on
+// instead of the message.
+var errorCodes = []errorCode{
+	{domain.ErrSongNotFound, http.StatusNotFound, "song_not_found"},
+	{domain.ErrRevisionNotFound, http.StatusNotFound, "revision_not_found"},
+	{domain.ErrSectionNotFound, http.StatusNotFound, "section_not_found"},
+	{domain.ErrSyncedLyricsNotFound, http.StatusNotFound, "synced_lyrics_not_found"},
+	{domain.ErrSongExists, http.StatusConflict, "song_exists"},
+	{domain.ErrTrackTaken, http.StatusConflict, "track_taken"},
+	{domain.ErrConflict, http.StatusConflict, CodeConflict},
+	{domain.ErrPreconditionFailed, http.StatusPreconditionFailed, "precondition_failed"},
+	{domain.ErrPatchTestFailed, http.StatusConflict, "patch_test_failed"},
+	{domain.ErrUnsupportedPatchType, http.StatusUnsupportedMediaType, "unsupported_media_type"},
+	{domain.ErrUnsupportedImportType, http.StatusUnsupportedMediaType, "unsupported_media_type"},
+	{domain.ErrImportJobNotFound, http.StatusNotFound, "import_job_not_found"},
+	{domain.ErrImportTooLarge, http.StatusRequestEntityTooLarge, "import_too_large"},
+	{domain.ErrUserNotFound, http.StatusNotFound, "user_not_found"},
+	{domain.ErrUserExists, http.StatusConflict, "user_exists"},
+	{domain.ErrInvalidCredentials, http.StatusUnauthorized, "invalid_credentials"},
+	{domain.ErrInvalidToken, http.StatusUnauthorized, "invalid_token"},
+	{domain.ErrUnauthorized, http.StatusUnauthorized, "unauthorized"},
+	{domain.ErrForbidden, http.StatusForbidden, "forbidden"},
+	{domain.ErrInvalidAPIKey, http.StatusUnauthorized, "invalid_api_key"},
+	{domain.ErrAPIKeyNotFound, http.StatusNotFound, "api_key_not_found"},
+	{domain.ErrRateLimited, http.StatusTooManyRequests, "rate_limited"},
+	{domain.ErrPlaylistNotFound, http.StatusNotFound, "playlist_not_found"},
+	{domain.ErrPlaylistItemNotFound, http.StatusNotFound, "playlist_item_not_found"},
+	{domain.ErrOwnRole, http.StatusForbidden, "own_role"},
+	{domain.ErrArtistNotFound, http.StatusNotFound, "artist_not_found"},
+	{domain.ErrArtistExists, http.StatusConflict, "artist_exists"},
+	{domain.ErrArtistInUse, http.StatusConflict, "artist_in_use"},
+	{domain.ErrAlbumNotFound, http.StatusNotFound, "album_not_found"},
+	{domain.ErrUnknownAlbumArtist, http.StatusConflict, "unknown_album_artist"},
 }
 
 func isBadRequest(err error) bool {
@@ -20,6 +68,39 @@ func isBadRequest(err error) bool {
 		domain.ErrBadName,
 		domain.ErrBadReleaseDate,
 		domain.ErrQueryParams,
+		domain.ErrBadID,
+		domain.ErrNilArtistRequest,
+		domain.ErrBadArtistName,
+		domain.ErrBadFormedYear,
+		domain.ErrNilAlbumRequest,
+		domain.ErrBadAlbumTitle,
+		domain.ErrBadArtistID,
+		domain.ErrBadTrackNumber,
+		domain.ErrBadAlbumID,
+		domain.ErrBadSearchQuery,
+		domain.ErrBadSearchLanguage,
+		domain.ErrBadMatchMode,
+		domain.ErrBadSort,
+		domain.ErrBadReleaseRange,
+		domain.ErrBadCursor,
+		domain.ErrBadRequestBody,
+		domain.ErrBadSectionType,
+		domain.ErrBadLRC,
+		domain.ErrBadPosition,
+		domain.ErrBadRevision,
+		domain.ErrDiffTooLarge,
+		domain.ErrBadPatch,
+		domain.ErrBadImportMode,
+		domain.ErrBadExportFormat,
+		domain.ErrTooManyIDs,
+		domain.ErrBadUsername,
+		domain.ErrBadPassword,
+		domain.ErrBadRole,
+		domain.ErrBadKeyName,
+		domain.ErrBadScope,
+		domain.ErrBadRateLimit,
+		domain.ErrBadPlaylistName,
+		domain.ErrBadPlaylistPosition,
 	}
 
 	for _, e := range errorsList {
@@ -31,17 +112,29 @@ func isBadRequest(err error) bool {
 	return false
 }
 
-func NewError(ctx *gin.Context, err error) {
-	var status int
+// Classify gives the HTTP status and error code of an error, other
+// transports map their statuses from it.
+func Classify(err error) (int, string) {
 	if isBadRequest(err) {
-		status = http.StatusBadRequest
-	} else {
-		status = http.StatusInternalServerError
+		return http.StatusBadRequest, CodeBadRequest
 	}
 
+	for _, e := range errorCodes {
+		if errors.Is(err, e.err) {
+			return e.status, e.code
+		}
+	}
+
+	return http.StatusInternalServerError, CodeInternal
+}
+
+func NewError(ctx *gin.Context, err error) {
+	status, code := Classify(err)
+
 	er := HTTPError{
-		Code:    status,
-		Message: err.Error(),
+		Code:      status,
+		ErrorCode: code,
+		Message:   err.Error(),
 	}
 
 	ctx.JSON(status, er)
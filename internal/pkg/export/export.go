@@ -0,0 +1,176 @@
+package export
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"github.com/NastyaAR/music_library/internal/domain"
+	"io"
+	"strconv"
+)
+
+type Format string
+
+const (
+	JSON   Format = "json"
+	NDJSON Format = "ndjson"
+	CSV    Format = "csv"
+	XLSX   Format = "xlsx"
+)
+
+func (f Format) IsValid() bool {
+	switch f {
+	case JSON, NDJSON, CSV, XLSX:
+		return true
+	}
+	return false
+}
+
+func (f Format) ContentType() string {
+	switch f {
+	case NDJSON:
+		return "application/x-ndjson"
+	case CSV:
+		return "text/csv; charset=utf-8"
+	case XLSX:
+		return "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+	default:
+		return "application/json"
+	}
+}
+
+// Compressed tells whether the format is already compressed and gains
+// nothing from gzip.
+func (f Format) Compressed() bool {
+	return f == XLSX
+}
+
+// Columns are the CSV and XLSX columns, named like the JSON fields.
+var Columns = []string{"id", "artist_id", "group", "name", "release_date",
+	"text", "link", "album_id", "track_number", "version"}
+
+// Writer encodes songs one at a time, nothing is kept after a song is
+// written. Close finishes the document but does not close the underlying
+// writer.
+type Writer interface {
+	Write(song domain.CreateSongResponse) error
+	Close() error
+}
+
+func NewWriter(f Format, w io.Writer) Writer {
+	switch f {
+	case NDJSON:
+		return &ndjsonWriter{w: w}
+	case CSV:
+		return &csvWriter{w: csv.NewWriter(w)}
+	case XLSX:
+		return newXLSXWriter(w)
+	default:
+		return &jsonWriter{w: w}
+	}
+}
+
+func record(song *domain.CreateSongResponse) []string {
+	return []string{
+		strconv.FormatInt(song.ID, 10),
+		optional(song.ArtistID),
+		song.Group,
+		song.Name,
+		song.ReleaseDate,
+		song.Text,
+		song.Link,
+		optional(song.AlbumID),
+		optional(int64(song.TrackNumber)),
+		strconv.Itoa(song.Version),
+	}
+}
+
+func optional(value int64) string {
+	if value == 0 {
+		return ""
+	}
+	return strconv.FormatInt(value, 10)
+}
+
+// jsonWriter writes a JSON array.
+type jsonWriter struct {
+	w     io.Writer
+	count int
+}
+
+func (j *jsonWriter) Write(song domain.CreateSongResponse) error {
+	data, err := json.Marshal(song)
+	if err != nil {
+		return err
+	}
+
+	sep := ",\n"
+	if j.count == 0 {
+		sep = "[\n"
+	}
+	j.count++
+
+	_, err = io.WriteString(j.w, sep)
+	if err != nil {
+		return err
+	}
+	_, err = j.w.Write(data)
+	return err
+}
+
+func (j *jsonWriter) Close() error {
+	end := "\n]\n"
+	if j.count == 0 {
+		end = "[]\n"
+	}
+	_, err := io.WriteString(j.w, end)
+	return err
+}
+
+type ndjsonWriter struct {
+	w io.Writer
+}
+
+func (n *ndjsonWriter) Write(song domain.CreateSongResponse) error {
+	data, err := json.Marshal(song)
+	if err != nil {
+		return err
+	}
+
+	_, err = n.w.Write(append(data, '\n'))
+	return err
+}
+
+func (n *ndjsonWriter) Close() error {
+	return nil
+}
+
+// csvWriter writes the header together with the first row or on Close.
+type csvWriter struct {
+	w      *csv.Writer
+	header bool
+}
+
+func (c *csvWriter) writeHeader() error {
+	if c.header {
+		return nil
+	}
+	c.header = true
+	return c.w.Write(Columns)
+}
+
+func (c *csvWriter) Write(song domain.CreateSongResponse) error {
+	err := c.writeHeader()
+	if err != nil {
+		return err
+	}
+	return c.w.Write(record(&song))
+}
+
+func (c *csvWriter) Close() error {
+	err := c.writeHeader()
+	if err != nil {
+		return err
+	}
+	c.w.Flush()
+	return c.w.Error()
+}
@@ -0,0 +1,275 @@
+package jsonpatch
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+var ErrBadOperation = errors.New("bad json patch operation")
+var ErrBadPath = errors.New("bad json patch path")
+var ErrTestFailed = errors.New("json patch test failed")
+
+// Operation is one RFC 6902 operation.
+type Operation struct {
+	Op    string          `json:"op"`
+	Path  string          `json:"path"`
+	From  string          `json:"from,omitempty"`
+	Value json.RawMessage `json:"value,omitempty"`
+}
+
+func Decode(body []byte) ([]Operation, error) {
+	var ops []Operation
+	err := json.Unmarshal(body, &ops)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrBadOperation, err)
+	}
+
+	return ops, nil
+}
+
+// Apply applies the operations in order to a document decoded with
+// encoding/json. The document may be modified in place.
+func Apply(doc interface{}, ops []Operation) (interface{}, error) {
+	var err error
+	for i, op := range ops {
+		doc, err = apply(doc, op)
+		if err != nil {
+			return nil, fmt.Errorf("operation %d: %w", i, err)
+		}
+	}
+
+	return doc, nil
+}
+
+func apply(doc interface{}, op Operation) (interface{}, error) {
+	switch op.Op {
+	case "add", "replace", "test":
+		if op.Value == nil {
+			return nil, fmt.Errorf("%w: %s without value", ErrBadOperation, op.Op)
+		}
+		var value interface{}
+		err := json.Unmarshal(op.Value, &value)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrBadOperation, err)
+		}
+
+		switch op.Op {
+		case "add":
+			return add(doc, op.Path, value)
+		case "replace":
+			if op.Path == "" {
+				return value, nil
+			}
+			doc, _, err = remove(doc, op.Path)
+			if err != nil {
+				return nil, err
+			}
+			return add(doc, op.Path, value)
+		default:
+			current, err := get(doc, op.Path)
+			if err != nil {
+				return nil, err
+			}
+			if !reflect.DeepEqual(current, value) {
+				return nil, fmt.Errorf("%w: %s", ErrTestFailed, op.Path)
+			}
+			return doc, nil
+		}
+	case "remove":
+		doc, _, err := remove(doc, op.Path)
+		return doc, err
+	case "move":
+		if strings.HasPrefix(op.Path, op.From+"/") {
+			return nil, fmt.Errorf("%w: move into own child", ErrBadPath)
+		}
+		doc, value, err := remove(doc, op.From)
+		if err != nil {
+			return nil, err
+		}
+		return add(doc, op.Path, value)
+	case "copy":
+		value, err := get(doc, op.From)
+		if err != nil {
+			return nil, err
+		}
+		return add(doc, op.Path, deepCopy(value))
+	}
+
+	return nil, fmt.Errorf("%w: unknown op %q", ErrBadOperation, op.Op)
+}
+
+// split parses an RFC 6901 pointer into unescaped reference tokens.
+func split(path string) ([]string, error) {
+	if path == "" {
+		return nil, nil
+	}
+	if !strings.HasPrefix(path, "/") {
+		return nil, fmt.Errorf("%w: %q", ErrBadPath, path)
+	}
+
+	tokens := strings.Split(path[1:], "/")
+	for i, t := range tokens {
+		tokens[i] = strings.ReplaceAll(strings.ReplaceAll(t, "~1", "/"), "~0", "~")
+	}
+	return tokens, nil
+}
+
+func index(token string, length int, allowEnd bool) (int, error) {
+	if token == "-" && allowEnd {
+		return length, nil
+	}
+
+	i, err := strconv.Atoi(token)
+	if err != nil || i < 0 || i > length || (i == length && !allowEnd) ||
+		(len(token) > 1 && token[0] == '0') {
+		return 0, fmt.Errorf("%w: bad index %q", ErrBadPath, token)
+	}
+	return i, nil
+}
+
+func get(doc interface{}, path string) (interface{}, error) {
+	tokens, err := split(path)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, t := range tokens {
+		switch node := doc.(type) {
+		case map[string]interface{}:
+			value, ok := node[t]
+			if !ok {
+				return nil, fmt.Errorf("%w: %q not found", ErrBadPath, path)
+			}
+			doc = value
+		case []interface{}:
+			i, err := index(t, len(node), false)
+			if err != nil {
+				return nil, err
+			}
+			doc = node[i]
+		default:
+			return nil, fmt.Errorf("%w: %q not found", ErrBadPath, path)
+		}
+	}
+
+	return doc, nil
+}
+
+// update replaces the container at the parent of path with the result of
+// change, rebuilding the containers on the way back up.
+func update(doc interface{}, tokens []string, change func(parent interface{}, last string) (interface{}, error)) (interface{}, error) {
+	if len(tokens) == 1 {
+		return change(doc, tokens[0])
+	}
+
+	switch node := doc.(type) {
+	case map[string]interface{}:
+		child, ok := node[tokens[0]]
+		if !ok {
+			return nil, fmt.Errorf("%w: %q not found", ErrBadPath, tokens[0])
+		}
+		child, err := update(child, tokens[1:], change)
+		if err != nil {
+			return nil, err
+		}
+		node[tokens[0]] = child
+		return node, nil
+	case []interface{}:
+		i, err := index(tokens[0], len(node), false)
+		if err != nil {
+			return nil, err
+		}
+		child, err := update(node[i], tokens[1:], change)
+		if err != nil {
+			return nil, err
+		}
+		node[i] = child
+		return node, nil
+	}
+
+	return nil, fmt.Errorf("%w: %q is not a container", ErrBadPath, tokens[0])
+}
+
+func add(doc interface{}, path string, value interface{}) (interface{}, error) {
+	tokens, err := split(path)
+	if err != nil {
+		return nil, err
+	}
+	if len(tokens) == 0 {
+		return value, nil
+	}
+
+	return update(doc, tokens, func(parent interface{}, last string) (interface{}, error) {
+		switch node := parent.(type) {
+		case map[string]interface{}:
+			node[last] = value
+			return node, nil
+		case []interface{}:
+			i, err := index(last, len(node), true)
+			if err != nil {
+				return nil, err
+			}
+			node = append(node, nil)
+			copy(node[i+1:], node[i:])
+			node[i] = value
+			return node, nil
+		}
+		return nil, fmt.Errorf("%w: %q is not a container", ErrBadPath, path)
+	})
+}
+
+func remove(doc interface{}, path string) (interface{}, interface{}, error) {
+	tokens, err := split(path)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(tokens) == 0 {
+		return nil, nil, fmt.Errorf("%w: cannot remove the whole document", ErrBadPath)
+	}
+
+	var removed interface{}
+	doc, err = update(doc, tokens, func(parent interface{}, last string) (interface{}, error) {
+		switch node := parent.(type) {
+		case map[string]interface{}:
+			value, ok := node[last]
+			if !ok {
+				return nil, fmt.Errorf("%w: %q not found", ErrBadPath, path)
+			}
+			removed = value
+			delete(node, last)
+			return node, nil
+		case []interface{}:
+			i, err := index(last, len(node), false)
+			if err != nil {
+				return nil, err
+			}
+			removed = node[i]
+			return append(node[:i:i], node[i+1:]...), nil
+		}
+		return nil, fmt.Errorf("%w: %q is not a container", ErrBadPath, path)
+	})
+
+	return doc, removed, err
+}
+
+func deepCopy(value interface{}) interface{} {
+	switch node := value.(type) {
+	case map[string]interface{}:
+		copied := make(map[string]interface{}, len(node))
+		for k, v := range node {
+			copied[k] = deepCopy(v)
+		}
+		return copied
+	case []interface{}:
+		copied := make([]interface{}, len(node))
+		for i, v := range node {
+			copied[i] = deepCopy(v)
+		}
+		return copied
+	}
+	return value
+}
@@ -0,0 +1,134 @@
+package jsonpatch
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func decodeJSON(t *testing.T, text string) interface{} {
+	t.Helper()
+	var value interface{}
+	err := json.Unmarshal([]byte(text), &value)
+	if err != nil {
+		t.Fatalf("decode %s: %v", text, err)
+	}
+	return value
+}
+
+func TestApply(t *testing.T) {
+	tests := []struct {
+		name string
+		doc  string
+		ops  string
+		want string
+	}{
+		{"add field", `{"a":1}`, `[{"op":"add","path":"/b","value":2}]`, `{"a":1,"b":2}`},
+		{"add replaces field", `{"a":1}`, `[{"op":"add","path":"/a","value":[1]}]`, `{"a":[1]}`},
+		{"add nested", `{"a":{"b":1}}`, `[{"op":"add","path":"/a/c","value":"x"}]`, `{"a":{"b":1,"c":"x"}}`},
+		{"add whole document", `{"a":1}`, `[{"op":"add","path":"","value":{"b":2}}]`, `{"b":2}`},
+		{"add into array", `{"a":[1,3]}`, `[{"op":"add","path":"/a/1","value":2}]`, `{"a":[1,2,3]}`},
+		{"add to array end", `{"a":[1]}`, `[{"op":"add","path":"/a/-","value":2}]`, `{"a":[1,2]}`},
+		{"add at array length", `{"a":[1]}`, `[{"op":"add","path":"/a/1","value":2}]`, `{"a":[1,2]}`},
+		{"remove field", `{"a":1,"b":2}`, `[{"op":"remove","path":"/a"}]`, `{"b":2}`},
+		{"remove from array", `[1,2,3]`, `[{"op":"remove","path":"/1"}]`, `[1,3]`},
+		{"replace field", `{"a":1}`, `[{"op":"replace","path":"/a","value":"x"}]`, `{"a":"x"}`},
+		{"replace in array", `[1,2]`, `[{"op":"replace","path":"/0","value":0}]`, `[0,2]`},
+		{"replace document", `{"a":1}`, `[{"op":"replace","path":"","value":[1]}]`, `[1]`},
+		{"move field", `{"a":1,"b":{}}`, `[{"op":"move","from":"/a","path":"/b/c"}]`, `{"b":{"c":1}}`},
+		{"move in array", `[1,2,3]`, `[{"op":"move","from":"/0","path":"/-"}]`, `[2,3,1]`},
+		{"copy field", `{"a":{"b":1}}`, `[{"op":"copy","from":"/a","path":"/c"}]`, `{"a":{"b":1},"c":{"b":1}}`},
+		{"test passes", `{"a":[1,{"b":"x"}]}`, `[{"op":"test","path":"/a","value":[1,{"b":"x"}]}]`, `{"a":[1,{"b":"x"}]}`},
+		{"escaped slash", `{"a/b":1}`, `[{"op":"replace","path":"/a~1b","value":2}]`, `{"a/b":2}`},
+		{"escaped tilde", `{"a~b":1}`, `[{"op":"remove","path":"/a~0b"}]`, `{}`},
+		{"escape order", `{}`, `[{"op":"add","path":"/~01","value":1}]`, `{"~1":1}`},
+		{"empty key", `{}`, `[{"op":"add","path":"/","value":1}]`, `{"":1}`},
+		{"test null field", `{"a":null}`, `[{"op":"test","path":"/a","value":null}]`, `{"a":null}`},
+		{"replace null field", `{"a":null}`, `[{"op":"replace","path":"/a","value":"x"}]`, `{"a":"x"}`},
+		{"replace with null", `{"a":"x"}`, `[{"op":"replace","path":"/a","value":null}]`, `{"a":null}`},
+		{"add null", `{}`, `[{"op":"add","path":"/a","value":null}]`, `{"a":null}`},
+		{"in order", `{"a":1}`, `[{"op":"copy","from":"/a","path":"/b"},{"op":"remove","path":"/a"}]`, `{"b":1}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ops, err := Decode([]byte(tt.ops))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			got, err := Apply(decodeJSON(t, tt.doc), ops)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if want := decodeJSON(t, tt.want); !reflect.DeepEqual(got, want) {
+				t.Errorf("Apply = %v, want %v", got, want)
+			}
+		})
+	}
+}
+
+func TestApplyCopyIsDeep(t *testing.T) {
+	ops, err := Decode([]byte(`[{"op":"copy","from":"/a","path":"/b"},
+		{"op":"add","path":"/b/c","value":2}]`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := Apply(decodeJSON(t, `{"a":{"c":1}}`), ops)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := decodeJSON(t, `{"a":{"c":1},"b":{"c":2}}`); !reflect.DeepEqual(got, want) {
+		t.Errorf("Apply = %v, want %v", got, want)
+	}
+}
+
+func TestApplyErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		doc  string
+		ops  string
+		want error
+	}{
+		{"unknown op", `{}`, `[{"op":"merge","path":"/a"}]`, ErrBadOperation},
+		{"add without value", `{}`, `[{"op":"add","path":"/a"}]`, ErrBadOperation},
+		{"relative path", `{}`, `[{"op":"add","path":"a","value":1}]`, ErrBadPath},
+		{"missing parent", `{}`, `[{"op":"add","path":"/a/b","value":1}]`, ErrBadPath},
+		{"remove missing", `{}`, `[{"op":"remove","path":"/a"}]`, ErrBadPath},
+		{"remove document", `{}`, `[{"op":"remove","path":""}]`, ErrBadPath},
+		{"replace missing", `{}`, `[{"op":"replace","path":"/a","value":1}]`, ErrBadPath},
+		{"index past end", `[1]`, `[{"op":"add","path":"/2","value":1}]`, ErrBadPath},
+		{"remove array end", `[1]`, `[{"op":"remove","path":"/-"}]`, ErrBadPath},
+		{"leading zero", `[1,2]`, `[{"op":"remove","path":"/01"}]`, ErrBadPath},
+		{"negative index", `[1]`, `[{"op":"replace","path":"/-1","value":1}]`, ErrBadPath},
+		{"not a container", `{"a":1}`, `[{"op":"add","path":"/a/b","value":1}]`, ErrBadPath},
+		{"move into child", `{"a":{}}`, `[{"op":"move","from":"/a","path":"/a/b"}]`, ErrBadPath},
+		{"copy missing", `{}`, `[{"op":"copy","from":"/a","path":"/b"}]`, ErrBadPath},
+		{"test differs", `{"a":1}`, `[{"op":"test","path":"/a","value":"1"}]`, ErrTestFailed},
+		{"test null", `{"a":""}`, `[{"op":"test","path":"/a","value":null}]`, ErrTestFailed},
+		{"test missing", `{}`, `[{"op":"test","path":"/a","value":null}]`, ErrBadPath},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ops, err := Decode([]byte(tt.ops))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			_, err = Apply(decodeJSON(t, tt.doc), ops)
+			if !errors.Is(err, tt.want) {
+				t.Errorf("Apply error = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestDecode(t *testing.T) {
+	_, err := Decode([]byte(`{"op":"add"}`))
+	if !errors.Is(err, ErrBadOperation) {
+		t.Errorf("Decode of an object error = %v, want %v", err, ErrBadOperation)
+	}
+}
@@ -0,0 +1,193 @@
+package lrc
+
+import (
+	"fmt"
+	"github.com/NastyaAR/music_library/internal/domain"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// timeTag matches [mm:ss], [mm:ss.xx], [mm:ss.xxx] and [mm:ss:xx].
+var timeTag = regexp.MustCompile(`^\[(\d{1,3}):(\d{1,2})(?:[.:](\d{1,3}))?\]`)
+
+var metaTag = regexp.MustCompile(`^\[([a-zA-Z#]+):(.*)\]$`)
+
+type Meta struct {
+	Artist string
+	Title  string
+}
+
+func parseTime(m []string) int {
+	minutes, _ := strconv.Atoi(m[1])
+	seconds, _ := strconv.Atoi(m[2])
+
+	ms := 0
+	if m[3] != "" {
+		frac, _ := strconv.Atoi(m[3])
+		switch len(m[3]) {
+		case 1:
+			ms = frac * 100
+		case 2:
+			ms = frac * 10
+		default:
+			ms = frac
+		}
+	}
+
+	return (minutes*60+seconds)*1000 + ms
+}
+
+// Parse reads LRC lyrics. A line may carry several time tags, the
+// [offset:] tag shifts every timestamp and other metadata tags are
+// skipped. Lines are returned sorted by time.
+func Parse(text string) ([]domain.SyncedLine, error) {
+	lines := make([]domain.SyncedLine, 0)
+	offset := 0
+
+	for n, raw := range strings.Split(strings.ReplaceAll(text, "\r\n", "\n"), "\n") {
+		raw = strings.TrimSpace(raw)
+		if raw == "" {
+			continue
+		}
+
+		times := make([]int, 0, 1)
+		rest := raw
+		for {
+			m := timeTag.FindStringSubmatch(rest)
+			if m == nil {
+				break
+			}
+			times = append(times, parseTime(m))
+			rest = rest[len(m[0]):]
+		}
+
+		if len(times) == 0 {
+			m := metaTag.FindStringSubmatch(raw)
+			if m == nil {
+				return nil, fmt.Errorf("%w: line %d", domain.ErrBadLRC, n+1)
+			}
+			if strings.ToLower(m[1]) == "offset" {
+				value, err := strconv.Atoi(strings.TrimSpace(m[2]))
+				if err != nil {
+					return nil, fmt.Errorf("%w: bad offset on line %d", domain.ErrBadLRC, n+1)
+				}
+				offset = value
+			}
+			continue
+		}
+
+		for _, t := range times {
+			lines = append(lines, domain.SyncedLine{TimeMs: t, Text: strings.TrimSpace(rest)})
+		}
+	}
+
+	if len(lines) == 0 {
+		return nil, fmt.Errorf("%w: no timed lines", domain.ErrBadLRC)
+	}
+
+	// a positive offset makes lyrics appear sooner
+	for i := range lines {
+		lines[i].TimeMs -= offset
+		if lines[i].TimeMs < 0 {
+			lines[i].TimeMs = 0
+		}
+	}
+
+	sort.SliceStable(lines, func(i, j int) bool {
+		return lines[i].TimeMs < lines[j].TimeMs
+	})
+
+	return lines, nil
+}
+
+func formatTime(ms int) string {
+	return fmt.Sprintf("[%02d:%02d.%02d]", ms/60000, ms/1000%60, ms%1000/10)
+}
+
+func Format(lines []domain.SyncedLine, meta Meta) string {
+	var b strings.Builder
+
+	if meta.Artist != "" {
+		fmt.Fprintf(&b, "[ar:%s]\n", meta.Artist)
+	}
+	if meta.Title != "" {
+		fmt.Fprintf(&b, "[ti:%s]\n", meta.Title)
+	}
+
+	for _, line := range lines {
+		b.WriteString(formatTime(line.TimeMs))
+		b.WriteString(line.Text)
+		b.WriteString("\n")
+	}
+
+	return b.String()
+}
+
+// At returns the line playing at ms and up to next following lines.
+func At(lines []domain.SyncedLine, ms int, next int) domain.LyricsAt {
+	i := sort.Search(len(lines), func(i int) bool {
+		return lines[i].TimeMs > ms
+	})
+
+	result := domain.LyricsAt{Next: make([]domain.SyncedLine, 0, next)}
+	if i > 0 {
+		current := lines[i-1]
+		result.Current = &current
+	}
+
+	for j := i; j < len(lines) && j < i+next; j++ {
+		result.Next = append(result.Next, lines[j])
+	}
+
+	return result
+}
+
+func normalize(line string) string {
+	return strings.Join(strings.Fields(strings.ToLower(line)), " ")
+}
+
+// SectionRange finds the time range of the section with the given order
+// by matching section lines against synced lines one after another.
+// The end is the start of the first line after the section, or the start
+// of its last line when the section closes the song.
+func SectionRange(sections []domain.LyricsSection, lines []domain.SyncedLine, order int) (int, int, bool) {
+	j := 0
+	first, last := -1, -1
+
+	for _, section := range sections {
+		for _, text := range section.Lines {
+			want := normalize(text)
+			for k := j; k < len(lines); k++ {
+				if normalize(lines[k].Text) != want {
+					continue
+				}
+
+				if section.Order == order {
+					if first < 0 {
+						first = k
+					}
+					last = k
+				}
+				j = k + 1
+				break
+			}
+		}
+
+		if section.Order == order {
+			break
+		}
+	}
+
+	if first < 0 {
+		return 0, 0, false
+	}
+
+	end := lines[last].TimeMs
+	if last+1 < len(lines) {
+		end = lines[last+1].TimeMs
+	}
+
+	return lines[first].TimeMs, end, true
+}
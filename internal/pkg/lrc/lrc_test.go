@@ -0,0 +1,176 @@
+package lrc
+
+import (
+	"errors"
+	"github.com/NastyaAR/music_library/internal/domain"
+	"reflect"
+	"testing"
+)
+
+func line(ms int, text string) domain.SyncedLine {
+	return domain.SyncedLine{TimeMs: ms, Text: text}
+}
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want []domain.SyncedLine
+	}{
+		{
+			name: "plain",
+			text: "[00:01.00]one\n[00:02.50] two ",
+			want: []domain.SyncedLine{line(1000, "one"), line(2500, "two")},
+		},
+		{
+			name: "fraction digits",
+			text: "[00:01]a\n[00:01.5]b\n[00:01.05]c\n[00:01.005]d\n[01:01:20]e",
+			want: []domain.SyncedLine{line(1000, "a"), line(1005, "d"), line(1050, "c"),
+				line(1500, "b"), line(61200, "e")},
+		},
+		{
+			name: "several tags on a line",
+			text: "[00:10.00][00:01.00]chorus\n[00:05.00]verse",
+			want: []domain.SyncedLine{line(1000, "chorus"), line(5000, "verse"), line(10000, "chorus")},
+		},
+		{
+			name: "metadata and crlf",
+			text: "[ar:Muse]\r\n[ti:Uprising]\r\n\r\n[00:01.00]one\r\n",
+			want: []domain.SyncedLine{line(1000, "one")},
+		},
+		{
+			name: "positive offset",
+			text: "[offset:500]\n[00:00.20]a\n[00:01.00]b",
+			want: []domain.SyncedLine{line(0, "a"), line(500, "b")},
+		},
+		{
+			name: "negative offset",
+			text: "[offset: -250]\n[00:01.00]a",
+			want: []domain.SyncedLine{line(1250, "a")},
+		},
+		{
+			name: "empty text",
+			text: "[00:01.00]",
+			want: []domain.SyncedLine{line(1000, "")},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Parse(tt.text)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Parse = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	tests := []string{
+		"",
+		"[ar:Muse]",
+		"no tag",
+		"[00:01.00]one\nno tag",
+		"[offset:soon]\n[00:01.00]one",
+	}
+
+	for _, text := range tests {
+		_, err := Parse(text)
+		if !errors.Is(err, domain.ErrBadLRC) {
+			t.Errorf("Parse(%q) error = %v, want %v", text, err, domain.ErrBadLRC)
+		}
+	}
+}
+
+func TestFormat(t *testing.T) {
+	lines := []domain.SyncedLine{line(0, "start"), line(61234, "one"), line(3599990, "")}
+
+	got := Format(lines, Meta{Artist: "Muse", Title: "Uprising"})
+	want := "[ar:Muse]\n[ti:Uprising]\n[00:00.00]start\n[01:01.23]one\n[59:59.99]\n"
+	if got != want {
+		t.Errorf("Format = %q, want %q", got, want)
+	}
+
+	if got := Format(lines[:1], Meta{}); got != "[00:00.00]start\n" {
+		t.Errorf("Format without meta = %q", got)
+	}
+}
+
+func TestFormatParse(t *testing.T) {
+	lines := []domain.SyncedLine{line(0, "start"), line(1230, "one"), line(61230, "two"),
+		line(61230, "same time"), line(600000, "")}
+
+	got, err := Parse(Format(lines, Meta{Artist: "Muse", Title: "Uprising"}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, lines) {
+		t.Errorf("Parse(Format) = %v, want %v", got, lines)
+	}
+}
+
+func TestAt(t *testing.T) {
+	lines := []domain.SyncedLine{line(1000, "a"), line(2000, "b"), line(3000, "c")}
+
+	tests := []struct {
+		ms      int
+		next    int
+		current string
+		want    []domain.SyncedLine
+	}{
+		{0, 2, "", []domain.SyncedLine{line(1000, "a"), line(2000, "b")}},
+		{1000, 1, "a", []domain.SyncedLine{line(2000, "b")}},
+		{2500, 5, "b", []domain.SyncedLine{line(3000, "c")}},
+		{9000, 2, "c", []domain.SyncedLine{}},
+		{1500, 0, "a", []domain.SyncedLine{}},
+	}
+
+	for _, tt := range tests {
+		got := At(lines, tt.ms, tt.next)
+
+		current := ""
+		if got.Current != nil {
+			current = got.Current.Text
+		}
+		if current != tt.current || !reflect.DeepEqual(got.Next, tt.want) {
+			t.Errorf("At(%d, %d) = %q, %v, want %q, %v", tt.ms, tt.next,
+				current, got.Next, tt.current, tt.want)
+		}
+	}
+}
+
+func TestSectionRange(t *testing.T) {
+	sections := []domain.LyricsSection{
+		{Type: domain.SectionVerse, Index: 1, Order: 1, Lines: []string{"One line", "Two line"}},
+		{Type: domain.SectionChorus, Index: 1, Order: 2, Lines: []string{"La  la"}},
+		{Type: domain.SectionVerse, Index: 2, Order: 3, Lines: []string{"Three"}},
+		{Type: domain.SectionChorus, Index: 2, Order: 4, Lines: []string{"la la"}},
+		{Type: domain.SectionOutro, Index: 1, Order: 5, Lines: []string{"not synced"}},
+	}
+	lines := []domain.SyncedLine{line(1000, "one line"), line(2000, "two line"),
+		line(3000, "LA LA"), line(4000, "three"), line(5000, "la la")}
+
+	tests := []struct {
+		order      int
+		start, end int
+		ok         bool
+	}{
+		{1, 1000, 3000, true},
+		{2, 3000, 4000, true},
+		{3, 4000, 5000, true},
+		{4, 5000, 5000, true},
+		{5, 0, 0, false},
+		{6, 0, 0, false},
+	}
+
+	for _, tt := range tests {
+		start, end, ok := SectionRange(sections, lines, tt.order)
+		if start != tt.start || end != tt.end || ok != tt.ok {
+			t.Errorf("SectionRange(%d) = %d, %d, %v, want %d, %d, %v", tt.order,
+				start, end, ok, tt.start, tt.end, tt.ok)
+		}
+	}
+}
@@ -0,0 +1,135 @@
+package lyrics
+
+import (
+	"github.com/NastyaAR/music_library/internal/domain"
+	"regexp"
+	"strings"
+)
+
+var sectionKeywords = map[string]domain.SectionType{
+	"intro":      domain.SectionIntro,
+	"интро":      domain.SectionIntro,
+	"вступление": domain.SectionIntro,
+	"verse":      domain.SectionVerse,
+	"куплет":     domain.SectionVerse,
+	"pre-chorus": domain.SectionPreChorus,
+	"prechorus":  domain.SectionPreChorus,
+	"pre chorus": domain.SectionPreChorus,
+	"пре-припев": domain.SectionPreChorus,
+	"предприпев": domain.SectionPreChorus,
+	"chorus":     domain.SectionChorus,
+	"refrain":    domain.SectionChorus,
+	"припев":     domain.SectionChorus,
+	"hook":       domain.SectionHook,
+	"хук":        domain.SectionHook,
+	"bridge":     domain.SectionBridge,
+	"бридж":      domain.SectionBridge,
+	"outro":      domain.SectionOutro,
+	"аутро":      domain.SectionOutro,
+	"концовка":   domain.SectionOutro,
+}
+
+// bracketMarker matches "[Chorus]", "[Verse 2: Artist]" or "(Припев)".
+var bracketMarker = regexp.MustCompile(`^[\[(]\s*([\p{L}\- ]+?)\s*(\d+)?\s*(?::[^\])]*)?[\])]$`)
+
+// colonMarker matches "Chorus:", "Куплет 2:" and "Припев: first line".
+var colonMarker = regexp.MustCompile(`^([\p{L}\- ]+?)\s*(\d+)?\s*:\s*(.*)$`)
+
+type marker struct {
+	sectionType domain.SectionType
+	rest        string
+}
+
+func parseMarker(line string) (marker, bool) {
+	if m := bracketMarker.FindStringSubmatch(line); m != nil {
+		t, ok := sectionKeywords[strings.ToLower(m[1])]
+		return marker{sectionType: t}, ok
+	}
+
+	if m := colonMarker.FindStringSubmatch(line); m != nil {
+		t, ok := sectionKeywords[strings.ToLower(m[1])]
+		return marker{sectionType: t, rest: strings.TrimSpace(m[3])}, ok
+	}
+
+	return marker{}, false
+}
+
+// Parse splits raw lyrics into sections. Blocks are separated by blank
+// lines or by section markers; blocks without a marker are verses.
+// A marker without lines repeats the previous section of that type,
+// which is how repeated choruses are usually written.
+func Parse(text string) []domain.LyricsSection {
+	sections := make([]domain.LyricsSection, 0)
+
+	var current *domain.LyricsSection
+
+	flush := func() {
+		if current != nil && len(current.Lines) > 0 {
+			sections = append(sections, *current)
+		} else if current != nil {
+			if lines := lastLines(sections, current.Type); lines != nil {
+				current.Lines = lines
+				sections = append(sections, *current)
+			}
+		}
+		current = nil
+	}
+
+	start := func(t domain.SectionType) {
+		flush()
+		current = &domain.LyricsSection{Type: t}
+	}
+
+	for _, line := range strings.Split(strings.ReplaceAll(text, "\r\n", "\n"), "\n") {
+		line = strings.TrimSpace(line)
+
+		if line == "" {
+			if current != nil && len(current.Lines) > 0 {
+				flush()
+			}
+			continue
+		}
+
+		if m, ok := parseMarker(line); ok {
+			start(m.sectionType)
+			if m.rest != "" {
+				current.Lines = append(current.Lines, m.rest)
+			}
+			continue
+		}
+
+		if current == nil {
+			current = &domain.LyricsSection{Type: domain.SectionVerse}
+		}
+		current.Lines = append(current.Lines, line)
+	}
+	flush()
+
+	counts := make(map[domain.SectionType]int)
+	for i := range sections {
+		counts[sections[i].Type] += 1
+		sections[i].Index = counts[sections[i].Type]
+		sections[i].Order = i + 1
+	}
+
+	return sections
+}
+
+func lastLines(sections []domain.LyricsSection, t domain.SectionType) []string {
+	for i := len(sections) - 1; i >= 0; i-- {
+		if sections[i].Type == t {
+			return append([]string(nil), sections[i].Lines...)
+		}
+	}
+	return nil
+}
+
+// Find returns the index-th section of the given type, index starts from 1.
+func Find(sections []domain.LyricsSection, t domain.SectionType, index int) (domain.LyricsSection, bool) {
+	for _, s := range sections {
+		if s.Type == t && s.Index == index {
+			return s, true
+		}
+	}
+	return domain.LyricsSection{}, false
+}
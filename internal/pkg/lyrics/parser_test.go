@@ -0,0 +1,122 @@
+package lyrics
+
+import (
+	"github.com/NastyaAR/music_library/internal/domain"
+	"reflect"
+	"testing"
+)
+
+func section(t domain.SectionType, index int, order int, lines ...string) domain.LyricsSection {
+	return domain.LyricsSection{Type: t, Index: index, Order: order, Lines: lines}
+}
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want []domain.LyricsSection
+	}{
+		{
+			name: "empty",
+			text: "",
+			want: []domain.LyricsSection{},
+		},
+		{
+			name: "blank lines split verses",
+			text: "one\ntwo\n\n\nthree\n",
+			want: []domain.LyricsSection{
+				section(domain.SectionVerse, 1, 1, "one", "two"),
+				section(domain.SectionVerse, 2, 2, "three"),
+			},
+		},
+		{
+			name: "crlf",
+			text: "one\r\ntwo\r\n\r\nthree\r\n",
+			want: []domain.LyricsSection{
+				section(domain.SectionVerse, 1, 1, "one", "two"),
+				section(domain.SectionVerse, 2, 2, "three"),
+			},
+		},
+		{
+			name: "bracket headers",
+			text: "[Intro]\nhey\n[Verse 1: Artist]\none\n(Chorus)\nla la\n[Bridge]\nbridge",
+			want: []domain.LyricsSection{
+				section(domain.SectionIntro, 1, 1, "hey"),
+				section(domain.SectionVerse, 1, 2, "one"),
+				section(domain.SectionChorus, 1, 3, "la la"),
+				section(domain.SectionBridge, 1, 4, "bridge"),
+			},
+		},
+		{
+			name: "colon headers",
+			text: "Куплет 1:\nраз\nПрипев: ля ля\nля\nPre-Chorus:\nup",
+			want: []domain.LyricsSection{
+				section(domain.SectionVerse, 1, 1, "раз"),
+				section(domain.SectionChorus, 1, 2, "ля ля", "ля"),
+				section(domain.SectionPreChorus, 1, 3, "up"),
+			},
+		},
+		{
+			name: "empty header repeats the section",
+			text: "[Chorus]\nla la\n\n[Verse]\none\n\n[Chorus]\n\n[Outro]\nbye",
+			want: []domain.LyricsSection{
+				section(domain.SectionChorus, 1, 1, "la la"),
+				section(domain.SectionVerse, 1, 2, "one"),
+				section(domain.SectionChorus, 2, 3, "la la"),
+				section(domain.SectionOutro, 1, 4, "bye"),
+			},
+		},
+		{
+			name: "blank line after a header",
+			text: "[Hook]\n\nline",
+			want: []domain.LyricsSection{
+				section(domain.SectionHook, 1, 1, "line"),
+			},
+		},
+		{
+			name: "empty header without earlier section",
+			text: "one\n[Bridge]\n[Outro]\nbye",
+			want: []domain.LyricsSection{
+				section(domain.SectionVerse, 1, 1, "one"),
+				section(domain.SectionOutro, 1, 2, "bye"),
+			},
+		},
+		{
+			name: "unknown header is a line",
+			text: "[Guitar solo]\nNote: not a header",
+			want: []domain.LyricsSection{
+				section(domain.SectionVerse, 1, 1, "[Guitar solo]", "Note: not a header"),
+			},
+		},
+		{
+			name: "lines are trimmed",
+			text: "  [Chorus]  \n\tla la \n",
+			want: []domain.LyricsSection{
+				section(domain.SectionChorus, 1, 1, "la la"),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Parse(tt.text)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Parse(%q) = %v, want %v", tt.text, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFind(t *testing.T) {
+	sections := Parse("[Chorus]\nfirst\n[Verse]\none\n[Chorus]\nsecond")
+
+	got, ok := Find(sections, domain.SectionChorus, 2)
+	if !ok || got.Text() != "second" {
+		t.Errorf("Find chorus 2 = %v, %v", got, ok)
+	}
+
+	_, ok = Find(sections, domain.SectionVerse, 2)
+	if ok {
+		t.Error("Find found a missing verse")
+	}
+}
@@ -0,0 +1,83 @@
+package ratelimit
+
+import (
+	"context"
+	"math"
+	"sync"
+	"time"
+)
+
+// idleTTL is how long a bucket nobody touches is kept, a full bucket is
+// the same as a missing one after that.
+const idleTTL = 10 * time.Minute
+
+type bucket struct {
+	tokens float64
+	seen   time.Time
+}
+
+// MemoryStore keeps buckets in the process, every instance of the app
+// counts on its own.
+type MemoryStore struct {
+	mu        sync.Mutex
+	buckets   map[string]*bucket
+	lastSweep time.Time
+}
+
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		buckets:   make(map[string]*bucket),
+		lastSweep: time.Now(),
+	}
+}
+
+func (m *MemoryStore) Take(ctx context.Context, key string, budget Budget) (Result, error) {
+	return m.take(key, budget, 1)
+}
+
+func (m *MemoryStore) Check(ctx context.Context, key string, budget Budget) (Result, error) {
+	return m.take(key, budget, 0)
+}
+
+// take refills the bucket and takes cost tokens from it if there is at
+// least one, cost 0 only looks.
+func (m *MemoryStore) take(key string, budget Budget, cost float64) (Result, error) {
+	if budget.Unlimited() {
+		return Result{Allowed: true}, nil
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := time.Now()
+	m.sweep(now)
+
+	b, ok := m.buckets[key]
+	if !ok {
+		b = &bucket{tokens: float64(budget.Limit), seen: now}
+		m.buckets[key] = b
+	}
+
+	b.tokens = math.Min(float64(budget.Limit), b.tokens+float64(now.Sub(b.seen))*budget.rate())
+	b.seen = now
+
+	allowed := b.tokens >= 1
+	if allowed {
+		b.tokens -= cost
+	}
+
+	return result(budget, b.tokens, allowed), nil
+}
+
+func (m *MemoryStore) sweep(now time.Time) {
+	if now.Sub(m.lastSweep) < idleTTL {
+		return
+	}
+
+	for key, b := range m.buckets {
+		if now.Sub(b.seen) > idleTTL {
+			delete(m.buckets, key)
+		}
+	}
+	m.lastSweep = now
+}
@@ -0,0 +1,55 @@
+package ratelimit
+
+import (
+	"context"
+	"math"
+	"time"
+)
+
+// Budget is a token bucket: it holds up to Limit tokens and gets Limit
+// tokens back over every Period.
+type Budget struct {
+	Limit  int
+	Period time.Duration
+}
+
+func (b Budget) rate() float64 {
+	return float64(b.Limit) / float64(b.Period)
+}
+
+func (b Budget) Unlimited() bool {
+	return b.Limit <= 0 || b.Period <= 0
+}
+
+// Result of taking a token. RetryAfter is set when the request is not
+// allowed, Reset is how long until the bucket is full again.
+type Result struct {
+	Allowed    bool
+	Limit      int
+	Remaining  int
+	RetryAfter time.Duration
+	Reset      time.Duration
+}
+
+// Store keeps the buckets, in the process or in a shared database so
+// that all instances of the app count together.
+type Store interface {
+	Take(ctx context.Context, key string, budget Budget) (Result, error)
+	// Check tells whether Take would be allowed without taking a token.
+	Check(ctx context.Context, key string, budget Budget) (Result, error)
+}
+
+// result turns the tokens left in a bucket after a take into a Result.
+func result(budget Budget, tokens float64, allowed bool) Result {
+	rate := budget.rate()
+	res := Result{
+		Allowed:   allowed,
+		Limit:     budget.Limit,
+		Remaining: int(math.Floor(tokens)),
+		Reset:     time.Duration(math.Ceil((float64(budget.Limit) - tokens) / rate)),
+	}
+	if !allowed {
+		res.RetryAfter = time.Duration(math.Ceil((1 - tokens) / rate))
+	}
+	return res
+}
@@ -0,0 +1,159 @@
+package ratelimit
+
+import (
+	"context"
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"testing"
+	"time"
+)
+
+func newRedisStore(t *testing.T) (*RedisStore, *miniredis.Miniredis) {
+	t.Helper()
+
+	server := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: server.Addr()})
+	t.Cleanup(func() { _ = client.Close() })
+
+	return NewRedisStore(client, "test:"), server
+}
+
+func take(t *testing.T, store Store, key string, budget Budget) Result {
+	t.Helper()
+
+	res, err := store.Take(context.Background(), key, budget)
+	if err != nil {
+		t.Fatalf("Take(%q) error = %v", key, err)
+	}
+	return res
+}
+
+func TestRedisStoreLimit(t *testing.T) {
+	store, server := newRedisStore(t)
+	server.SetTime(time.Date(2024, 10, 1, 12, 0, 0, 0, time.UTC))
+	budget := Budget{Limit: 3, Period: time.Minute}
+
+	for i := 0; i < 3; i++ {
+		res := take(t, store, "client", budget)
+		if !res.Allowed {
+			t.Fatalf("take #%d not allowed", i)
+		}
+		if res.Limit != 3 || res.Remaining != 2-i {
+			t.Errorf("take #%d = %+v, want limit 3, remaining %d", i, res, 2-i)
+		}
+	}
+
+	res := take(t, store, "client", budget)
+	if res.Allowed {
+		t.Fatalf("take over the limit allowed")
+	}
+	if res.RetryAfter != 20*time.Second {
+		t.Errorf("RetryAfter = %v, want 20s", res.RetryAfter)
+	}
+
+	// other clients have their own buckets
+	if res := take(t, store, "other", budget); !res.Allowed {
+		t.Errorf("take by another client not allowed")
+	}
+
+	if !server.Exists("test:client") {
+		t.Errorf("bucket key without prefix")
+	}
+}
+
+func TestRedisStoreRefill(t *testing.T) {
+	store, server := newRedisStore(t)
+	now := time.Date(2024, 10, 1, 12, 0, 0, 0, time.UTC)
+	server.SetTime(now)
+	budget := Budget{Limit: 2, Period: time.Minute}
+
+	take(t, store, "client", budget)
+	take(t, store, "client", budget)
+	if res := take(t, store, "client", budget); res.Allowed {
+		t.Fatalf("take from an empty bucket allowed")
+	}
+
+	// one token comes back every 30s
+	server.SetTime(now.Add(30 * time.Second))
+	res := take(t, store, "client", budget)
+	if !res.Allowed || res.Remaining != 0 {
+		t.Fatalf("take after 30s = %+v, want allowed with nothing left", res)
+	}
+	if res := take(t, store, "client", budget); res.Allowed {
+		t.Fatalf("second take after 30s allowed")
+	}
+
+	// the bucket never holds more than the limit
+	server.SetTime(now.Add(time.Hour))
+	res = take(t, store, "client", budget)
+	if !res.Allowed || res.Remaining != 1 {
+		t.Errorf("take after an hour = %+v, want allowed with 1 left", res)
+	}
+}
+
+func TestRedisStoreUnlimited(t *testing.T) {
+	store, server := newRedisStore(t)
+
+	res := take(t, store, "client", Budget{})
+	if !res.Allowed {
+		t.Errorf("unlimited take not allowed")
+	}
+	if len(server.Keys()) != 0 {
+		t.Errorf("unlimited take stored %v", server.Keys())
+	}
+}
+
+func TestMemoryStoreLimit(t *testing.T) {
+	store := NewMemoryStore()
+	budget := Budget{Limit: 2, Period: time.Hour}
+
+	take(t, store, "client", budget)
+	take(t, store, "client", budget)
+	res := take(t, store, "client", budget)
+	if res.Allowed {
+		t.Fatalf("take over the limit allowed")
+	}
+	if res.RetryAfter <= 0 || res.RetryAfter > 30*time.Minute {
+		t.Errorf("RetryAfter = %v, want up to 30m", res.RetryAfter)
+	}
+
+	if res := take(t, store, "other", budget); !res.Allowed {
+		t.Errorf("take by another client not allowed")
+	}
+}
+
+func TestMemoryStoreRefill(t *testing.T) {
+	store := NewMemoryStore()
+	budget := Budget{Limit: 1, Period: 50 * time.Millisecond}
+
+	take(t, store, "client", budget)
+	if res := take(t, store, "client", budget); res.Allowed {
+		t.Fatalf("take from an empty bucket allowed")
+	}
+
+	time.Sleep(60 * time.Millisecond)
+	if res := take(t, store, "client", budget); !res.Allowed {
+		t.Errorf("take after the period not allowed")
+	}
+}
+
+func TestCheckDoesNotTake(t *testing.T) {
+	redisStore, _ := newRedisStore(t)
+	stores := map[string]Store{"memory": NewMemoryStore(), "redis": redisStore}
+	budget := Budget{Limit: 1, Period: time.Minute}
+
+	for name, store := range stores {
+		for i := 0; i < 3; i++ {
+			res, err := store.Check(context.Background(), "client", budget)
+			if err != nil || !res.Allowed {
+				t.Fatalf("%s: Check #%d = %+v, %v, want allowed", name, i, res, err)
+			}
+		}
+
+		take(t, store, "client", budget)
+		res, err := store.Check(context.Background(), "client", budget)
+		if err != nil || res.Allowed || res.RetryAfter <= 0 {
+			t.Errorf("%s: Check on an empty bucket = %+v, %v, want not allowed", name, res, err)
+		}
+	}
+}
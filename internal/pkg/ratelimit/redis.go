@@ -0,0 +1,79 @@
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"github.com/redis/go-redis/v9"
+	"strconv"
+)
+
+// takeScript refills and takes from a bucket in one step. The time comes
+// from the redis server, so the app instances do not need synced clocks.
+// Tokens go back as a string, redis would cut a lua number to an integer.
+// Cost 0 only checks the bucket.
+var takeScript = redis.NewScript(`
+local limit = tonumber(ARGV[1])
+local period = tonumber(ARGV[2])
+local cost = tonumber(ARGV[3])
+local t = redis.call('TIME')
+local now = tonumber(t[1]) * 1000 + math.floor(tonumber(t[2]) / 1000)
+
+local bucket = redis.call('HMGET', KEYS[1], 'tokens', 'ts')
+local tokens = tonumber(bucket[1]) or limit
+local ts = tonumber(bucket[2]) or now
+
+tokens = math.min(limit, tokens + math.max(0, now - ts) * limit / period)
+local allowed = 0
+if tokens >= 1 then
+	tokens = tokens - cost
+	allowed = 1
+end
+
+redis.call('HSET', KEYS[1], 'tokens', tostring(tokens), 'ts', now)
+redis.call('PEXPIRE', KEYS[1], period)
+return {allowed, tostring(tokens)}
+`)
+
+// RedisStore keeps buckets in redis or anything that speaks its protocol
+// and runs lua scripts, so all instances of the app share the budgets.
+type RedisStore struct {
+	client redis.Scripter
+	prefix string
+}
+
+func NewRedisStore(client redis.Scripter, prefix string) *RedisStore {
+	return &RedisStore{client: client, prefix: prefix}
+}
+
+func (r *RedisStore) Take(ctx context.Context, key string, budget Budget) (Result, error) {
+	return r.take(ctx, key, budget, 1)
+}
+
+func (r *RedisStore) Check(ctx context.Context, key string, budget Budget) (Result, error) {
+	return r.take(ctx, key, budget, 0)
+}
+
+func (r *RedisStore) take(ctx context.Context, key string, budget Budget, cost int) (Result, error) {
+	if budget.Unlimited() {
+		return Result{Allowed: true}, nil
+	}
+
+	reply, err := takeScript.Run(ctx, r.client, []string{r.prefix + key},
+		budget.Limit, budget.Period.Milliseconds(), cost).Slice()
+	if err != nil {
+		return Result{}, fmt.Errorf("redis take error: %w", err)
+	}
+
+	if len(reply) != 2 {
+		return Result{}, fmt.Errorf("redis take error: unexpected reply %v", reply)
+	}
+
+	allowed, _ := reply[0].(int64)
+	raw, _ := reply[1].(string)
+	tokens, err := strconv.ParseFloat(raw, 64)
+	if err != nil {
+		return Result{}, fmt.Errorf("redis take error: bad tokens %q", raw)
+	}
+
+	return result(budget, tokens, allowed == 1), nil
+}
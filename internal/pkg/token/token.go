@@ -0,0 +1,90 @@
+package token
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"github.com/NastyaAR/music_library/internal/domain"
+	"github.com/golang-jwt/jwt/v5"
+	"strconv"
+	"time"
+)
+
+// Manager issues and checks HS256 signed access tokens.
+type Manager struct {
+	key    []byte
+	issuer string
+	ttl    time.Duration
+}
+
+func NewManager(key string, issuer string, ttl time.Duration) *Manager {
+	return &Manager{key: []byte(key), issuer: issuer, ttl: ttl}
+}
+
+type claims struct {
+	Username string      `json:"username"`
+	Role     domain.Role `json:"role"`
+	jwt.RegisteredClaims
+}
+
+func (m *Manager) Issue(identity domain.Identity, now time.Time) (string, time.Time, error) {
+	expiresAt := now.Add(m.ttl)
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims{
+		Username: identity.Username,
+		Role:     identity.Role,
+		RegisteredClaims: jwt.RegisteredClaims{
+			Issuer:    m.issuer,
+			Subject:   strconv.FormatInt(identity.UserID, 10),
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(expiresAt),
+		},
+	})
+
+	signed, err := token.SignedString(m.key)
+	if err != nil {
+		return "", time.Time{}, err
+	}
+
+	return signed, expiresAt, nil
+}
+
+func (m *Manager) Parse(signed string) (domain.Identity, error) {
+	var c claims
+	_, err := jwt.ParseWithClaims(signed, &c, func(*jwt.Token) (interface{}, error) {
+		return m.key, nil
+	}, jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}),
+		jwt.WithIssuer(m.issuer), jwt.WithExpirationRequired())
+	if err != nil {
+		return domain.Identity{}, domain.ErrInvalidToken
+	}
+
+	id, err := strconv.ParseInt(c.Subject, 10, 64)
+	if err != nil || id <= 0 {
+		return domain.Identity{}, domain.ErrInvalidToken
+	}
+
+	if !c.Role.IsValid() {
+		return domain.Identity{}, domain.ErrInvalidToken
+	}
+
+	return domain.Identity{UserID: id, Username: c.Username, Role: c.Role}, nil
+}
+
+// NewOpaque returns a random token for the client and the hash to keep,
+// so leaked rows of the store can not be used as tokens.
+func NewOpaque() (string, string, error) {
+	raw := make([]byte, 32)
+	_, err := rand.Read(raw)
+	if err != nil {
+		return "", "", err
+	}
+
+	plain := base64.RawURLEncoding.EncodeToString(raw)
+	return plain, Hash(plain), nil
+}
+
+func Hash(plain string) string {
+	sum := sha256.Sum256([]byte(plain))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,133 @@
+package song_info
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/NastyaAR/music_library/internal/domain"
+	"github.com/NastyaAR/music_library/internal/pkg/circuit_breaker"
+	"go.uber.org/zap"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const dateLayout = "02.01.2006"
+
+type songDetailResponse struct {
+	ReleaseDate string `json:"releaseDate"`
+	Text        string `json:"text"`
+	Link        string `json:"link"`
+}
+
+type HTTPSongInfoProvider struct {
+	baseURL    string
+	client     *http.Client
+	retries    int
+	retryDelay time.Duration
+	breaker    *circuit_breaker.CircuitBreaker
+	lg         *zap.Logger
+}
+
+func NewHTTPSongInfoProvider(baseURL string, timeout time.Duration, retries int,
+	retryDelay time.Duration, breaker *circuit_breaker.CircuitBreaker, lg *zap.Logger) *HTTPSongInfoProvider {
+	lg.With(zap.String("component", "http_song_info_provider"))
+	return &HTTPSongInfoProvider{
+		baseURL:    strings.TrimRight(baseURL, "/"),
+		client:     &http.Client{Timeout: timeout},
+		retries:    retries,
+		retryDelay: retryDelay,
+		breaker:    breaker,
+		lg:         lg,
+	}
+}
+
+func isProviderFailure(err error) bool {
+	return !errors.Is(err, domain.ErrSongInfoNotFound)
+}
+
+func (p *HTTPSongInfoProvider) GetInfo(ctx context.Context, group string, name string) (domain.SongDetail, error) {
+	p.lg.Info("get song info", zap.String("group", group),
+		zap.String("name", name))
+
+	var detail domain.SongDetail
+	var err error
+	for attempt := 0; attempt <= p.retries; attempt++ {
+		if attempt > 0 {
+			select {
+			case <-ctx.Done():
+				return domain.SongDetail{}, fmt.Errorf("%w: %v", domain.ErrSongInfoUnavailable, ctx.Err())
+			case <-time.After(p.retryDelay * time.Duration(attempt)):
+			}
+		}
+
+		err = p.breaker.Execute(func() error {
+			var reqErr error
+			detail, reqErr = p.request(ctx, group, name)
+			return reqErr
+		}, isProviderFailure)
+		if err == nil || !isProviderFailure(err) || errors.Is(err, circuit_breaker.ErrOpen) {
+			break
+		}
+		p.lg.Warn("get song info attempt failed", zap.Int("attempt", attempt+1),
+			zap.Error(err))
+	}
+
+	if errors.Is(err, domain.ErrSongInfoNotFound) {
+		return domain.SongDetail{}, err
+	}
+	if err != nil {
+		p.lg.Warn("get song info error", zap.Error(err))
+		return domain.SongDetail{}, fmt.Errorf("%w: %v", domain.ErrSongInfoUnavailable, err)
+	}
+
+	p.lg.Info("successful get song info")
+	return detail, nil
+}
+
+func (p *HTTPSongInfoProvider) request(ctx context.Context, group string, name string) (domain.SongDetail, error) {
+	params := url.Values{}
+	params.Set("group", group)
+	params.Set("song", name)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet,
+		p.baseURL+"/info?"+params.Encode(), nil)
+	if err != nil {
+		return domain.SongDetail{}, err
+	}
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return domain.SongDetail{}, err
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode == http.StatusNotFound:
+		return domain.SongDetail{}, domain.ErrSongInfoNotFound
+	case resp.StatusCode != http.StatusOK:
+		return domain.SongDetail{}, fmt.Errorf("unexpected status %d", resp.StatusCode)
+	}
+
+	var body songDetailResponse
+	err = json.NewDecoder(resp.Body).Decode(&body)
+	if err != nil {
+		return domain.SongDetail{}, err
+	}
+
+	detail := domain.SongDetail{
+		Text: body.Text,
+		Link: body.Link,
+	}
+
+	if body.ReleaseDate != "" {
+		detail.ReleaseDate, err = time.Parse(dateLayout, body.ReleaseDate)
+		if err != nil {
+			return domain.SongDetail{}, fmt.Errorf("bad release date %q: %v", body.ReleaseDate, err)
+		}
+	}
+
+	return detail, nil
+}
@@ -0,0 +1,142 @@
+package song_info_test
+
+import (
+	"context"
+	"errors"
+	"github.com/NastyaAR/music_library/internal/domain"
+	"github.com/NastyaAR/music_library/internal/pkg/circuit_breaker"
+	"github.com/NastyaAR/music_library/internal/provider/song_info"
+	"github.com/NastyaAR/music_library/internal/provider/song_info/song_infotest"
+	"go.uber.org/zap"
+	"testing"
+	"time"
+)
+
+func newProvider(t *testing.T, retries int, breaker *circuit_breaker.CircuitBreaker) (*song_info.HTTPSongInfoProvider,
+	*song_infotest.FakeServer) {
+	t.Helper()
+
+	server := song_infotest.NewFakeServer()
+	t.Cleanup(server.Close)
+
+	if breaker == nil {
+		breaker = circuit_breaker.NewCircuitBreaker(100, time.Minute)
+	}
+
+	return song_info.NewHTTPSongInfoProvider(server.URL, time.Second, retries,
+		time.Millisecond, breaker, zap.NewNop()), server
+}
+
+func TestGetInfo(t *testing.T) {
+	provider, server := newProvider(t, 0, nil)
+	released := time.Date(2006, 7, 16, 0, 0, 0, 0, time.UTC)
+	server.AddSong("Muse", "Supermassive Black Hole", domain.SongDetail{
+		ReleaseDate: released,
+		Text:        "Ooh baby, don't you know I suffer?",
+		Link:        "https://www.youtube.com/watch?v=Xsp3_a-PMTw",
+	})
+
+	detail, err := provider.GetInfo(context.Background(), "Muse", "Supermassive Black Hole")
+	if err != nil {
+		t.Fatalf("GetInfo() error = %v", err)
+	}
+
+	if !detail.ReleaseDate.Equal(released) || detail.Text == "" || detail.Link == "" {
+		t.Errorf("GetInfo() = %+v, want all fields filled", detail)
+	}
+}
+
+func TestGetInfoNotFoundIsNotRetried(t *testing.T) {
+	provider, server := newProvider(t, 3, nil)
+
+	_, err := provider.GetInfo(context.Background(), "Muse", "Unknown")
+	if !errors.Is(err, domain.ErrSongInfoNotFound) {
+		t.Fatalf("GetInfo() error = %v, want %v", err, domain.ErrSongInfoNotFound)
+	}
+
+	if server.Requests() != 1 {
+		t.Errorf("requests = %d, want 1", server.Requests())
+	}
+}
+
+func TestGetInfoRetriesServerErrors(t *testing.T) {
+	provider, server := newProvider(t, 2, nil)
+	server.AddSong("Muse", "Uprising", domain.SongDetail{Text: "Paranoia is in bloom"})
+	server.FailNext(2)
+
+	detail, err := provider.GetInfo(context.Background(), "Muse", "Uprising")
+	if err != nil {
+		t.Fatalf("GetInfo() error = %v", err)
+	}
+
+	if detail.Text != "Paranoia is in bloom" {
+		t.Errorf("GetInfo().Text = %q", detail.Text)
+	}
+	if server.Requests() != 3 {
+		t.Errorf("requests = %d, want 3", server.Requests())
+	}
+}
+
+func TestGetInfoGivesUpAfterRetries(t *testing.T) {
+	provider, server := newProvider(t, 1, nil)
+	server.SetDown(true)
+
+	_, err := provider.GetInfo(context.Background(), "Muse", "Uprising")
+	if !errors.Is(err, domain.ErrSongInfoUnavailable) {
+		t.Fatalf("GetInfo() error = %v, want %v", err, domain.ErrSongInfoUnavailable)
+	}
+
+	if server.Requests() != 2 {
+		t.Errorf("requests = %d, want 2", server.Requests())
+	}
+}
+
+func TestCircuitBreakerOpensAndHalfOpens(t *testing.T) {
+	cooldown := 50 * time.Millisecond
+	provider, server := newProvider(t, 0, circuit_breaker.NewCircuitBreaker(2, cooldown))
+	server.AddSong("Muse", "Uprising", domain.SongDetail{Text: "Paranoia is in bloom"})
+	server.SetDown(true)
+	ctx := context.Background()
+
+	for i := 0; i < 2; i++ {
+		_, err := provider.GetInfo(ctx, "Muse", "Uprising")
+		if !errors.Is(err, domain.ErrSongInfoUnavailable) {
+			t.Fatalf("GetInfo() #%d error = %v, want %v", i, err, domain.ErrSongInfoUnavailable)
+		}
+	}
+
+	// open: the service is not called at all
+	server.SetDown(false)
+	_, err := provider.GetInfo(ctx, "Muse", "Uprising")
+	if !errors.Is(err, domain.ErrSongInfoUnavailable) {
+		t.Fatalf("GetInfo() on open breaker error = %v, want %v", err, domain.ErrSongInfoUnavailable)
+	}
+	if server.Requests() != 2 {
+		t.Fatalf("requests on open breaker = %d, want 2", server.Requests())
+	}
+
+	// half-open after the cooldown: a failed probe opens it again
+	time.Sleep(cooldown + 10*time.Millisecond)
+	server.SetDown(true)
+	_, err = provider.GetInfo(ctx, "Muse", "Uprising")
+	if !errors.Is(err, domain.ErrSongInfoUnavailable) || server.Requests() != 3 {
+		t.Fatalf("failed probe: error = %v, requests = %d, want 3", err, server.Requests())
+	}
+	_, err = provider.GetInfo(ctx, "Muse", "Uprising")
+	if server.Requests() != 3 {
+		t.Fatalf("requests after failed probe = %d, want 3", server.Requests())
+	}
+
+	// a successful probe closes it
+	time.Sleep(cooldown + 10*time.Millisecond)
+	server.SetDown(false)
+	for i := 0; i < 2; i++ {
+		_, err = provider.GetInfo(ctx, "Muse", "Uprising")
+		if err != nil {
+			t.Fatalf("GetInfo() after recovery #%d error = %v", i, err)
+		}
+	}
+	if server.Requests() != 5 {
+		t.Errorf("requests after recovery = %d, want 5", server.Requests())
+	}
+}
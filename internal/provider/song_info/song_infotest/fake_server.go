@@ -0,0 +1,102 @@
+// Package song_infotest has a local song details service for tests, it is
+// not linked into the app.
+package song_infotest
+
+import (
+	"encoding/json"
+	"github.com/NastyaAR/music_library/internal/domain"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+)
+
+// dateLayout is the date format of the song details service.
+const dateLayout = "02.01.2006"
+
+type songDetailResponse struct {
+	ReleaseDate string `json:"releaseDate"`
+	Text        string `json:"text"`
+	Link        string `json:"link"`
+}
+
+// FakeServer is a local stand-in for the song details service. It serves
+// GET /info?group=&song= from an in-memory catalog and can be switched
+// into a failing state to exercise retries and the circuit breaker.
+type FakeServer struct {
+	*httptest.Server
+
+	mu       sync.Mutex
+	songs    map[string]domain.SongDetail
+	down     bool
+	failNext int
+	requests int
+}
+
+func NewFakeServer() *FakeServer {
+	f := &FakeServer{songs: make(map[string]domain.SongDetail)}
+	f.Server = httptest.NewServer(http.HandlerFunc(f.serveInfo))
+	return f
+}
+
+func fakeKey(group string, name string) string {
+	return group + "\x00" + name
+}
+
+func (f *FakeServer) AddSong(group string, name string, detail domain.SongDetail) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.songs[fakeKey(group, name)] = detail
+}
+
+func (f *FakeServer) SetDown(down bool) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.down = down
+}
+
+// FailNext makes the next n requests fail with 503.
+func (f *FakeServer) FailNext(n int) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.failNext = n
+}
+
+func (f *FakeServer) Requests() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.requests
+}
+
+func (f *FakeServer) serveInfo(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	f.requests += 1
+	down := f.down || f.failNext > 0
+	if f.failNext > 0 {
+		f.failNext -= 1
+	}
+	detail, ok := f.songs[fakeKey(r.URL.Query().Get("group"), r.URL.Query().Get("song"))]
+	f.mu.Unlock()
+
+	switch {
+	case r.Method != http.MethodGet || r.URL.Path != "/info":
+		w.WriteHeader(http.StatusNotFound)
+		return
+	case down:
+		w.WriteHeader(http.StatusServiceUnavailable)
+		return
+	case !ok:
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	resp := songDetailResponse{
+		Text: detail.Text,
+		Link: detail.Link,
+	}
+	if !detail.ReleaseDate.IsZero() {
+		resp.ReleaseDate = detail.ReleaseDate.Format(dateLayout)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(resp)
+}
@@ -0,0 +1,211 @@
+package repo
+
+import (
+	"context"
+	"errors"
+	"github.com/NastyaAR/music_library/internal/domain"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"go.uber.org/zap"
+)
+
+type PostgresAlbumRepo struct {
+	db *pgxpool.Pool
+	lg *zap.Logger
+}
+
+const albumColumns = `id, title, artist_id, release_date, cover_link`
+
+func NewPostgresAlbumRepo(db *pgxpool.Pool, lg *zap.Logger) *PostgresAlbumRepo {
+	lg.With(zap.String("component", "postgres_album_repo"))
+	return &PostgresAlbumRepo{db: db, lg: lg}
+}
+
+func scanAlbum(row pgx.Row, album *domain.Album) error {
+	return row.Scan(&album.ID, &album.Title, &album.ArtistID,
+		&album.ReleaseDate, &album.CoverLink)
+}
+
+// albumError translates driver errors of album queries, a foreign key
+// violation means the artist of the album does not exist.
+func albumError(err error, fallback error) error {
+	if errors.Is(err, pgx.ErrNoRows) || errors.Is(err, domain.ErrAlbumNotFound) {
+		return domain.ErrAlbumNotFound
+	}
+
+	var pgErr *pgconn.PgError
+	if !errors.As(err, &pgErr) {
+		return fallback
+	}
+
+	switch pgErr.Code {
+	case foreignKeyViolation:
+		return domain.ErrUnknownAlbumArtist
+	case uniqueViolation, serializationFailure, deadlockDetected:
+		return domain.ErrConflict
+	}
+
+	return fallback
+}
+
+func (p *PostgresAlbumRepo) Add(ctx context.Context, newAlbum *domain.Album) (domain.Album, error) {
+	p.lg.Info("add new album", zap.Any("album", *newAlbum))
+
+	query := `insert into albums(title, artist_id, release_date, cover_link)
+	values ($1, $2, $3, $4) returning ` + albumColumns
+
+	var created domain.Album
+	err := scanAlbum(p.db.QueryRow(ctx, query, newAlbum.Title, newAlbum.ArtistID,
+		newAlbum.ReleaseDate, newAlbum.CoverLink), &created)
+	if err != nil {
+		p.lg.Warn("add album error", zap.Error(err))
+		return domain.Album{}, albumError(err, domain.ErrAddAlbumDB)
+	}
+
+	p.lg.Info("successful adding new album")
+	return created, nil
+}
+
+func (p *PostgresAlbumRepo) Delete(ctx context.Context, id int64) error {
+	p.lg.Info("delete album", zap.Int64("id", id))
+
+	err := pgx.BeginFunc(ctx, p.db, func(tx pgx.Tx) error {
+		_, err := tx.Exec(ctx, `update songs set album_id=null, track_number=null
+			where album_id=$1`, id)
+		if err != nil {
+			return err
+		}
+
+		tag, err := tx.Exec(ctx, `delete from albums where id=$1`, id)
+		if err != nil {
+			return err
+		}
+		if tag.RowsAffected() == 0 {
+			return domain.ErrAlbumNotFound
+		}
+		return nil
+	})
+	if err != nil {
+		p.lg.Warn("delete album error", zap.Error(err))
+		return albumError(err, domain.ErrDeleteAlbumDB)
+	}
+
+	p.lg.Info("successful delete album")
+	return nil
+}
+
+func (p *PostgresAlbumRepo) Update(ctx context.Context, id int64, upd *domain.Album) (domain.Album, error) {
+	p.lg.Info("update album", zap.Int64("id", id))
+
+	query := `update albums set title=$1, artist_id=$2, release_date=$3, cover_link=$4
+				where id=$5
+				returning ` + albumColumns
+
+	var updated domain.Album
+	err := scanAlbum(p.db.QueryRow(ctx, query, upd.Title, upd.ArtistID,
+		upd.ReleaseDate, upd.CoverLink, id), &updated)
+	if err != nil {
+		p.lg.Warn("update album error", zap.Error(err))
+		return domain.Album{}, albumError(err, domain.ErrUpdateAlbumDB)
+	}
+
+	p.lg.Info("successful updating album")
+	return updated, nil
+}
+
+func (p *PostgresAlbumRepo) Get(ctx context.Context, id int64) (domain.Album, error) {
+	p.lg.Info("get album", zap.Int64("id", id))
+
+	query := `select ` + albumColumns + ` from albums where id=$1`
+
+	var album domain.Album
+	err := scanAlbum(p.db.QueryRow(ctx, query, id), &album)
+	if err != nil {
+		p.lg.Warn("get album error", zap.Error(err))
+		return domain.Album{}, albumError(err, domain.ErrGetAlbumDB)
+	}
+
+	p.lg.Info("successful getting album")
+	return album, nil
+}
+
+func (p *PostgresAlbumRepo) GetAll(ctx context.Context, limit int, offset int) ([]domain.Album, error) {
+	p.lg.Info("get albums", zap.Int("limit", limit), zap.Int("offset", offset))
+
+	query := `select ` + albumColumns + ` from albums
+	order by title, id limit $1 offset $2`
+
+	rows, err := p.db.Query(ctx, query, limit, offset)
+	if err != nil {
+		p.lg.Warn("get albums error", zap.Error(err))
+		return nil, domain.ErrGetAllAlbumsDB
+	}
+	defer rows.Close()
+
+	albums := []domain.Album{}
+	for rows.Next() {
+		var album domain.Album
+		err = scanAlbum(rows, &album)
+		if err != nil {
+			p.lg.Warn("get albums error", zap.Error(err))
+			continue
+		}
+		albums = append(albums, album)
+	}
+
+	return albums, nil
+}
+
+func (p *PostgresAlbumRepo) GetTracks(ctx context.Context, id int64) ([]domain.Song, error) {
+	p.lg.Info("get album tracks", zap.Int64("id", id))
+
+	query := `select ` + songColumns + ` from songs
+	where album_id=$1 and ` + songNotDeleted + ` order by track_number nulls last, name`
+
+	rows, err := p.db.Query(ctx, query, id)
+	if err != nil {
+		p.lg.Warn("get album tracks error", zap.Error(err))
+		return nil, domain.ErrGetAllSongsDB
+	}
+	defer rows.Close()
+
+	songs := []domain.Song{}
+	for rows.Next() {
+		var song domain.Song
+		err = scanSong(rows, &song)
+		if err != nil {
+			p.lg.Warn("get album tracks error", zap.Error(err))
+			continue
+		}
+		songs = append(songs, song)
+	}
+
+	return songs, nil
+}
+
+func (p *PostgresAlbumRepo) GetByIDs(ctx context.Context, ids []int64) ([]domain.Album, error) {
+	p.lg.Info("get albums by ids", zap.Int("count", len(ids)))
+
+	query := `select ` + albumColumns + ` from albums where id=any($1) order by id`
+
+	rows, err := p.db.Query(ctx, query, ids)
+	if err != nil {
+		p.lg.Warn("get albums by ids error", zap.Error(err))
+		return nil, domain.ErrGetAllAlbumsDB
+	}
+	defer rows.Close()
+
+	albums := []domain.Album{}
+	for rows.Next() {
+		var album domain.Album
+		err = scanAlbum(rows, &album)
+		if err != nil {
+			p.lg.Warn("get albums by ids error", zap.Error(err))
+			continue
+		}
+		albums = append(albums, album)
+	}
+
+	return albums, nil
+}
@@ -0,0 +1,158 @@
+package repo
+
+import (
+	"context"
+	"errors"
+	"github.com/NastyaAR/music_library/internal/domain"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"go.uber.org/zap"
+)
+
+type PostgresAPIKeyRepo struct {
+	db *pgxpool.Pool
+	lg *zap.Logger
+}
+
+const apiKeyColumns = `id, user_id, name, prefix, key_hash, scopes, rate_limit,
+	created_at, last_used_at, revoked_at`
+
+func NewPostgresAPIKeyRepo(db *pgxpool.Pool, lg *zap.Logger) *PostgresAPIKeyRepo {
+	lg.With(zap.String("component", "postgres_api_key_repo"))
+	return &PostgresAPIKeyRepo{db: db, lg: lg}
+}
+
+func scanAPIKey(row pgx.Row, key *domain.APIKey, dest ...any) error {
+	var scopes []string
+	err := row.Scan(append([]any{&key.ID, &key.UserID, &key.Name, &key.Prefix, &key.Hash, &scopes,
+		&key.RateLimit, &key.CreatedAt, &key.LastUsedAt, &key.RevokedAt}, dest...)...)
+	if err != nil {
+		return err
+	}
+
+	key.Scopes = make([]domain.Scope, 0, len(scopes))
+	for _, scope := range scopes {
+		key.Scopes = append(key.Scopes, domain.Scope(scope))
+	}
+	return nil
+}
+
+func (p *PostgresAPIKeyRepo) Add(ctx context.Context, key *domain.APIKey) (domain.APIKey, error) {
+	p.lg.Info("add api key", zap.Int64("user", key.UserID), zap.String("name", key.Name))
+
+	scopes := make([]string, 0, len(key.Scopes))
+	for _, scope := range key.Scopes {
+		scopes = append(scopes, string(scope))
+	}
+
+	query := `insert into api_keys(user_id, name, prefix, key_hash, scopes, rate_limit)
+	values ($1, $2, $3, $4, $5, $6) returning ` + apiKeyColumns
+
+	var created domain.APIKey
+	err := scanAPIKey(p.db.QueryRow(ctx, query, key.UserID, key.Name, key.Prefix, key.Hash,
+		scopes, key.RateLimit), &created)
+	if err != nil {
+		p.lg.Warn("add api key error", zap.Error(err))
+		return domain.APIKey{}, domain.ErrAddAPIKeyDB
+	}
+
+	p.lg.Info("successful adding api key")
+	return created, nil
+}
+
+func (p *PostgresAPIKeyRepo) Get(ctx context.Context, id int64) (domain.APIKey, error) {
+	p.lg.Info("get api key", zap.Int64("id", id))
+
+	query := `select ` + apiKeyColumns + ` from api_keys where id=$1`
+
+	var key domain.APIKey
+	err := scanAPIKey(p.db.QueryRow(ctx, query, id), &key)
+	if errors.Is(err, pgx.ErrNoRows) {
+		p.lg.Warn("get api key error", zap.Error(err))
+		return domain.APIKey{}, domain.ErrAPIKeyNotFound
+	}
+	if err != nil {
+		p.lg.Warn("get api key error", zap.Error(err))
+		return domain.APIKey{}, domain.ErrGetAPIKeyDB
+	}
+
+	p.lg.Info("successful getting api key")
+	return key, nil
+}
+
+func (p *PostgresAPIKeyRepo) GetByUser(ctx context.Context, userID int64) ([]domain.APIKey, error) {
+	p.lg.Info("get api keys", zap.Int64("user", userID))
+
+	query := `select ` + apiKeyColumns + ` from api_keys where user_id=$1 order by id`
+
+	rows, err := p.db.Query(ctx, query, userID)
+	if err != nil {
+		p.lg.Warn("get api keys error", zap.Error(err))
+		return nil, domain.ErrGetAPIKeyDB
+	}
+	defer rows.Close()
+
+	keys := []domain.APIKey{}
+	for rows.Next() {
+		var key domain.APIKey
+		err = scanAPIKey(rows, &key)
+		if err != nil {
+			p.lg.Warn("get api keys error", zap.Error(err))
+			continue
+		}
+		keys = append(keys, key)
+	}
+
+	return keys, nil
+}
+
+func (p *PostgresAPIKeyRepo) Revoke(ctx context.Context, id int64) error {
+	p.lg.Info("revoke api key", zap.Int64("id", id))
+
+	query := `update api_keys set revoked_at=coalesce(revoked_at, now()) where id=$1`
+
+	tag, err := p.db.Exec(ctx, query, id)
+	if err != nil {
+		p.lg.Warn("revoke api key error", zap.Error(err))
+		return domain.ErrRevokeAPIKeyDB
+	}
+
+	if tag.RowsAffected() == 0 {
+		p.lg.Warn("revoke api key error", zap.Error(domain.ErrAPIKeyNotFound))
+		return domain.ErrAPIKeyNotFound
+	}
+
+	p.lg.Info("successful revoking api key")
+	return nil
+}
+
+func (p *PostgresAPIKeyRepo) Use(ctx context.Context, hash string) (domain.APIKey, domain.User, error) {
+	p.lg.Info("use api key")
+
+	// last_used_at is kept to the minute, so that busy keys do not
+	// write on every request
+	query := `with used as (
+		update api_keys set last_used_at=now()
+		where key_hash=$1 and revoked_at is null
+		and (last_used_at is null or last_used_at < now() - interval '1 minute')
+	)
+	select k.id, k.user_id, k.name, k.prefix, k.key_hash, k.scopes, k.rate_limit,
+		k.created_at, k.last_used_at, k.revoked_at, u.username, u.role
+	from api_keys k join users u on u.id = k.user_id
+	where k.key_hash=$1 and k.revoked_at is null`
+
+	var key domain.APIKey
+	var owner domain.User
+	err := scanAPIKey(p.db.QueryRow(ctx, query, hash), &key, &owner.Username, &owner.Role)
+	if errors.Is(err, pgx.ErrNoRows) {
+		p.lg.Warn("use api key error", zap.Error(err))
+		return domain.APIKey{}, domain.User{}, domain.ErrInvalidAPIKey
+	}
+	if err != nil {
+		p.lg.Warn("use api key error", zap.Error(err))
+		return domain.APIKey{}, domain.User{}, domain.ErrGetAPIKeyDB
+	}
+	owner.ID = key.UserID
+
+	return key, owner, nil
+}
@@ -0,0 +1,279 @@
+package repo
+
+import (
+	"context"
+	"errors"
+	"github.com/NastyaAR/music_library/internal/domain"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"go.uber.org/zap"
+)
+
+type PostgresArtistRepo struct {
+	db *pgxpool.Pool
+	lg *zap.Logger
+}
+
+const artistColumns = `id, name, country, coalesce(formed_year, 0), description, aliases`
+
+func NewPostgresArtistRepo(db *pgxpool.Pool, lg *zap.Logger) *PostgresArtistRepo {
+	lg.With(zap.String("component", "postgres_artist_repo"))
+	return &PostgresArtistRepo{db: db, lg: lg}
+}
+
+func scanArtist(row pgx.Row, artist *domain.Artist) error {
+	return row.Scan(&artist.ID, &artist.Name, &artist.Country,
+		&artist.FormedYear, &artist.Description, &artist.Aliases)
+}
+
+// artistError translates driver errors of artist queries, a foreign key
+// violation means songs or albums still refer to the artist.
+func artistError(err error, fallback error) error {
+	if errors.Is(err, pgx.ErrNoRows) || errors.Is(err, domain.ErrArtistNotFound) {
+		return domain.ErrArtistNotFound
+	}
+
+	var pgErr *pgconn.PgError
+	if !errors.As(err, &pgErr) {
+		return fallback
+	}
+
+	switch pgErr.Code {
+	case uniqueViolation:
+		return domain.ErrArtistExists
+	case foreignKeyViolation:
+		return domain.ErrArtistInUse
+	case serializationFailure, deadlockDetected:
+		return domain.ErrConflict
+	}
+
+	return fallback
+}
+
+func artistAliases(artist *domain.Artist) []string {
+	if artist.Aliases == nil {
+		return []string{}
+	}
+	return artist.Aliases
+}
+
+func (p *PostgresArtistRepo) Add(ctx context.Context, newArtist *domain.Artist) (domain.Artist, error) {
+	p.lg.Info("add new artist", zap.Any("artist", *newArtist))
+
+	query := `insert into artists(name, country, formed_year, description, aliases)
+	values ($1, $2, nullif($3, 0), $4, $5) returning ` + artistColumns
+
+	var created domain.Artist
+	err := scanArtist(p.db.QueryRow(ctx, query, newArtist.Name, newArtist.Country,
+		newArtist.FormedYear, newArtist.Description, artistAliases(newArtist)), &created)
+	if err != nil {
+		p.lg.Warn("add artist error", zap.Error(err))
+		return domain.Artist{}, artistError(err, domain.ErrAddArtistDB)
+	}
+
+	p.lg.Info("successful adding new artist")
+	return created, nil
+}
+
+// Delete also purges the trashed songs of the artist, only live songs and
+// albums keep the artist in use.
+func (p *PostgresArtistRepo) Delete(ctx context.Context, id int64) error {
+	p.lg.Info("delete artist", zap.Int64("id", id))
+
+	err := pgx.BeginFunc(ctx, p.db, func(tx pgx.Tx) error {
+		_, err := tx.Exec(ctx, `delete from songs where artist_id=$1 and not `+songNotDeleted, id)
+		if err != nil {
+			return err
+		}
+
+		tag, err := tx.Exec(ctx, `delete from artists where id=$1`, id)
+		if err != nil {
+			return err
+		}
+		if tag.RowsAffected() == 0 {
+			return domain.ErrArtistNotFound
+		}
+		return nil
+	})
+	if err != nil {
+		p.lg.Warn("delete artist error", zap.Error(err))
+		return artistError(err, domain.ErrDeleteArtistDB)
+	}
+
+	p.lg.Info("successful delete artist")
+	return nil
+}
+
+// Update also renames the denormalized song_group of the artist songs,
+// so the group/name song routes keep working after a rename.
+func (p *PostgresArtistRepo) Update(ctx context.Context, id int64, upd *domain.Artist) (domain.Artist, error) {
+	p.lg.Info("update artist", zap.Int64("id", id))
+
+	query := `update artists set name=$1, country=$2, formed_year=nullif($3, 0),
+                 description=$4, aliases=$5
+				where id=$6
+				returning ` + artistColumns
+
+	var updated domain.Artist
+	err := pgx.BeginFunc(ctx, p.db, func(tx pgx.Tx) error {
+		err := scanArtist(tx.QueryRow(ctx, query, upd.Name, upd.Country, upd.FormedYear,
+			upd.Description, artistAliases(upd), id), &updated)
+		if err != nil {
+			return err
+		}
+
+		_, err = tx.Exec(ctx, `update songs set song_group=$1 where artist_id=$2`,
+			updated.Name, id)
+		return err
+	})
+	if err != nil {
+		p.lg.Warn("update artist error", zap.Error(err))
+		return domain.Artist{}, artistError(err, domain.ErrUpdateArtistDB)
+	}
+
+	p.lg.Info("successful updating artist")
+	return updated, nil
+}
+
+func (p *PostgresArtistRepo) Get(ctx context.Context, id int64) (domain.Artist, error) {
+	p.lg.Info("get artist", zap.Int64("id", id))
+
+	query := `select ` + artistColumns + ` from artists where id=$1`
+
+	var artist domain.Artist
+	err := scanArtist(p.db.QueryRow(ctx, query, id), &artist)
+	if err != nil {
+		p.lg.Warn("get artist error", zap.Error(err))
+		return domain.Artist{}, artistError(err, domain.ErrGetArtistDB)
+	}
+
+	p.lg.Info("successful getting artist")
+	return artist, nil
+}
+
+func (p *PostgresArtistRepo) GetAll(ctx context.Context, limit int, offset int) ([]domain.Artist, error) {
+	p.lg.Info("get artists", zap.Int("limit", limit), zap.Int("offset", offset))
+
+	query := `select ` + artistColumns + ` from artists
+	order by name limit $1 offset $2`
+
+	rows, err := p.db.Query(ctx, query, limit, offset)
+	if err != nil {
+		p.lg.Warn("get artists error", zap.Error(err))
+		return nil, domain.ErrGetAllArtistsDB
+	}
+	defer rows.Close()
+
+	artists := []domain.Artist{}
+	for rows.Next() {
+		var artist domain.Artist
+		err = scanArtist(rows, &artist)
+		if err != nil {
+			p.lg.Warn("get artists error", zap.Error(err))
+			continue
+		}
+		artists = append(artists, artist)
+	}
+
+	return artists, nil
+}
+
+func (p *PostgresArtistRepo) GetSongs(ctx context.Context, id int64, limit int, offset int) ([]domain.Song, error) {
+	p.lg.Info("get artist songs", zap.Int64("id", id))
+
+	query := `select ` + songColumns + ` from songs
+	where artist_id=$1 and ` + songNotDeleted + ` order by name limit $2 offset $3`
+
+	rows, err := p.db.Query(ctx, query, id, limit, offset)
+	if err != nil {
+		p.lg.Warn("get artist songs error", zap.Error(err))
+		return nil, domain.ErrGetAllSongsDB
+	}
+	defer rows.Close()
+
+	songs := []domain.Song{}
+	for rows.Next() {
+		var song domain.Song
+		err = scanSong(rows, &song)
+		if err != nil {
+			p.lg.Warn("get artist songs error", zap.Error(err))
+			continue
+		}
+		songs = append(songs, song)
+	}
+
+	if len(songs) > 0 {
+		return songs, nil
+	}
+
+	var exists bool
+	err = p.db.QueryRow(ctx, `select exists (select 1 from artists where id=$1)`, id).Scan(&exists)
+	if err != nil {
+		p.lg.Warn("get artist songs error", zap.Error(err))
+		return nil, domain.ErrGetAllSongsDB
+	}
+	if !exists {
+		p.lg.Warn("get artist songs error", zap.Error(domain.ErrArtistNotFound))
+		return nil, domain.ErrArtistNotFound
+	}
+
+	return songs, nil
+}
+
+func (p *PostgresArtistRepo) GetByIDs(ctx context.Context, ids []int64) ([]domain.Artist, error) {
+	p.lg.Info("get artists by ids", zap.Int("count", len(ids)))
+
+	query := `select ` + artistColumns + ` from artists where id=any($1) order by id`
+
+	rows, err := p.db.Query(ctx, query, ids)
+	if err != nil {
+		p.lg.Warn("get artists by ids error", zap.Error(err))
+		return nil, domain.ErrGetAllArtistsDB
+	}
+	defer rows.Close()
+
+	artists := []domain.Artist{}
+	for rows.Next() {
+		var artist domain.Artist
+		err = scanArtist(rows, &artist)
+		if err != nil {
+			p.lg.Warn("get artists by ids error", zap.Error(err))
+			continue
+		}
+		artists = append(artists, artist)
+	}
+
+	return artists, nil
+}
+
+// GetSongsByArtists reads the first limit songs of every artist in one
+// query, in the order GetSongs uses.
+func (p *PostgresArtistRepo) GetSongsByArtists(ctx context.Context, ids []int64, limit int) (map[int64][]domain.Song, error) {
+	p.lg.Info("get songs of artists", zap.Int("count", len(ids)), zap.Int("limit", limit))
+
+	query := `select ` + songColumns + ` from (
+		select *, row_number() over (partition by artist_id order by name, id) as artist_row
+		from songs where artist_id=any($1) and ` + songNotDeleted + `
+	) songs where artist_row<=$2 order by artist_id, artist_row`
+
+	rows, err := p.db.Query(ctx, query, ids, limit)
+	if err != nil {
+		p.lg.Warn("get songs of artists error", zap.Error(err))
+		return nil, domain.ErrGetAllSongsDB
+	}
+	defer rows.Close()
+
+	songs := make(map[int64][]domain.Song)
+	for rows.Next() {
+		var song domain.Song
+		err = scanSong(rows, &song)
+		if err != nil {
+			p.lg.Warn("get songs of artists error", zap.Error(err))
+			continue
+		}
+		songs[song.ArtistID] = append(songs[song.ArtistID], song)
+	}
+
+	return songs, nil
+}
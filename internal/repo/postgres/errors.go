@@ -0,0 +1,54 @@
+package repo
+
+import (
+	"errors"
+	"github.com/NastyaAR/music_library/internal/domain"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+const (
+	uniqueViolation      = "23505"
+	foreignKeyViolation  = "23503"
+	serializationFailure = "40001"
+	deadlockDetected     = "40P01"
+)
+
+// songNameConstraint keeps a group from having two live songs with the
+// same name, songTrackConstraint two live songs on one album place.
+const (
+	songNameConstraint  = "songs_group_name_key"
+	songTrackConstraint = "songs_album_track_key"
+)
+
+// songError translates driver errors into domain errors, anything not
+// recognised is reported as fallback.
+func songError(err error, fallback error) error {
+	if errors.Is(err, pgx.ErrNoRows) || errors.Is(err, domain.ErrSongNotFound) {
+		return domain.ErrSongNotFound
+	}
+
+	if errors.Is(err, domain.ErrPreconditionFailed) {
+		return domain.ErrPreconditionFailed
+	}
+
+	var pgErr *pgconn.PgError
+	if !errors.As(err, &pgErr) {
+		return fallback
+	}
+
+	switch pgErr.Code {
+	case uniqueViolation:
+		switch pgErr.ConstraintName {
+		case songNameConstraint:
+			return domain.ErrSongExists
+		case songTrackConstraint:
+			return domain.ErrTrackTaken
+		}
+		return domain.ErrConflict
+	case foreignKeyViolation, serializationFailure, deadlockDetected:
+		return domain.ErrConflict
+	}
+
+	return fallback
+}
@@ -0,0 +1,351 @@
+package repo
+
+import (
+	"context"
+	"errors"
+	"github.com/NastyaAR/music_library/internal/domain"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"go.uber.org/zap"
+)
+
+type PostgresPlaylistRepo struct {
+	db *pgxpool.Pool
+	lg *zap.Logger
+}
+
+const playlistColumns = `id, user_id, name, is_public, created_at, updated_at`
+
+func NewPostgresPlaylistRepo(db *pgxpool.Pool, lg *zap.Logger) *PostgresPlaylistRepo {
+	lg.With(zap.String("component", "postgres_playlist_repo"))
+	return &PostgresPlaylistRepo{db: db, lg: lg}
+}
+
+func scanPlaylist(row pgx.Row, playlist *domain.Playlist) error {
+	return row.Scan(&playlist.ID, &playlist.UserID, &playlist.Name, &playlist.Public,
+		&playlist.CreatedAt, &playlist.UpdatedAt)
+}
+
+// playlistError translates driver errors into domain errors, anything not
+// recognised is reported as fallback.
+func playlistError(err error, fallback error) error {
+	switch {
+	case errors.Is(err, pgx.ErrNoRows), errors.Is(err, domain.ErrPlaylistNotFound):
+		return domain.ErrPlaylistNotFound
+	case errors.Is(err, domain.ErrPlaylistItemNotFound):
+		return domain.ErrPlaylistItemNotFound
+	case errors.Is(err, domain.ErrSongNotFound):
+		return domain.ErrSongNotFound
+	}
+
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) {
+		switch pgErr.Code {
+		case serializationFailure, deadlockDetected:
+			return domain.ErrConflict
+		}
+	}
+
+	return fallback
+}
+
+func (p *PostgresPlaylistRepo) Add(ctx context.Context, new *domain.Playlist) (domain.Playlist, error) {
+	p.lg.Info("add playlist", zap.Int64("user", new.UserID), zap.String("name", new.Name))
+
+	query := `insert into playlists(user_id, name, is_public) values ($1, $2, $3)
+	returning ` + playlistColumns
+
+	var playlist domain.Playlist
+	err := scanPlaylist(p.db.QueryRow(ctx, query, new.UserID, new.Name, new.Public), &playlist)
+	if err != nil {
+		p.lg.Warn("add playlist error", zap.Error(err))
+		return domain.Playlist{}, domain.ErrAddPlaylistDB
+	}
+
+	p.lg.Info("successful adding playlist")
+	return playlist, nil
+}
+
+func (p *PostgresPlaylistRepo) Update(ctx context.Context, id int64, upd *domain.Playlist) (domain.Playlist, error) {
+	p.lg.Info("update playlist", zap.Int64("id", id))
+
+	query := `update playlists set name=$2, is_public=$3, updated_at=now()
+	where id=$1 returning ` + playlistColumns
+
+	var playlist domain.Playlist
+	err := scanPlaylist(p.db.QueryRow(ctx, query, id, upd.Name, upd.Public), &playlist)
+	if err != nil {
+		p.lg.Warn("update playlist error", zap.Error(err))
+		return domain.Playlist{}, playlistError(err, domain.ErrUpdatePlaylistDB)
+	}
+
+	p.lg.Info("successful updating playlist")
+	return playlist, nil
+}
+
+func (p *PostgresPlaylistRepo) Delete(ctx context.Context, id int64) error {
+	p.lg.Info("delete playlist", zap.Int64("id", id))
+
+	tag, err := p.db.Exec(ctx, `delete from playlists where id=$1`, id)
+	if err != nil {
+		p.lg.Warn("delete playlist error", zap.Error(err))
+		return domain.ErrDeletePlaylistDB
+	}
+
+	if tag.RowsAffected() == 0 {
+		p.lg.Warn("delete playlist error", zap.Error(domain.ErrPlaylistNotFound))
+		return domain.ErrPlaylistNotFound
+	}
+
+	p.lg.Info("successful deleting playlist")
+	return nil
+}
+
+func (p *PostgresPlaylistRepo) Get(ctx context.Context, id int64) (domain.Playlist, error) {
+	p.lg.Info("get playlist", zap.Int64("id", id))
+
+	var playlist domain.Playlist
+	err := scanPlaylist(p.db.QueryRow(ctx, `select `+playlistColumns+` from playlists where id=$1`, id), &playlist)
+	if err != nil {
+		p.lg.Warn("get playlist error", zap.Error(err))
+		return domain.Playlist{}, playlistError(err, domain.ErrGetPlaylistDB)
+	}
+
+	// deleted songs leave their playlists, see removeFromPlaylists
+	query := `select s.*, i.id, i.position, i.added_at from playlist_items i
+	join lateral (select ` + songColumns + ` from songs
+		where songs.id = i.song_id and ` + songNotDeleted + `) s on true
+	where i.playlist_id=$1 order by i.position`
+
+	rows, err := p.db.Query(ctx, query, id)
+	if err != nil {
+		p.lg.Warn("get playlist items error", zap.Error(err))
+		return domain.Playlist{}, domain.ErrGetPlaylistDB
+	}
+	defer rows.Close()
+
+	playlist.Items = []domain.PlaylistItem{}
+	for rows.Next() {
+		var item domain.PlaylistItem
+		err = scanSong(rows, &item.Song, &item.ID, &item.Position, &item.AddedAt)
+		if err != nil {
+			p.lg.Warn("get playlist items error", zap.Error(err))
+			return domain.Playlist{}, domain.ErrGetPlaylistDB
+		}
+		item.SongID = item.Song.ID
+		playlist.Items = append(playlist.Items, item)
+	}
+
+	if rows.Err() != nil {
+		p.lg.Warn("get playlist items error", zap.Error(rows.Err()))
+		return domain.Playlist{}, domain.ErrGetPlaylistDB
+	}
+
+	p.lg.Info("successful getting playlist")
+	return playlist, nil
+}
+
+func (p *PostgresPlaylistRepo) GetByUser(ctx context.Context, userID int64) ([]domain.Playlist, error) {
+	p.lg.Info("get playlists", zap.Int64("user", userID))
+
+	query := `select ` + playlistColumns + ` from playlists where user_id=$1 order by id`
+
+	rows, err := p.db.Query(ctx, query, userID)
+	if err != nil {
+		p.lg.Warn("get playlists error", zap.Error(err))
+		return nil, domain.ErrGetPlaylistDB
+	}
+	defer rows.Close()
+
+	playlists := []domain.Playlist{}
+	for rows.Next() {
+		var playlist domain.Playlist
+		err = scanPlaylist(rows, &playlist)
+		if err != nil {
+			p.lg.Warn("get playlists error", zap.Error(err))
+			continue
+		}
+		playlists = append(playlists, playlist)
+	}
+
+	return playlists, nil
+}
+
+// lockPlaylist serialises changes of the items of a playlist and returns
+// how many there are.
+func lockPlaylist(ctx context.Context, tx pgx.Tx, id int64) (int, error) {
+	var locked int64
+	err := tx.QueryRow(ctx, `select id from playlists where id=$1 for update`, id).Scan(&locked)
+	if err != nil {
+		return 0, err
+	}
+
+	var count int
+	err = tx.QueryRow(ctx, `select count(*) from playlist_items where playlist_id=$1`, id).Scan(&count)
+	return count, err
+}
+
+func touchPlaylists(ctx context.Context, tx pgx.Tx, ids []int64) error {
+	_, err := tx.Exec(ctx, `update playlists set updated_at=now() where id = any($1)`, ids)
+	return err
+}
+
+func (p *PostgresPlaylistRepo) AddItem(ctx context.Context, id int64, songID int64, position int) error {
+	p.lg.Info("add playlist item", zap.Int64("id", id), zap.Int64("song", songID),
+		zap.Int("position", position))
+
+	err := pgx.BeginFunc(ctx, p.db, func(tx pgx.Tx) error {
+		count, err := lockPlaylist(ctx, tx, id)
+		if err != nil {
+			return err
+		}
+
+		if position <= 0 || position > count+1 {
+			position = count + 1
+		}
+
+		_, err = tx.Exec(ctx, `update playlist_items set position=position+1
+		where playlist_id=$1 and position>=$2`, id, position)
+		if err != nil {
+			return err
+		}
+
+		tag, err := tx.Exec(ctx, `insert into playlist_items(playlist_id, song_id, position)
+		select $1, id, $3 from songs where id=$2 and `+songNotDeleted, id, songID, position)
+		if err != nil {
+			return err
+		}
+		if tag.RowsAffected() == 0 {
+			return domain.ErrSongNotFound
+		}
+
+		return touchPlaylists(ctx, tx, []int64{id})
+	})
+	if err != nil {
+		p.lg.Warn("add playlist item error", zap.Error(err))
+		return playlistError(err, domain.ErrPlaylistItemDB)
+	}
+
+	p.lg.Info("successful adding playlist item")
+	return nil
+}
+
+func (p *PostgresPlaylistRepo) RemoveItem(ctx context.Context, id int64, itemID int64) error {
+	p.lg.Info("remove playlist item", zap.Int64("id", id), zap.Int64("item", itemID))
+
+	err := pgx.BeginFunc(ctx, p.db, func(tx pgx.Tx) error {
+		_, err := lockPlaylist(ctx, tx, id)
+		if err != nil {
+			return err
+		}
+
+		var position int
+		err = tx.QueryRow(ctx, `delete from playlist_items where id=$1 and playlist_id=$2
+		returning position`, itemID, id).Scan(&position)
+		if errors.Is(err, pgx.ErrNoRows) {
+			return domain.ErrPlaylistItemNotFound
+		}
+		if err != nil {
+			return err
+		}
+
+		_, err = tx.Exec(ctx, `update playlist_items set position=position-1
+		where playlist_id=$1 and position>$2`, id, position)
+		if err != nil {
+			return err
+		}
+
+		return touchPlaylists(ctx, tx, []int64{id})
+	})
+	if err != nil {
+		p.lg.Warn("remove playlist item error", zap.Error(err))
+		return playlistError(err, domain.ErrPlaylistItemDB)
+	}
+
+	p.lg.Info("successful removing playlist item")
+	return nil
+}
+
+func (p *PostgresPlaylistRepo) MoveItem(ctx context.Context, id int64, itemID int64, position int) error {
+	p.lg.Info("move playlist item", zap.Int64("id", id), zap.Int64("item", itemID),
+		zap.Int("position", position))
+
+	err := pgx.BeginFunc(ctx, p.db, func(tx pgx.Tx) error {
+		count, err := lockPlaylist(ctx, tx, id)
+		if err != nil {
+			return err
+		}
+
+		var from int
+		err = tx.QueryRow(ctx, `select position from playlist_items where id=$1 and playlist_id=$2`,
+			itemID, id).Scan(&from)
+		if errors.Is(err, pgx.ErrNoRows) {
+			return domain.ErrPlaylistItemNotFound
+		}
+		if err != nil {
+			return err
+		}
+
+		if position > count {
+			position = count
+		}
+		if position == from {
+			return nil
+		}
+
+		// the items between the old and the new position make room
+		query := `update playlist_items set position = case
+			when id=$2 then $4::integer
+			when $3::integer < $4::integer then position-1
+			else position+1
+		end
+		where playlist_id=$1 and position between least($3::integer, $4::integer)
+			and greatest($3::integer, $4::integer)`
+		_, err = tx.Exec(ctx, query, id, itemID, from, position)
+		if err != nil {
+			return err
+		}
+
+		return touchPlaylists(ctx, tx, []int64{id})
+	})
+	if err != nil {
+		p.lg.Warn("move playlist item error", zap.Error(err))
+		return playlistError(err, domain.ErrPlaylistItemDB)
+	}
+
+	p.lg.Info("successful moving playlist item")
+	return nil
+}
+
+// removeFromPlaylists takes a song that goes to the trash out of all
+// playlists and closes the gaps it leaves, in the transaction of the
+// delete.
+func removeFromPlaylists(ctx context.Context, tx pgx.Tx, songID int64) error {
+	rows, err := tx.Query(ctx, `delete from playlist_items where song_id=$1
+	returning playlist_id`, songID)
+	if err != nil {
+		return err
+	}
+
+	affected, err := pgx.CollectRows(rows, pgx.RowTo[int64])
+	if err != nil {
+		return err
+	}
+	if len(affected) == 0 {
+		return nil
+	}
+
+	query := `update playlist_items i set position = r.n
+	from (
+		select id, row_number() over (partition by playlist_id order by position) as n
+		from playlist_items where playlist_id = any($1)
+	) r
+	where i.id = r.id and i.position <> r.n`
+	_, err = tx.Exec(ctx, query, affected)
+	if err != nil {
+		return err
+	}
+
+	return touchPlaylists(ctx, tx, affected)
+}
@@ -0,0 +1,82 @@
+package repo
+
+import (
+	"context"
+	"fmt"
+	"github.com/NastyaAR/music_library/internal/domain"
+	"github.com/jackc/pgx/v5"
+	"go.uber.org/zap"
+)
+
+// exportBatch is how many rows are fetched from the cursor at a time.
+const exportBatch = 500
+
+// Export reads songs through a server-side cursor so that only one batch
+// is held in memory. The cursor lives in a read only repeatable read
+// transaction, which gives the whole export a single snapshot.
+func (p *PostgresSongRepo) Export(ctx context.Context, filter *domain.SongFilter, fn domain.ExportFunc) error {
+	p.lg.Info("export songs", zap.Any("filter", filter))
+
+	q := songQuery{}
+	q.addFilter(filter)
+
+	query := `declare export_songs no scroll cursor for select ` + songColumns +
+		` from songs` + q.whereClause() + q.orderClause(sortTerms(filter.Sort), false)
+
+	var fnErr error
+	count := 0
+	err := pgx.BeginTxFunc(ctx, p.db, pgx.TxOptions{
+		IsoLevel:   pgx.RepeatableRead,
+		AccessMode: pgx.ReadOnly,
+	}, func(tx pgx.Tx) error {
+		_, err := tx.Exec(ctx, query, q.values...)
+		if err != nil {
+			return err
+		}
+
+		for {
+			rows, err := tx.Query(ctx, fmt.Sprintf(`fetch forward %d from export_songs`, exportBatch))
+			if err != nil {
+				return err
+			}
+
+			fetched := 0
+			for rows.Next() {
+				fetched++
+
+				var song domain.Song
+				err = scanSong(rows, &song)
+				if err != nil {
+					rows.Close()
+					return err
+				}
+
+				fnErr = fn(song)
+				if fnErr != nil {
+					rows.Close()
+					return fnErr
+				}
+				count++
+			}
+			rows.Close()
+			if rows.Err() != nil {
+				return rows.Err()
+			}
+
+			if fetched < exportBatch {
+				return nil
+			}
+		}
+	})
+	if fnErr != nil {
+		p.lg.Warn("export stopped", zap.Int("exported", count), zap.Error(fnErr))
+		return fnErr
+	}
+	if err != nil {
+		p.lg.Warn("export error", zap.Int("exported", count), zap.Error(err))
+		return domain.ErrExportSongsDB
+	}
+
+	p.lg.Info("successful export", zap.Int("exported", count))
+	return nil
+}
@@ -0,0 +1,176 @@
+package repo
+
+import (
+	"fmt"
+	"github.com/NastyaAR/music_library/internal/domain"
+	"strings"
+)
+
+// songQuery collects where clauses, their arguments and order terms of
+// a songs listing. Only column names known to the repo are ever put into
+// the query text, user input always goes through arguments.
+type songQuery struct {
+	where  []string
+	values []interface{}
+	scores []string
+}
+
+func (q *songQuery) arg(value interface{}) string {
+	q.values = append(q.values, value)
+	return fmt.Sprintf("$%d", len(q.values))
+}
+
+func escapeLike(value string) string {
+	replacer := strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+	return replacer.Replace(value)
+}
+
+func (q *songQuery) addStringFilter(column string, filter domain.StringFilter, wordSimilarity bool) {
+	if filter.Value == "" {
+		return
+	}
+
+	switch filter.Match {
+	case domain.MatchPrefix:
+		q.where = append(q.where, fmt.Sprintf(`%s ilike %s`, column,
+			q.arg(escapeLike(filter.Value)+"%")))
+	case domain.MatchContains:
+		q.where = append(q.where, fmt.Sprintf(`%s ilike %s`, column,
+			q.arg("%"+escapeLike(filter.Value)+"%")))
+	case domain.MatchInsensitive:
+		q.where = append(q.where, fmt.Sprintf(`lower(%s)=lower(%s)`, column,
+			q.arg(filter.Value)))
+	case domain.MatchSimilar:
+		param := q.arg(filter.Value)
+		if wordSimilarity {
+			q.where = append(q.where, fmt.Sprintf(`%s <%% %s`, param, column))
+			q.scores = append(q.scores, fmt.Sprintf(`word_similarity(%s, %s)`, param, column))
+		} else {
+			q.where = append(q.where, fmt.Sprintf(`%s %% %s`, column, param))
+			q.scores = append(q.scores, fmt.Sprintf(`similarity(%s, %s)`, column, param))
+		}
+	default:
+		q.where = append(q.where, fmt.Sprintf(`%s=%s`, column, q.arg(filter.Value)))
+	}
+}
+
+func (q *songQuery) addFilter(filter *domain.SongFilter) {
+	q.addStringFilter("song_group", filter.Group, false)
+	q.addStringFilter("name", filter.Name, false)
+
+	if !filter.ReleaseDate.IsZero() {
+		q.where = append(q.where, `release_date=`+q.arg(filter.ReleaseDate))
+	}
+
+	if !filter.ReleasedFrom.IsZero() {
+		q.where = append(q.where, `release_date>=`+q.arg(filter.ReleasedFrom))
+	}
+
+	if !filter.ReleasedTo.IsZero() {
+		q.where = append(q.where, `release_date<=`+q.arg(filter.ReleasedTo))
+	}
+
+	q.addStringFilter("text", filter.Text, true)
+	q.addStringFilter("link", filter.Link, false)
+
+	if filter.AlbumID != 0 {
+		q.where = append(q.where, `album_id=`+q.arg(filter.AlbumID))
+	}
+}
+
+// whereClause always leaves out songs in the trash.
+func (q *songQuery) whereClause() string {
+	return ` where ` + strings.Join(append([]string{songNotDeleted}, q.where...), ` and `)
+}
+
+var sortColumns = map[domain.SortField]string{
+	domain.SortByID:          "id",
+	domain.SortByGroup:       "song_group",
+	domain.SortByName:        "name",
+	domain.SortByReleaseDate: "release_date",
+}
+
+type orderTerm struct {
+	column string
+	field  domain.SortField
+	desc   bool
+}
+
+// sortTerms maps the requested sort keys to columns and always ends with
+// id so that the ordering is total and pages are stable.
+func sortTerms(sort []domain.SortKey) []orderTerm {
+	terms := make([]orderTerm, 0, len(sort)+1)
+
+	byID := false
+	for _, key := range sort {
+		column, ok := sortColumns[key.Field]
+		if !ok {
+			continue
+		}
+
+		terms = append(terms, orderTerm{column: column, field: key.Field, desc: key.Desc})
+		byID = byID || key.Field == domain.SortByID
+	}
+
+	if !byID {
+		terms = append(terms, orderTerm{column: "id", field: domain.SortByID})
+	}
+
+	return terms
+}
+
+// orderClause puts the most similar songs first when any similarity
+// filter is used, then applies the sort terms. With reverse every
+// direction is flipped, which is how pages before a cursor are read.
+func (q *songQuery) orderClause(terms []orderTerm, reverse bool) string {
+	parts := make([]string, 0)
+	if len(q.scores) > 0 {
+		parts = append(parts, strings.Join(q.scores, ` + `)+` desc`)
+	}
+
+	for _, term := range terms {
+		if term.desc != reverse {
+			parts = append(parts, term.column+` desc`)
+		} else {
+			parts = append(parts, term.column)
+		}
+	}
+
+	return ` order by ` + strings.Join(parts, `, `)
+}
+
+func cursorValue(song *domain.Song, field domain.SortField) interface{} {
+	switch field {
+	case domain.SortByGroup:
+		return song.Group
+	case domain.SortByName:
+		return song.Name
+	case domain.SortByReleaseDate:
+		return song.ReleaseDate
+	default:
+		return song.ID
+	}
+}
+
+// addKeyset restricts rows to those strictly after (or before when
+// backward) the cursor boundary in the given order:
+// (a > x) or (a = x and b > y) or ...
+func (q *songQuery) addKeyset(terms []orderTerm, cursor *domain.SongCursor) {
+	alternatives := make([]string, 0, len(terms))
+	for i, term := range terms {
+		conds := make([]string, 0, i+1)
+		for _, prev := range terms[:i] {
+			conds = append(conds, prev.column+`=`+q.arg(cursorValue(&cursor.Boundary, prev.field)))
+		}
+
+		op := `>`
+		if term.desc != cursor.Backward {
+			op = `<`
+		}
+		conds = append(conds, term.column+op+q.arg(cursorValue(&cursor.Boundary, term.field)))
+
+		alternatives = append(alternatives, `(`+strings.Join(conds, ` and `)+`)`)
+	}
+
+	q.where = append(q.where, `(`+strings.Join(alternatives, ` or `)+`)`)
+}
@@ -0,0 +1,247 @@
+package repo
+
+import (
+	"context"
+	"errors"
+	"github.com/NastyaAR/music_library/internal/domain"
+	"github.com/jackc/pgx/v5"
+	"go.uber.org/zap"
+)
+
+// errImportAborted rolls back an import in fail mode.
+var errImportAborted = errors.New("import aborted")
+
+// import_songs row states, rows left without a state are inserted.
+const (
+	importExists       = "exists"
+	importDuplicateRow = "duplicate_row"
+	importMissingAlbum = "missing_album"
+	importTrackTaken   = "track_taken"
+	importCreated      = "created"
+	importUpdated      = "updated"
+	importSkipped      = "skipped"
+)
+
+var importErrors = map[string]string{
+	importExists:       "song already exists",
+	importDuplicateRow: "song repeats an earlier row",
+	importMissingAlbum: "album does not exist",
+	importTrackTaken:   "track number is taken on the album",
+}
+
+// importSteps resolve artists and mark the rows that cannot be inserted.
+var importSteps = []string{
+	`update import_songs i set artist_id=a.id, song_group=a.name
+	from artists a where a.name=i.song_group`,
+	`update import_songs i set artist_id=a.id, song_group=a.name
+	from artists a where i.artist_id is null and i.song_group = any(a.aliases)`,
+	`with created as (
+		insert into artists(name)
+		select distinct song_group from import_songs where artist_id is null
+		on conflict (name) do update set name=excluded.name
+		returning id, name)
+	update import_songs i set artist_id=c.id
+	from created c where i.artist_id is null and i.song_group=c.name`,
+	`update import_songs i set status='` + importMissingAlbum + `'
+	where album_id is not null and not exists (select 1 from albums a where a.id=i.album_id)`,
+	`update import_songs i set status='` + importDuplicateRow + `'
+	where status is null and exists (select 1 from import_songs j
+		where j.song_group=i.song_group and j.name=i.name and j.row_num < i.row_num)`,
+	`update import_songs i set song_id=s.id, status='` + importExists + `'
+	from songs s where i.status is null and s.song_group=i.song_group and s.name=i.name
+		and s.deleted_at is null`,
+	`update import_songs i set status='` + importTrackTaken + `'
+	from songs s where i.status is null and s.album_id=i.album_id
+		and s.track_number=i.track_number and s.deleted_at is null`,
+	`update import_songs i set status='` + importTrackTaken + `'
+	where status is null and exists (select 1 from import_songs j
+		where j.status is null and j.album_id=i.album_id
+			and j.track_number=i.track_number and j.row_num < i.row_num)`,
+}
+
+const importInsert = `with inserted as (
+		insert into songs(artist_id, song_group, name, release_date, text, link,
+			album_id, track_number, sections)
+		select artist_id, song_group, name, release_date, text, link,
+			album_id, track_number, sections
+		from import_songs where status is null order by row_num
+		returning id, song_group, name)
+	update import_songs i set song_id=ins.id, status='` + importCreated + `'
+	from inserted ins where i.status is null and i.song_group=ins.song_group and i.name=ins.name`
+
+const importOverwrite = `with updated as (
+		update songs s set artist_id=i.artist_id, song_group=i.song_group, name=i.name,
+			release_date=i.release_date, text=i.text, link=i.link, album_id=i.album_id,
+			track_number=i.track_number, sections=i.sections, version=s.version+1
+		from import_songs i where i.status='` + importExists + `' and s.id=i.song_id
+		returning s.id)
+	update import_songs i set status='` + importUpdated + `'
+	from updated u where i.song_id=u.id and i.status='` + importExists + `'`
+
+const importSkip = `update import_songs set status='` + importSkipped + `'
+	where status='` + importExists + `'`
+
+const importRevisions = `insert into song_revisions(song_id, revision, action, artist_id,
+		song_group, name, release_date, text, link, album_id, track_number)
+	select s.id, coalesce((select max(r.revision) from song_revisions r where r.song_id=s.id), 0) + 1,
+		case i.status when '` + importCreated + `' then '` + string(domain.RevisionCreate) + `'
+			else '` + string(domain.RevisionUpdate) + `' end,
+		s.artist_id, s.song_group, s.name, s.release_date, s.text, s.link, s.album_id, s.track_number
+	from import_songs i join songs s on s.id=i.song_id
+	where i.status in ('` + importCreated + `', '` + importUpdated + `')`
+
+func nullIfZero[T int | int64](value T) interface{} {
+	if value == 0 {
+		return nil
+	}
+	return value
+}
+
+// Import copies the rows into a temporary table and inserts them with a
+// few set-based statements in one transaction, so a large catalog costs
+// the same handful of round trips as a small one.
+func (p *PostgresSongRepo) Import(ctx context.Context, rows []domain.ImportRow,
+	mode domain.ImportMode) ([]domain.ImportRowResult, error) {
+	p.lg.Info("import songs", zap.Int("rows", len(rows)), zap.String("mode", string(mode)))
+
+	copyRows := make([][]interface{}, 0, len(rows))
+	for _, row := range rows {
+		sections, err := marshalSections(row.Song.Sections)
+		if err != nil {
+			p.lg.Warn("import error", zap.Error(err))
+			return nil, domain.ErrImportSongsDB
+		}
+
+		copyRows = append(copyRows, []interface{}{row.Row, row.Song.Group, row.Song.Name,
+			row.Song.ReleaseDate, row.Song.Text, row.Song.Link, nullIfZero(row.Song.AlbumID),
+			nullIfZero(row.Song.TrackNumber), sections})
+	}
+
+	var results []domain.ImportRowResult
+	err := pgx.BeginFunc(ctx, p.db, func(tx pgx.Tx) error {
+		_, err := tx.Exec(ctx, `create temp table import_songs (
+			row_num integer primary key,
+			artist_id bigint,
+			song_group text not null,
+			name text not null,
+			release_date date,
+			text text,
+			link text,
+			album_id bigint,
+			track_number integer,
+			sections jsonb,
+			song_id bigint,
+			status text
+		) on commit drop`)
+		if err != nil {
+			return err
+		}
+
+		_, err = tx.CopyFrom(ctx, pgx.Identifier{"import_songs"},
+			[]string{"row_num", "song_group", "name", "release_date", "text", "link",
+				"album_id", "track_number", "sections"}, pgx.CopyFromRows(copyRows))
+		if err != nil {
+			return err
+		}
+
+		for _, step := range importSteps {
+			_, err = tx.Exec(ctx, step)
+			if err != nil {
+				return err
+			}
+		}
+
+		if mode == domain.ImportFail {
+			var failed bool
+			err = tx.QueryRow(ctx, `select exists (select 1 from import_songs
+				where status is not null)`).Scan(&failed)
+			if err != nil {
+				return err
+			}
+
+			if failed {
+				results, err = importResults(ctx, tx)
+				if err != nil {
+					return err
+				}
+				return errImportAborted
+			}
+		}
+
+		_, err = tx.Exec(ctx, importInsert)
+		if err != nil {
+			return err
+		}
+
+		switch mode {
+		case domain.ImportOverwrite:
+			_, err = tx.Exec(ctx, importOverwrite)
+		case domain.ImportSkip:
+			_, err = tx.Exec(ctx, importSkip)
+		}
+		if err != nil {
+			return err
+		}
+
+		_, err = tx.Exec(ctx, importRevisions)
+		if err != nil {
+			return err
+		}
+
+		results, err = importResults(ctx, tx)
+		return err
+	})
+	if errors.Is(err, errImportAborted) {
+		p.lg.Info("import aborted")
+		return results, nil
+	}
+	if err != nil {
+		p.lg.Warn("import error", zap.Error(err))
+		return nil, songError(err, domain.ErrImportSongsDB)
+	}
+
+	p.lg.Info("successful import songs")
+	return results, nil
+}
+
+// importResults reads the row states, rows without a final state are
+// reported as skipped since an aborted import writes nothing.
+func importResults(ctx context.Context, tx pgx.Tx) ([]domain.ImportRowResult, error) {
+	rows, err := tx.Query(ctx, `select row_num, coalesce(song_id, 0), coalesce(status, '')
+	from import_songs order by row_num`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	results := []domain.ImportRowResult{}
+	for rows.Next() {
+		var result domain.ImportRowResult
+		var status string
+		err = rows.Scan(&result.Row, &result.SongID, &status)
+		if err != nil {
+			return nil, err
+		}
+
+		switch status {
+		case importCreated:
+			result.Status = domain.ImportCreated
+		case importUpdated:
+			result.Status = domain.ImportUpdated
+		case importSkipped, "":
+			result.Status = domain.ImportSkipped
+			if status == "" {
+				result.SongID = 0
+			}
+		default:
+			result.Status = domain.ImportFailed
+			result.Error = importErrors[status]
+			if status != importExists {
+				result.SongID = 0
+			}
+		}
+		results = append(results, result)
+	}
+
+	return results, rows.Err()
+}
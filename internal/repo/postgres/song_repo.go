@@ -2,12 +2,12 @@ package repo
 
 import (
 	"context"
-	"fmt"
+	"errors"
 	"github.com/NastyaAR/music_library/internal/domain"
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"go.uber.org/zap"
 	"strings"
-	"time"
 )
 
 type PostgresSongRepo struct {
@@ -15,61 +15,244 @@ type PostgresSongRepo struct {
 	lg *zap.Logger
 }
 
+const songColumns = `id, coalesce(artist_id, 0), song_group, name, release_date, text, link,
+	coalesce(album_id, 0), coalesce(track_number, 0), sections, version`
+
+// songGroupMatch matches a song group either by its canonical artist name
+// or by one of the artist aliases.
+const songGroupMatch = `(song_group=$1 or artist_id in
+	(select id from artists where $1 = any(aliases)))`
+
+const songNotDeleted = `deleted_at is null`
+
 func NewPostgresSongRepo(db *pgxpool.Pool, lg *zap.Logger) *PostgresSongRepo {
 	lg.With(zap.String("component", "postgres_song_repo"))
 	return &PostgresSongRepo{db: db, lg: lg}
 }
 
+// scanSong reads songColumns followed by the optional extra columns.
+func scanSong(row pgx.Row, song *domain.Song, extra ...interface{}) error {
+	var sections []byte
+	dest := []interface{}{&song.ID, &song.ArtistID, &song.Group, &song.Name,
+		&song.ReleaseDate, &song.Text, &song.Link, &song.AlbumID, &song.TrackNumber,
+		&sections, &song.Version}
+
+	err := row.Scan(append(dest, extra...)...)
+	if err != nil {
+		return err
+	}
+
+	song.Sections, err = unmarshalSections(sections)
+	return err
+}
+
+// resolveArtist finds the artist by name or alias and creates it when
+// the group is seen for the first time.
+func resolveArtist(ctx context.Context, tx pgx.Tx, group string) (int64, string, error) {
+	query := `select id, name from artists
+	where name=$1 or $1 = any(aliases)
+	order by name=$1 desc, id limit 1`
+
+	var id int64
+	var name string
+	err := tx.QueryRow(ctx, query, group).Scan(&id, &name)
+	if err == nil {
+		return id, name, nil
+	}
+	if !errors.Is(err, pgx.ErrNoRows) {
+		return 0, "", err
+	}
+
+	query = `insert into artists(name) values ($1)
+	on conflict (name) do update set name=excluded.name
+	returning id, name`
+	err = tx.QueryRow(ctx, query, group).Scan(&id, &name)
+	return id, name, err
+}
+
 func (p *PostgresSongRepo) Add(ctx context.Context, newSong *domain.Song) (domain.Song, error) {
 	p.lg.Info("add new song", zap.Any("song", *newSong))
 
-	query := `insert into songs(song_group, name, release_date, text, link)
-	values ($1, $2, $3, $4, $5) returning *`
+	query := `insert into songs(artist_id, song_group, name, release_date, text, link,
+		album_id, track_number, sections)
+	values ($1, $2, $3, $4, $5, $6, nullif($7, 0), nullif($8, 0), $9) returning ` + songColumns
 
-	var createdSong domain.Song
-	err := p.db.QueryRow(ctx, query, newSong.Group, newSong.Name,
-		newSong.ReleaseDate, newSong.Text, newSong.Link).Scan(&createdSong.Group, &createdSong.Name,
-		&createdSong.ReleaseDate, &createdSong.Text, &createdSong.Link)
+	sections, err := marshalSections(newSong.Sections)
 	if err != nil {
 		p.lg.Warn("add error", zap.Error(err))
 		return domain.Song{}, domain.ErrAddSongDB
 	}
 
+	var createdSong domain.Song
+	err = pgx.BeginFunc(ctx, p.db, func(tx pgx.Tx) error {
+		artistID, group, err := resolveArtist(ctx, tx, newSong.Group)
+		if err != nil {
+			return err
+		}
+
+		err = scanSong(tx.QueryRow(ctx, query, artistID, group, newSong.Name,
+			newSong.ReleaseDate, newSong.Text, newSong.Link, newSong.AlbumID,
+			newSong.TrackNumber, sections), &createdSong)
+		if err != nil {
+			return err
+		}
+
+		return recordRevision(ctx, tx, &createdSong, domain.RevisionCreate)
+	})
+	if err != nil {
+		p.lg.Warn("add error", zap.Error(err))
+		return domain.Song{}, songError(err, domain.ErrAddSongDB)
+	}
+
 	p.lg.Info("successful adding new song")
 	return createdSong, nil
 }
 
-func (p *PostgresSongRepo) Delete(ctx context.Context, group string, name string) error {
+// lockSong locks the live song selected by query and checks it still has
+// the expected version. Zero expected version skips the check.
+func lockSong(ctx context.Context, tx pgx.Tx, expected int, query string, args ...interface{}) (domain.Song, error) {
+	var song domain.Song
+	err := scanSong(tx.QueryRow(ctx, query+` for update`, args...), &song)
+	if err != nil {
+		return domain.Song{}, err
+	}
+
+	if expected != 0 && song.Version != expected {
+		return domain.Song{}, domain.ErrPreconditionFailed
+	}
+
+	return song, nil
+}
+
+const lockByGroupName = `select ` + songColumns + ` from songs
+	where ` + songGroupMatch + ` and name=$2 and ` + songNotDeleted
+
+const lockByID = `select ` + songColumns + ` from songs where id=$1 and ` + songNotDeleted
+
+// softDelete moves the locked song to the trash, takes it out of the
+// playlists and records its last state.
+func softDelete(ctx context.Context, tx pgx.Tx, id int64) error {
+	query := `update songs set deleted_at=now(), version=version+1
+	where id=$1 returning ` + songColumns
+
+	var song domain.Song
+	err := scanSong(tx.QueryRow(ctx, query, id), &song)
+	if err != nil {
+		return err
+	}
+
+	err = removeFromPlaylists(ctx, tx, id)
+	if err != nil {
+		return err
+	}
+
+	return recordRevision(ctx, tx, &song, domain.RevisionDelete)
+}
+
+func (p *PostgresSongRepo) Delete(ctx context.Context, group string, name string, version int) error {
 	p.lg.Info("delete song", zap.String("group", group),
 		zap.String("name", name))
 
-	query := `delete from songs where song_group=$1 and name=$2`
-	_, err := p.db.Exec(ctx, query, group, name)
+	err := pgx.BeginFunc(ctx, p.db, func(tx pgx.Tx) error {
+		song, err := lockSong(ctx, tx, version, lockByGroupName, group, name)
+		if err != nil {
+			return err
+		}
+
+		return softDelete(ctx, tx, song.ID)
+	})
 	if err != nil {
 		p.lg.Warn("delete error", zap.Error(err))
-		return domain.ErrDeleteSongDB
+		return songError(err, domain.ErrDeleteSongDB)
 	}
 
 	p.lg.Info("successful delete song")
 	return nil
 }
 
-func (p *PostgresSongRepo) Update(ctx context.Context, group string, name string, upd *domain.Song) (domain.Song, error) {
+// patchSong applies the patch to the locked song and writes only the
+// columns that actually change. A patch changing nothing leaves the song
+// and its version as they are.
+func patchSong(ctx context.Context, tx pgx.Tx, current domain.Song, patch *domain.SongPatch) (domain.Song, error) {
+	next := patch.Apply(current)
+	if next.TrackNumber > 0 && next.AlbumID == 0 {
+		return domain.Song{}, domain.ErrBadTrackNumber
+	}
+
+	q := songQuery{values: []interface{}{current.ID}}
+	var set []string
+
+	if next.Group != current.Group {
+		artistID, group, err := resolveArtist(ctx, tx, next.Group)
+		if err != nil {
+			return domain.Song{}, err
+		}
+		if artistID != current.ArtistID || group != current.Group {
+			set = append(set, `artist_id=`+q.arg(artistID), `song_group=`+q.arg(group))
+		}
+	}
+	if next.Name != current.Name {
+		set = append(set, `name=`+q.arg(next.Name))
+	}
+	if !next.ReleaseDate.Equal(current.ReleaseDate) {
+		set = append(set, `release_date=`+q.arg(next.ReleaseDate))
+	}
+	if next.Text != current.Text {
+		sections, err := marshalSections(next.Sections)
+		if err != nil {
+			return domain.Song{}, err
+		}
+		set = append(set, `text=`+q.arg(next.Text), `sections=`+q.arg(sections))
+	}
+	if next.Link != current.Link {
+		set = append(set, `link=`+q.arg(next.Link))
+	}
+	if next.AlbumID != current.AlbumID {
+		set = append(set, `album_id=nullif(`+q.arg(next.AlbumID)+`, 0)`)
+	}
+	if next.TrackNumber != current.TrackNumber {
+		set = append(set, `track_number=nullif(`+q.arg(next.TrackNumber)+`, 0)`)
+	}
+
+	if len(set) == 0 {
+		return current, nil
+	}
+
+	query := `update songs set ` + strings.Join(set, `, `) + `, version=version+1
+	where id=$1 returning ` + songColumns
+
+	var newSong domain.Song
+	err := scanSong(tx.QueryRow(ctx, query, q.values...), &newSong)
+	if err != nil {
+		return domain.Song{}, err
+	}
+
+	return newSong, recordRevision(ctx, tx, &newSong, domain.RevisionUpdate)
+}
+
+// Update applies the patch to the song, a non-zero patch.Version must
+// match the stored version.
+func (p *PostgresSongRepo) Update(ctx context.Context, group string, name string, patch *domain.SongPatch) (domain.Song, error) {
 	p.lg.Info("update song", zap.String("group", group),
 		zap.String("name", name))
 
-	query := `update songs set song_group=$1, name=$2, release_date=$3,
-                 text=$4, link=$5
-				where song_group=$6 and name=$7
-				returning *`
-
 	var newSong domain.Song
-	err := p.db.QueryRow(ctx, query, upd.Group, upd.Name,
-		upd.ReleaseDate, upd.Text, upd.Link, group, name).Scan(&newSong.Group, &newSong.Name,
-		&newSong.ReleaseDate, &newSong.Text, &newSong.Link)
+	err := pgx.BeginFunc(ctx, p.db, func(tx pgx.Tx) error {
+		current, err := lockSong(ctx, tx, patch.Version, lockByGroupName, group, name)
+		if err != nil {
+			return err
+		}
+
+		newSong, err = patchSong(ctx, tx, current, patch)
+		return err
+	})
+	if errors.Is(err, domain.ErrBadTrackNumber) {
+		p.lg.Warn("update error", zap.Error(err))
+		return domain.Song{}, domain.ErrBadTrackNumber
+	}
 	if err != nil {
 		p.lg.Warn("update error", zap.Error(err))
-		return domain.Song{}, domain.ErrAddSongDB
+		return domain.Song{}, songError(err, domain.ErrUpdateSongDB)
 	}
 
 	p.lg.Info("successful updating new song")
@@ -80,74 +263,94 @@ func (p *PostgresSongRepo) Get(ctx context.Context, group string, name string) (
 	p.lg.Info("get song", zap.String("group", group),
 		zap.String("name", name))
 
-	query := `select * from songs
-	where song_group=$1 and name=$2`
+	query := `select ` + songColumns + ` from songs
+	where ` + songGroupMatch + ` and name=$2 and ` + songNotDeleted
 
 	var newSong domain.Song
-	err := p.db.QueryRow(ctx, query, group, name).Scan(&newSong.Group, &newSong.Name,
-		&newSong.ReleaseDate, &newSong.Text, &newSong.Link)
+	err := scanSong(p.db.QueryRow(ctx, query, group, name), &newSong)
 	if err != nil {
 		p.lg.Warn("get error", zap.Error(err))
-		return domain.Song{}, domain.ErrAddSongDB
+		return domain.Song{}, songError(err, domain.ErrGetSongDB)
 	}
 
 	p.lg.Info("successful getting new song")
 	return newSong, nil
 }
 
-func getFilterParams(filter *domain.Song) ([]string, []interface{}) {
-	where := make([]string, 0)
-	values := make([]interface{}, 0)
-	cnt := 3
-	if filter.Group != "" {
-		where = append(where, fmt.Sprintf(`song_group=$%d`, cnt))
-		cnt += 1
-		values = append(values, filter.Group)
-	}
-	if filter.Name != "" {
-		where = append(where, fmt.Sprintf(`name=$%d`, cnt))
-		cnt += 1
-		values = append(values, filter.Name)
-	}
+func (p *PostgresSongRepo) DeleteByID(ctx context.Context, id int64, version int) error {
+	p.lg.Info("delete song by id", zap.Int64("id", id))
 
-	var nilTime time.Time
-	if filter.ReleaseDate != nilTime {
-		where = append(where, fmt.Sprintf(`release_date=$%d`, cnt))
-		cnt += 1
-		values = append(values, filter.ReleaseDate)
-	}
+	err := pgx.BeginFunc(ctx, p.db, func(tx pgx.Tx) error {
+		_, err := lockSong(ctx, tx, version, lockByID, id)
+		if err != nil {
+			return err
+		}
 
-	if filter.Text != "" {
-		where = append(where, fmt.Sprintf(`text=$%d`, cnt))
-		cnt += 1
-		values = append(values, filter.Text)
+		return softDelete(ctx, tx, id)
+	})
+	if err != nil {
+		p.lg.Warn("delete by id error", zap.Error(err))
+		return songError(err, domain.ErrDeleteSongDB)
 	}
 
-	if filter.Link != "" {
-		where = append(where, fmt.Sprintf(`link=$%d`, cnt))
-		cnt += 1
-		values = append(values, filter.Link)
+	p.lg.Info("successful delete song by id")
+	return nil
+}
+
+// UpdateByID applies the patch to the song, a non-zero patch.Version
+// must match the stored version.
+func (p *PostgresSongRepo) UpdateByID(ctx context.Context, id int64, patch *domain.SongPatch) (domain.Song, error) {
+	p.lg.Info("update song by id", zap.Int64("id", id))
+
+	var newSong domain.Song
+	err := pgx.BeginFunc(ctx, p.db, func(tx pgx.Tx) error {
+		current, err := lockSong(ctx, tx, patch.Version, lockByID, id)
+		if err != nil {
+			return err
+		}
+
+		newSong, err = patchSong(ctx, tx, current, patch)
+		return err
+	})
+	if errors.Is(err, domain.ErrBadTrackNumber) {
+		p.lg.Warn("update by id error", zap.Error(err))
+		return domain.Song{}, domain.ErrBadTrackNumber
+	}
+	if err != nil {
+		p.lg.Warn("update by id error", zap.Error(err))
+		return domain.Song{}, songError(err, domain.ErrUpdateSongDB)
 	}
 
-	return where, values
+	p.lg.Info("successful updating song by id")
+	return newSong, nil
 }
 
-func (p *PostgresSongRepo) GetAll(ctx context.Context, filter *domain.Song, limit int, offset int) ([]domain.Song, error) {
-	p.lg.Info("filter songs", zap.Any("filter", filter))
+func (p *PostgresSongRepo) GetByID(ctx context.Context, id int64) (domain.Song, error) {
+	p.lg.Info("get song by id", zap.Int64("id", id))
 
-	query := `select * from songs`
-	values := make([]interface{}, 0)
-	values = append(values, limit, offset)
-	where, critValues := getFilterParams(filter)
+	query := `select ` + songColumns + ` from songs where id=$1 and ` + songNotDeleted
 
-	if len(where) > 0 {
-		query += ` where ` + strings.Join(where, ` and `)
+	var song domain.Song
+	err := scanSong(p.db.QueryRow(ctx, query, id), &song)
+	if err != nil {
+		p.lg.Warn("get by id error", zap.Error(err))
+		return domain.Song{}, songError(err, domain.ErrGetSongDB)
 	}
 
-	values = append(values, critValues...)
-	query += ` limit $1 offset $2`
+	p.lg.Info("successful getting song by id")
+	return song, nil
+}
+
+func (p *PostgresSongRepo) GetAll(ctx context.Context, filter *domain.SongFilter, limit int, offset int) ([]domain.Song, error) {
+	p.lg.Info("filter songs", zap.Any("filter", filter))
 
-	rows, err := p.db.Query(ctx, query, values...)
+	q := songQuery{values: []interface{}{limit, offset}}
+	q.addFilter(filter)
+
+	query := `select ` + songColumns + ` from songs` + q.whereClause() +
+		q.orderClause(sortTerms(filter.Sort), false) + ` limit $1 offset $2`
+
+	rows, err := p.db.Query(ctx, query, q.values...)
 	defer rows.Close()
 	if err != nil {
 		p.lg.Warn("getall error", zap.Error(err))
@@ -157,8 +360,7 @@ func (p *PostgresSongRepo) GetAll(ctx context.Context, filter *domain.Song, limi
 	var song domain.Song
 	songs := []domain.Song{}
 	for rows.Next() {
-		err = rows.Scan(&song.Group, &song.Name, &song.ReleaseDate,
-			&song.Text, &song.Link)
+		err = scanSong(rows, &song)
 		if err != nil {
 			p.lg.Warn("getall error", zap.Error(err))
 			continue
@@ -168,3 +370,148 @@ func (p *PostgresSongRepo) GetAll(ctx context.Context, filter *domain.Song, limi
 
 	return songs, nil
 }
+
+func (p *PostgresSongRepo) GetByCursor(ctx context.Context, filter *domain.SongFilter,
+	cursor *domain.SongCursor, limit int) ([]domain.Song, error) {
+	p.lg.Info("filter songs by cursor", zap.Any("filter", filter),
+		zap.Any("cursor", cursor))
+
+	terms := sortTerms(filter.Sort)
+	q := songQuery{values: []interface{}{limit}}
+	q.addFilter(filter)
+
+	backward := false
+	if cursor != nil {
+		q.addKeyset(terms, cursor)
+		backward = cursor.Backward
+	}
+
+	query := `select ` + songColumns + ` from songs` + q.whereClause() +
+		q.orderClause(terms, backward) + ` limit $1`
+
+	rows, err := p.db.Query(ctx, query, q.values...)
+	if err != nil {
+		p.lg.Warn("get by cursor error", zap.Error(err))
+		return nil, domain.ErrGetAllSongsDB
+	}
+	defer rows.Close()
+
+	songs := []domain.Song{}
+	for rows.Next() {
+		var song domain.Song
+		err = scanSong(rows, &song)
+		if err != nil {
+			p.lg.Warn("get by cursor error", zap.Error(err))
+			continue
+		}
+		songs = append(songs, song)
+	}
+
+	if backward {
+		for i, j := 0, len(songs)-1; i < j; i, j = i+1, j-1 {
+			songs[i], songs[j] = songs[j], songs[i]
+		}
+	}
+
+	return songs, nil
+}
+
+func (p *PostgresSongRepo) Count(ctx context.Context, filter *domain.SongFilter) (int, error) {
+	p.lg.Info("count songs", zap.Any("filter", filter))
+
+	q := songQuery{}
+	q.addFilter(filter)
+
+	var total int
+	err := p.db.QueryRow(ctx, `select count(*) from songs`+q.whereClause(),
+		q.values...).Scan(&total)
+	if err != nil {
+		p.lg.Warn("count error", zap.Error(err))
+		return 0, domain.ErrCountSongsDB
+	}
+
+	return total, nil
+}
+
+type searchConfig struct {
+	column    string
+	regconfig string
+}
+
+// searchConfigs is the whitelist of generated tsvector columns; the
+// language never reaches the query text directly.
+var searchConfigs = map[domain.SearchLanguage]searchConfig{
+	domain.SearchEnglish: {column: "search_english", regconfig: "english"},
+	domain.SearchRussian: {column: "search_russian", regconfig: "russian"},
+}
+
+func (p *PostgresSongRepo) Search(ctx context.Context, query string, lang domain.SearchLanguage,
+	limit int, offset int) ([]domain.SongSearchResult, error) {
+	p.lg.Info("search songs", zap.String("query", query),
+		zap.String("lang", string(lang)))
+
+	cfg, ok := searchConfigs[lang]
+	if !ok {
+		p.lg.Warn("search error", zap.Error(domain.ErrBadSearchLanguage))
+		return nil, domain.ErrBadSearchLanguage
+	}
+
+	sqlQuery := `select ` + songColumns + `,
+		ts_rank(` + cfg.column + `, q) as rank,
+		ts_headline('` + cfg.regconfig + `', coalesce(text, ''), q,
+			'StartSel=<b>, StopSel=</b>, MaxFragments=2, MaxWords=20, MinWords=5') as snippet
+	from songs, websearch_to_tsquery('` + cfg.regconfig + `', $1) q
+	where ` + cfg.column + ` @@ q and ` + songNotDeleted + `
+	order by rank desc, id
+	limit $2 offset $3`
+
+	rows, err := p.db.Query(ctx, sqlQuery, query, limit, offset)
+	if err != nil {
+		p.lg.Warn("search error", zap.Error(err))
+		return nil, domain.ErrSearchSongsDB
+	}
+	defer rows.Close()
+
+	results := []domain.SongSearchResult{}
+	for rows.Next() {
+		var r domain.SongSearchResult
+		var rank float32
+		err = scanSong(rows, &r.Song, &rank, &r.Snippet)
+		if err != nil {
+			p.lg.Warn("search error", zap.Error(err))
+			continue
+		}
+		r.Rank = float64(rank)
+		results = append(results, r)
+	}
+
+	p.lg.Info("successful search songs")
+	return results, nil
+}
+
+func (p *PostgresSongRepo) GetByIDs(ctx context.Context, ids []int64) ([]domain.Song, error) {
+	p.lg.Info("get songs by ids", zap.Int("count", len(ids)))
+
+	query := `select ` + songColumns + ` from songs
+	where id=any($1) and ` + songNotDeleted + ` order by id`
+
+	rows, err := p.db.Query(ctx, query, ids)
+	if err != nil {
+		p.lg.Warn("get by ids error", zap.Error(err))
+		return nil, domain.ErrGetAllSongsDB
+	}
+	defer rows.Close()
+
+	songs := []domain.Song{}
+	for rows.Next() {
+		var song domain.Song
+		err = scanSong(rows, &song)
+		if err != nil {
+			p.lg.Warn("get by ids error", zap.Error(err))
+			continue
+		}
+		songs = append(songs, song)
+	}
+
+	return songs, nil
+}
@@ -0,0 +1,149 @@
+package repo
+
+import (
+	"context"
+	"errors"
+	"github.com/NastyaAR/music_library/internal/domain"
+	"github.com/jackc/pgx/v5"
+	"go.uber.org/zap"
+)
+
+const revisionColumns = `revision, action, created_at, song_id, coalesce(artist_id, 0),
+	song_group, name, release_date, text, link, coalesce(album_id, 0), coalesce(track_number, 0)`
+
+// recordRevision appends the song state to its history, it must run in
+// the transaction that changed the song.
+func recordRevision(ctx context.Context, tx pgx.Tx, song *domain.Song, action domain.RevisionAction) error {
+	query := `insert into song_revisions(song_id, revision, action, artist_id, song_group,
+		name, release_date, text, link, album_id, track_number)
+	select $1, coalesce(max(revision), 0) + 1, $2, nullif($3, 0), $4, $5, $6, $7, $8,
+		nullif($9, 0), nullif($10, 0)
+	from song_revisions where song_id=$1`
+
+	_, err := tx.Exec(ctx, query, song.ID, string(action), song.ArtistID, song.Group,
+		song.Name, song.ReleaseDate, song.Text, song.Link, song.AlbumID, song.TrackNumber)
+	return err
+}
+
+func scanRevision(row pgx.Row, rev *domain.SongRevision) error {
+	var action string
+	err := row.Scan(&rev.Revision, &action, &rev.CreatedAt, &rev.Song.ID, &rev.Song.ArtistID,
+		&rev.Song.Group, &rev.Song.Name, &rev.Song.ReleaseDate, &rev.Song.Text,
+		&rev.Song.Link, &rev.Song.AlbumID, &rev.Song.TrackNumber)
+	rev.Action = domain.RevisionAction(action)
+	return err
+}
+
+func (p *PostgresSongRepo) GetRevisions(ctx context.Context, id int64) ([]domain.SongRevision, error) {
+	p.lg.Info("get song revisions", zap.Int64("id", id))
+
+	query := `select ` + revisionColumns + ` from song_revisions
+	where song_id=$1 order by revision`
+
+	rows, err := p.db.Query(ctx, query, id)
+	if err != nil {
+		p.lg.Warn("get revisions error", zap.Error(err))
+		return nil, domain.ErrGetRevisionsDB
+	}
+	defer rows.Close()
+
+	revisions := []domain.SongRevision{}
+	for rows.Next() {
+		var rev domain.SongRevision
+		err = scanRevision(rows, &rev)
+		if err != nil {
+			p.lg.Warn("get revisions error", zap.Error(err))
+			return nil, domain.ErrGetRevisionsDB
+		}
+		revisions = append(revisions, rev)
+	}
+	if rows.Err() != nil {
+		p.lg.Warn("get revisions error", zap.Error(rows.Err()))
+		return nil, domain.ErrGetRevisionsDB
+	}
+
+	if len(revisions) > 0 {
+		return revisions, nil
+	}
+
+	// songs written before revisions were kept have none, unknown ids are
+	// told apart by the songs table
+	var exists bool
+	err = p.db.QueryRow(ctx, `select exists (select 1 from songs where id=$1)`, id).Scan(&exists)
+	if err != nil {
+		p.lg.Warn("get revisions error", zap.Error(err))
+		return nil, domain.ErrGetRevisionsDB
+	}
+	if !exists {
+		p.lg.Warn("get revisions error", zap.Error(domain.ErrSongNotFound))
+		return nil, domain.ErrSongNotFound
+	}
+
+	return revisions, nil
+}
+
+func (p *PostgresSongRepo) GetRevision(ctx context.Context, id int64, revision int) (domain.SongRevision, error) {
+	p.lg.Info("get song revision", zap.Int64("id", id), zap.Int("revision", revision))
+
+	query := `select ` + revisionColumns + ` from song_revisions
+	where song_id=$1 and revision=$2`
+
+	var rev domain.SongRevision
+	err := scanRevision(p.db.QueryRow(ctx, query, id, revision), &rev)
+	if errors.Is(err, pgx.ErrNoRows) {
+		p.lg.Warn("get revision error", zap.Error(err))
+		return domain.SongRevision{}, domain.ErrRevisionNotFound
+	}
+	if err != nil {
+		p.lg.Warn("get revision error", zap.Error(err))
+		return domain.SongRevision{}, domain.ErrGetRevisionsDB
+	}
+
+	return rev, nil
+}
+
+// Restore writes the song back with the given state, recreating it under
+// the same id when it has been deleted.
+func (p *PostgresSongRepo) Restore(ctx context.Context, restored *domain.Song) (domain.Song, error) {
+	p.lg.Info("restore song", zap.Int64("id", restored.ID))
+
+	query := `insert into songs(id, artist_id, song_group, name, release_date, text, link,
+		album_id, track_number, sections)
+	values ($1, $2, $3, $4, $5, $6, $7, nullif($8, 0), nullif($9, 0), $10)
+	on conflict (id) do update set artist_id=excluded.artist_id,
+		song_group=excluded.song_group, name=excluded.name,
+		release_date=excluded.release_date, text=excluded.text, link=excluded.link,
+		album_id=excluded.album_id, track_number=excluded.track_number,
+		sections=excluded.sections, deleted_at=null, version=songs.version+1
+	returning ` + songColumns
+
+	sections, err := marshalSections(restored.Sections)
+	if err != nil {
+		p.lg.Warn("restore error", zap.Error(err))
+		return domain.Song{}, domain.ErrRestoreSongDB
+	}
+
+	var song domain.Song
+	err = pgx.BeginFunc(ctx, p.db, func(tx pgx.Tx) error {
+		artistID, group, err := resolveArtist(ctx, tx, restored.Group)
+		if err != nil {
+			return err
+		}
+
+		err = scanSong(tx.QueryRow(ctx, query, restored.ID, artistID, group, restored.Name,
+			restored.ReleaseDate, restored.Text, restored.Link, restored.AlbumID,
+			restored.TrackNumber, sections), &song)
+		if err != nil {
+			return err
+		}
+
+		return recordRevision(ctx, tx, &song, domain.RevisionRestore)
+	})
+	if err != nil {
+		p.lg.Warn("restore error", zap.Error(err))
+		return domain.Song{}, songError(err, domain.ErrRestoreSongDB)
+	}
+
+	p.lg.Info("successful restoring song")
+	return song, nil
+}
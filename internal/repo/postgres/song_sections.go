@@ -0,0 +1,57 @@
+package repo
+
+import (
+	"encoding/json"
+	"github.com/NastyaAR/music_library/internal/domain"
+)
+
+type sectionRecord struct {
+	Type  string   `json:"type"`
+	Index int      `json:"index"`
+	Order int      `json:"order"`
+	Lines []string `json:"lines"`
+}
+
+// marshalSections returns nil for songs without parsed sections so that
+// the column stays null and the usecase parses the text on read.
+func marshalSections(sections []domain.LyricsSection) ([]byte, error) {
+	if sections == nil {
+		return nil, nil
+	}
+
+	records := make([]sectionRecord, 0, len(sections))
+	for _, s := range sections {
+		records = append(records, sectionRecord{
+			Type:  string(s.Type),
+			Index: s.Index,
+			Order: s.Order,
+			Lines: s.Lines,
+		})
+	}
+
+	return json.Marshal(records)
+}
+
+func unmarshalSections(data []byte) ([]domain.LyricsSection, error) {
+	if data == nil {
+		return nil, nil
+	}
+
+	var records []sectionRecord
+	err := json.Unmarshal(data, &records)
+	if err != nil {
+		return nil, err
+	}
+
+	sections := make([]domain.LyricsSection, 0, len(records))
+	for _, r := range records {
+		sections = append(sections, domain.LyricsSection{
+			Type:  domain.SectionType(r.Type),
+			Index: r.Index,
+			Order: r.Order,
+			Lines: r.Lines,
+		})
+	}
+
+	return sections, nil
+}
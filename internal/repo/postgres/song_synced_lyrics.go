@@ -0,0 +1,92 @@
+package repo
+
+import (
+	"context"
+	"github.com/NastyaAR/music_library/internal/domain"
+	"github.com/jackc/pgx/v5"
+	"go.uber.org/zap"
+)
+
+// SetSyncedLyrics replaces the synced lines of the song.
+func (p *PostgresSongRepo) SetSyncedLyrics(ctx context.Context, id int64, lines []domain.SyncedLine) error {
+	p.lg.Info("set synced lyrics", zap.Int64("id", id), zap.Int("lines", len(lines)))
+
+	err := pgx.BeginFunc(ctx, p.db, func(tx pgx.Tx) error {
+		_, err := tx.Exec(ctx, `delete from song_synced_lines where song_id=$1`, id)
+		if err != nil {
+			return err
+		}
+
+		rows := make([][]interface{}, 0, len(lines))
+		for i, line := range lines {
+			rows = append(rows, []interface{}{id, i, line.TimeMs, line.Text})
+		}
+
+		_, err = tx.CopyFrom(ctx, pgx.Identifier{"song_synced_lines"},
+			[]string{"song_id", "position", "time_ms", "line"}, pgx.CopyFromRows(rows))
+		return err
+	})
+	if err != nil {
+		p.lg.Warn("set synced lyrics error", zap.Error(err))
+		return domain.ErrSetSyncedLyricsDB
+	}
+
+	p.lg.Info("successful setting synced lyrics")
+	return nil
+}
+
+func (p *PostgresSongRepo) GetSyncedLyrics(ctx context.Context, id int64) ([]domain.SyncedLine, error) {
+	p.lg.Info("get synced lyrics", zap.Int64("id", id))
+
+	query := `select time_ms, line from song_synced_lines
+	where song_id=$1 order by position`
+
+	rows, err := p.db.Query(ctx, query, id)
+	if err != nil {
+		p.lg.Warn("get synced lyrics error", zap.Error(err))
+		return nil, domain.ErrGetSyncedLyricsDB
+	}
+	defer rows.Close()
+
+	lines := []domain.SyncedLine{}
+	for rows.Next() {
+		var line domain.SyncedLine
+		err = rows.Scan(&line.TimeMs, &line.Text)
+		if err != nil {
+			p.lg.Warn("get synced lyrics error", zap.Error(err))
+			return nil, domain.ErrGetSyncedLyricsDB
+		}
+		lines = append(lines, line)
+	}
+
+	return lines, nil
+}
+
+// GetSyncedLyricsByIDs leaves songs without synced lyrics out of the map.
+func (p *PostgresSongRepo) GetSyncedLyricsByIDs(ctx context.Context, ids []int64) (map[int64][]domain.SyncedLine, error) {
+	p.lg.Info("get synced lyrics by ids", zap.Int("count", len(ids)))
+
+	query := `select song_id, time_ms, line from song_synced_lines
+	where song_id=any($1) order by song_id, position`
+
+	rows, err := p.db.Query(ctx, query, ids)
+	if err != nil {
+		p.lg.Warn("get synced lyrics by ids error", zap.Error(err))
+		return nil, domain.ErrGetSyncedLyricsDB
+	}
+	defer rows.Close()
+
+	lines := make(map[int64][]domain.SyncedLine)
+	for rows.Next() {
+		var id int64
+		var line domain.SyncedLine
+		err = rows.Scan(&id, &line.TimeMs, &line.Text)
+		if err != nil {
+			p.lg.Warn("get synced lyrics by ids error", zap.Error(err))
+			return nil, domain.ErrGetSyncedLyricsDB
+		}
+		lines[id] = append(lines[id], line)
+	}
+
+	return lines, nil
+}
@@ -0,0 +1,77 @@
+package repo
+
+import (
+	"context"
+	"github.com/NastyaAR/music_library/internal/domain"
+	"github.com/jackc/pgx/v5"
+	"go.uber.org/zap"
+	"time"
+)
+
+func (p *PostgresSongRepo) GetDeleted(ctx context.Context, limit int, offset int) ([]domain.TrashedSong, error) {
+	p.lg.Info("get deleted songs")
+
+	query := `select ` + songColumns + `, deleted_at from songs
+	where deleted_at is not null
+	order by deleted_at desc, id limit $1 offset $2`
+
+	rows, err := p.db.Query(ctx, query, limit, offset)
+	if err != nil {
+		p.lg.Warn("get deleted error", zap.Error(err))
+		return nil, domain.ErrGetTrashDB
+	}
+	defer rows.Close()
+
+	songs := []domain.TrashedSong{}
+	for rows.Next() {
+		var trashed domain.TrashedSong
+		err = scanSong(rows, &trashed.Song, &trashed.DeletedAt)
+		if err != nil {
+			p.lg.Warn("get deleted error", zap.Error(err))
+			continue
+		}
+		songs = append(songs, trashed)
+	}
+
+	return songs, nil
+}
+
+func (p *PostgresSongRepo) RestoreDeleted(ctx context.Context, id int64) (domain.Song, error) {
+	p.lg.Info("restore deleted song", zap.Int64("id", id))
+
+	query := `update songs set deleted_at=null, version=version+1
+	where id=$1 and deleted_at is not null
+	returning ` + songColumns
+
+	var song domain.Song
+	err := pgx.BeginFunc(ctx, p.db, func(tx pgx.Tx) error {
+		err := scanSong(tx.QueryRow(ctx, query, id), &song)
+		if err != nil {
+			return err
+		}
+
+		return recordRevision(ctx, tx, &song, domain.RevisionRestore)
+	})
+	if err != nil {
+		p.lg.Warn("restore deleted error", zap.Error(err))
+		return domain.Song{}, songError(err, domain.ErrRestoreTrashDB)
+	}
+
+	p.lg.Info("successful restoring deleted song")
+	return song, nil
+}
+
+// PurgeDeleted removes songs deleted before the given moment for good,
+// their revisions are kept.
+func (p *PostgresSongRepo) PurgeDeleted(ctx context.Context, before time.Time) (int64, error) {
+	p.lg.Info("purge deleted songs", zap.Time("before", before))
+
+	tag, err := p.db.Exec(ctx, `delete from songs where deleted_at < $1`, before)
+	if err != nil {
+		p.lg.Warn("purge deleted error", zap.Error(err))
+		return 0, domain.ErrPurgeTrashDB
+	}
+
+	p.lg.Info("successful purging deleted songs", zap.Int64("purged", tag.RowsAffected()))
+	return tag.RowsAffected(), nil
+}
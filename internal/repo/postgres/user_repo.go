@@ -0,0 +1,220 @@
+package repo
+
+import (
+	"context"
+	"errors"
+	"github.com/NastyaAR/music_library/internal/domain"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"go.uber.org/zap"
+	"time"
+)
+
+type PostgresUserRepo struct {
+	db *pgxpool.Pool
+	lg *zap.Logger
+}
+
+const userColumns = `id, username, password_hash, role, created_at`
+
+func NewPostgresUserRepo(db *pgxpool.Pool, lg *zap.Logger) *PostgresUserRepo {
+	lg.With(zap.String("component", "postgres_user_repo"))
+	return &PostgresUserRepo{db: db, lg: lg}
+}
+
+func scanUser(row pgx.Row, user *domain.User) error {
+	return row.Scan(&user.ID, &user.Username, &user.PasswordHash, &user.Role, &user.CreatedAt)
+}
+
+func userError(err error, fallback error) error {
+	if errors.Is(err, pgx.ErrNoRows) {
+		return domain.ErrUserNotFound
+	}
+
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == uniqueViolation {
+		return domain.ErrUserExists
+	}
+
+	return fallback
+}
+
+func (p *PostgresUserRepo) Add(ctx context.Context, user *domain.User) (domain.User, error) {
+	p.lg.Info("add user", zap.String("username", user.Username))
+
+	query := `insert into users(username, password_hash, role) values ($1, $2, $3)
+	returning ` + userColumns
+
+	var created domain.User
+	err := scanUser(p.db.QueryRow(ctx, query, user.Username, user.PasswordHash, user.Role), &created)
+	if err != nil {
+		p.lg.Warn("add user error", zap.Error(err))
+		return domain.User{}, userError(err, domain.ErrAddUserDB)
+	}
+
+	p.lg.Info("successful adding user")
+	return created, nil
+}
+
+func (p *PostgresUserRepo) GetByUsername(ctx context.Context, username string) (domain.User, error) {
+	p.lg.Info("get user by username", zap.String("username", username))
+
+	query := `select ` + userColumns + ` from users where username=$1`
+
+	var user domain.User
+	err := scanUser(p.db.QueryRow(ctx, query, username), &user)
+	if err != nil {
+		p.lg.Warn("get user by username error", zap.Error(err))
+		return domain.User{}, userError(err, domain.ErrGetUserDB)
+	}
+
+	return user, nil
+}
+
+func (p *PostgresUserRepo) GetByID(ctx context.Context, id int64) (domain.User, error) {
+	p.lg.Info("get user", zap.Int64("id", id))
+
+	query := `select ` + userColumns + ` from users where id=$1`
+
+	var user domain.User
+	err := scanUser(p.db.QueryRow(ctx, query, id), &user)
+	if err != nil {
+		p.lg.Warn("get user error", zap.Error(err))
+		return domain.User{}, userError(err, domain.ErrGetUserDB)
+	}
+
+	return user, nil
+}
+
+func (p *PostgresUserRepo) GetAll(ctx context.Context, limit int, offset int) ([]domain.User, error) {
+	p.lg.Info("get users", zap.Int("limit", limit), zap.Int("offset", offset))
+
+	query := `select ` + userColumns + ` from users
+	order by id limit $1 offset $2`
+
+	rows, err := p.db.Query(ctx, query, limit, offset)
+	if err != nil {
+		p.lg.Warn("get users error", zap.Error(err))
+		return nil, domain.ErrGetAllUsersDB
+	}
+	defer rows.Close()
+
+	users := []domain.User{}
+	for rows.Next() {
+		var user domain.User
+		err = scanUser(rows, &user)
+		if err != nil {
+			p.lg.Warn("get users error", zap.Error(err))
+			continue
+		}
+		users = append(users, user)
+	}
+
+	return users, nil
+}
+
+func (p *PostgresUserRepo) SetRole(ctx context.Context, id int64, role domain.Role) (domain.User, error) {
+	p.lg.Info("set role", zap.Int64("id", id), zap.String("role", string(role)))
+
+	query := `update users set role=$2 where id=$1 returning ` + userColumns
+
+	var user domain.User
+	err := scanUser(p.db.QueryRow(ctx, query, id, role), &user)
+	if err != nil {
+		p.lg.Warn("set role error", zap.Error(err))
+		return domain.User{}, userError(err, domain.ErrSetRoleDB)
+	}
+
+	p.lg.Info("successful setting role")
+	return user, nil
+}
+
+func (p *PostgresUserRepo) SeedAdmin(ctx context.Context, user *domain.User) (domain.User, error) {
+	p.lg.Info("seed admin", zap.String("username", user.Username))
+
+	var seeded domain.User
+	err := pgx.BeginFunc(ctx, p.db, func(tx pgx.Tx) error {
+		var current domain.User
+		err := scanUser(tx.QueryRow(ctx, `select `+userColumns+` from users where username=$1 for update`,
+			user.Username), &current)
+		if errors.Is(err, pgx.ErrNoRows) {
+			// another instance may be seeding at the same time
+			query := `insert into users(username, password_hash, role) values ($1, $2, $3)
+			on conflict (username) do update set password_hash=excluded.password_hash, role=excluded.role
+			returning ` + userColumns
+			return scanUser(tx.QueryRow(ctx, query, user.Username, user.PasswordHash, user.Role), &seeded)
+		}
+		if err != nil {
+			return err
+		}
+
+		if current.PasswordHash != user.PasswordHash {
+			// whoever knew the old password must not keep a session
+			_, err = tx.Exec(ctx, `update refresh_tokens set revoked_at=now()
+			where user_id=$1 and revoked_at is null`, current.ID)
+			if err != nil {
+				return err
+			}
+		}
+
+		query := `update users set password_hash=$2, role=$3 where id=$1 returning ` + userColumns
+		return scanUser(tx.QueryRow(ctx, query, current.ID, user.PasswordHash, user.Role), &seeded)
+	})
+	if err != nil {
+		p.lg.Warn("seed admin error", zap.Error(err))
+		return domain.User{}, domain.ErrSeedAdminDB
+	}
+
+	p.lg.Info("successful seeding admin")
+	return seeded, nil
+}
+
+func (p *PostgresUserRepo) AddRefreshToken(ctx context.Context, userID int64, tokenHash string, expiresAt time.Time) error {
+	p.lg.Info("add refresh token", zap.Int64("user", userID))
+
+	query := `insert into refresh_tokens(user_id, token_hash, expires_at) values ($1, $2, $3)`
+
+	_, err := p.db.Exec(ctx, query, userID, tokenHash, expiresAt)
+	if err != nil {
+		p.lg.Warn("add refresh token error", zap.Error(err))
+		return domain.ErrRefreshTokenDB
+	}
+
+	return nil
+}
+
+func (p *PostgresUserRepo) UseRefreshToken(ctx context.Context, tokenHash string) (int64, error) {
+	p.lg.Info("use refresh token")
+
+	query := `update refresh_tokens set revoked_at=now()
+	where token_hash=$1 and revoked_at is null and expires_at>now()
+	returning user_id`
+
+	var userID int64
+	err := p.db.QueryRow(ctx, query, tokenHash).Scan(&userID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return 0, domain.ErrInvalidToken
+	}
+	if err != nil {
+		p.lg.Warn("use refresh token error", zap.Error(err))
+		return 0, domain.ErrRefreshTokenDB
+	}
+
+	return userID, nil
+}
+
+func (p *PostgresUserRepo) RevokeRefreshToken(ctx context.Context, tokenHash string) error {
+	p.lg.Info("revoke refresh token")
+
+	query := `update refresh_tokens set revoked_at=now()
+	where token_hash=$1 and revoked_at is null`
+
+	_, err := p.db.Exec(ctx, query, tokenHash)
+	if err != nil {
+		p.lg.Warn("revoke refresh token error", zap.Error(err))
+		return domain.ErrRefreshTokenDB
+	}
+
+	return nil
+}
@@ -0,0 +1,232 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"github.com/NastyaAR/music_library/internal/domain"
+	"github.com/go-playground/validator/v10"
+	"go.uber.org/zap"
+	"time"
+)
+
+type AlbumUsecase struct {
+	albumRepo domain.AlbumRepo
+	validate  *validator.Validate
+	lg        *zap.Logger
+	dbTimeout time.Duration
+}
+
+func NewAlbumUsecase(albumRepo domain.AlbumRepo, valid *validator.Validate, lg *zap.Logger) *AlbumUsecase {
+	lg.With(zap.String("component", "album usecase"))
+	return &AlbumUsecase{
+		albumRepo: albumRepo,
+		validate:  valid,
+		lg:        lg,
+		dbTimeout: time.Hour,
+	}
+}
+
+func (a *AlbumUsecase) validateAlbum(album *domain.Album) error {
+	if album == nil {
+		a.lg.Warn("album error: nil request",
+			zap.Error(domain.ErrNilAlbumRequest))
+		return domain.ErrNilAlbumRequest
+	}
+
+	err := a.validate.Var(album.Title, "required")
+	if err != nil {
+		a.lg.Warn("album error: bad title",
+			zap.Error(domain.ErrBadAlbumTitle))
+		return domain.ErrBadAlbumTitle
+	}
+
+	if album.ArtistID <= 0 {
+		a.lg.Warn("album error: bad artist id",
+			zap.Error(domain.ErrBadArtistID))
+		return domain.ErrBadArtistID
+	}
+
+	return nil
+}
+
+func (a *AlbumUsecase) Create(ctx context.Context, createReq *domain.Album) (domain.Album, error) {
+	a.lg.Info("create album", zap.Any("request", createReq))
+
+	if err := authorize(ctx, domain.PermEditAlbums); err != nil {
+		a.lg.Warn("create album error: forbidden", zap.Error(err))
+		return domain.Album{}, err
+	}
+
+	err := a.validateAlbum(createReq)
+	if err != nil {
+		return domain.Album{}, err
+	}
+
+	dbCtx, cancel := context.WithTimeout(ctx, a.dbTimeout)
+	defer cancel()
+
+	created, err := a.albumRepo.Add(dbCtx, createReq)
+	if err != nil {
+		a.lg.Warn("create album error", zap.Error(err))
+		return domain.Album{}, fmt.Errorf("create album error: %w", err)
+	}
+
+	a.lg.Info("successful create album")
+	return created, nil
+}
+
+func (a *AlbumUsecase) Delete(ctx context.Context, id int64) error {
+	a.lg.Info("delete album", zap.Int64("id", id))
+
+	if err := authorize(ctx, domain.PermDeleteAlbums); err != nil {
+		a.lg.Warn("delete album error: forbidden", zap.Error(err))
+		return err
+	}
+
+	if id <= 0 {
+		a.lg.Warn("delete album error: bad id",
+			zap.Error(domain.ErrBadID))
+		return domain.ErrBadID
+	}
+
+	dbCtx, cancel := context.WithTimeout(ctx, a.dbTimeout)
+	defer cancel()
+
+	err := a.albumRepo.Delete(dbCtx, id)
+	if err != nil {
+		a.lg.Warn("delete album error", zap.Error(err))
+		return fmt.Errorf("delete album error: %w", err)
+	}
+
+	a.lg.Info("successful delete album")
+	return nil
+}
+
+func (a *AlbumUsecase) Update(ctx context.Context, id int64, updReq *domain.Album) (domain.Album, error) {
+	a.lg.Info("update album", zap.Int64("id", id))
+
+	if err := authorize(ctx, domain.PermEditAlbums); err != nil {
+		a.lg.Warn("update album error: forbidden", zap.Error(err))
+		return domain.Album{}, err
+	}
+
+	if id <= 0 {
+		a.lg.Warn("update album error: bad id",
+			zap.Error(domain.ErrBadID))
+		return domain.Album{}, domain.ErrBadID
+	}
+
+	err := a.validateAlbum(updReq)
+	if err != nil {
+		return domain.Album{}, err
+	}
+
+	dbCtx, cancel := context.WithTimeout(ctx, a.dbTimeout)
+	defer cancel()
+
+	updated, err := a.albumRepo.Update(dbCtx, id, updReq)
+	if err != nil {
+		a.lg.Warn("update album error", zap.Error(err))
+		return domain.Album{}, fmt.Errorf("update album error: %w", err)
+	}
+
+	a.lg.Info("successful update album")
+	return updated, nil
+}
+
+func (a *AlbumUsecase) Get(ctx context.Context, id int64) (domain.Album, error) {
+	a.lg.Info("get album", zap.Int64("id", id))
+
+	if id <= 0 {
+		a.lg.Warn("get album error: bad id",
+			zap.Error(domain.ErrBadID))
+		return domain.Album{}, domain.ErrBadID
+	}
+
+	dbCtx, cancel := context.WithTimeout(ctx, a.dbTimeout)
+	defer cancel()
+
+	album, err := a.albumRepo.Get(dbCtx, id)
+	if err != nil {
+		a.lg.Warn("get album error", zap.Error(err))
+		return domain.Album{}, fmt.Errorf("get album error: %w", err)
+	}
+
+	a.lg.Info("successful get album")
+	return album, nil
+}
+
+func (a *AlbumUsecase) GetAll(ctx context.Context, limit int, offset int) ([]domain.Album, error) {
+	a.lg.Info("get albums", zap.Int("limit", limit), zap.Int("offset", offset))
+
+	if limit <= 0 {
+		a.lg.Warn("get albums error: bad limit",
+			zap.Error(domain.ErrBadLimit))
+		return nil, domain.ErrBadLimit
+	}
+
+	if offset < 1 {
+		a.lg.Warn("get albums error: bad offset",
+			zap.Error(domain.ErrBadOffset))
+		return nil, domain.ErrBadOffset
+	}
+
+	dbCtx, cancel := context.WithTimeout(ctx, a.dbTimeout)
+	defer cancel()
+
+	albums, err := a.albumRepo.GetAll(dbCtx, limit, (offset-1)*limit)
+	if err != nil {
+		a.lg.Warn("get albums error", zap.Error(err))
+		return nil, fmt.Errorf("get albums error: %w", err)
+	}
+
+	a.lg.Info("successful get albums")
+	return albums, nil
+}
+
+func (a *AlbumUsecase) GetTracks(ctx context.Context, id int64) ([]domain.Song, error) {
+	a.lg.Info("get album tracks", zap.Int64("id", id))
+
+	if id <= 0 {
+		a.lg.Warn("get album tracks error: bad id",
+			zap.Error(domain.ErrBadID))
+		return nil, domain.ErrBadID
+	}
+
+	dbCtx, cancel := context.WithTimeout(ctx, a.dbTimeout)
+	defer cancel()
+
+	tracks, err := a.albumRepo.GetTracks(dbCtx, id)
+	if err != nil {
+		a.lg.Warn("get album tracks error", zap.Error(err))
+		return nil, fmt.Errorf("get album tracks error: %w", err)
+	}
+
+	a.lg.Info("successful get album tracks")
+	return tracks, nil
+}
+
+func (a *AlbumUsecase) GetByIDs(ctx context.Context, ids []int64) ([]domain.Album, error) {
+	a.lg.Info("get albums by ids", zap.Int("count", len(ids)))
+
+	ids, err := validateIDs(ids)
+	if err != nil {
+		a.lg.Warn("get albums by ids error: bad ids", zap.Error(err))
+		return nil, err
+	}
+	if len(ids) == 0 {
+		return []domain.Album{}, nil
+	}
+
+	dbCtx, cancel := context.WithTimeout(ctx, a.dbTimeout)
+	defer cancel()
+
+	albums, err := a.albumRepo.GetByIDs(dbCtx, ids)
+	if err != nil {
+		a.lg.Warn("get albums by ids error", zap.Error(err))
+		return nil, fmt.Errorf("get albums by ids error: %w", err)
+	}
+
+	a.lg.Info("successful get albums by ids")
+	return albums, nil
+}
@@ -0,0 +1,219 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"github.com/NastyaAR/music_library/internal/domain"
+	"github.com/NastyaAR/music_library/internal/pkg/token"
+	"go.uber.org/zap"
+	"strings"
+	"time"
+)
+
+const (
+	apiKeyPrefix     = "mlk_"
+	apiKeyShownChars = 12
+	maxKeyNameLen    = 64
+)
+
+type APIKeyUsecase struct {
+	apiKeyRepo       domain.APIKeyRepo
+	defaultRateLimit int
+	maxRateLimit     int
+	lg               *zap.Logger
+	dbTimeout        time.Duration
+}
+
+// NewAPIKeyUsecase: rate limits are requests per minute, keys created
+// without one get defaultRateLimit.
+func NewAPIKeyUsecase(apiKeyRepo domain.APIKeyRepo, defaultRateLimit int, maxRateLimit int,
+	lg *zap.Logger) *APIKeyUsecase {
+	lg.With(zap.String("component", "api key usecase"))
+	return &APIKeyUsecase{
+		apiKeyRepo:       apiKeyRepo,
+		defaultRateLimit: defaultRateLimit,
+		maxRateLimit:     maxRateLimit,
+		lg:               lg,
+		dbTimeout:        time.Hour,
+	}
+}
+
+// keyOwner is the signed in user, api keys can not manage keys.
+func keyOwner(ctx context.Context) (domain.Identity, error) {
+	identity, ok := domain.IdentityFrom(ctx)
+	if !ok {
+		return domain.Identity{}, domain.ErrUnauthorized
+	}
+
+	if identity.APIKeyID != 0 {
+		return domain.Identity{}, domain.ErrForbidden
+	}
+
+	return identity, nil
+}
+
+func validateScopes(scopes []domain.Scope) ([]domain.Scope, error) {
+	if len(scopes) == 0 {
+		return nil, domain.ErrBadScope
+	}
+
+	seen := make(map[domain.Scope]bool, len(scopes))
+	unique := make([]domain.Scope, 0, len(scopes))
+	for _, scope := range scopes {
+		if !scope.IsValid() {
+			return nil, domain.ErrBadScope
+		}
+		if !seen[scope] {
+			seen[scope] = true
+			unique = append(unique, scope)
+		}
+	}
+
+	return unique, nil
+}
+
+func (a *APIKeyUsecase) Create(ctx context.Context, name string, scopes []domain.Scope,
+	rateLimit int) (domain.APIKey, string, error) {
+	a.lg.Info("create api key", zap.String("name", name))
+
+	owner, err := keyOwner(ctx)
+	if err != nil {
+		a.lg.Warn("create api key error: forbidden", zap.Error(err))
+		return domain.APIKey{}, "", err
+	}
+
+	name = strings.TrimSpace(name)
+	if name == "" || len(name) > maxKeyNameLen {
+		a.lg.Warn("create api key error: bad name", zap.Error(domain.ErrBadKeyName))
+		return domain.APIKey{}, "", domain.ErrBadKeyName
+	}
+
+	scopes, err = validateScopes(scopes)
+	if err != nil {
+		a.lg.Warn("create api key error: bad scopes", zap.Error(err))
+		return domain.APIKey{}, "", err
+	}
+
+	if rateLimit == 0 {
+		rateLimit = a.defaultRateLimit
+	}
+	if rateLimit < 0 || rateLimit > a.maxRateLimit {
+		a.lg.Warn("create api key error: bad rate limit", zap.Error(domain.ErrBadRateLimit))
+		return domain.APIKey{}, "", domain.ErrBadRateLimit
+	}
+
+	plain, _, err := token.NewOpaque()
+	if err != nil {
+		a.lg.Warn("create api key error: generate", zap.Error(err))
+		return domain.APIKey{}, "", domain.ErrInternalServer
+	}
+	key := apiKeyPrefix + plain
+
+	dbCtx, cancel := context.WithTimeout(ctx, a.dbTimeout)
+	defer cancel()
+
+	created, err := a.apiKeyRepo.Add(dbCtx, &domain.APIKey{
+		UserID:    owner.UserID,
+		Name:      name,
+		Prefix:    key[:apiKeyShownChars],
+		Hash:      token.Hash(key),
+		Scopes:    scopes,
+		RateLimit: rateLimit,
+	})
+	if err != nil {
+		a.lg.Warn("create api key error", zap.Error(err))
+		return domain.APIKey{}, "", fmt.Errorf("create api key error: %w", err)
+	}
+
+	a.lg.Info("successful create api key", zap.Int64("id", created.ID))
+	return created, key, nil
+}
+
+func (a *APIKeyUsecase) GetAll(ctx context.Context) ([]domain.APIKey, error) {
+	a.lg.Info("get api keys")
+
+	owner, err := keyOwner(ctx)
+	if err != nil {
+		a.lg.Warn("get api keys error: forbidden", zap.Error(err))
+		return nil, err
+	}
+
+	dbCtx, cancel := context.WithTimeout(ctx, a.dbTimeout)
+	defer cancel()
+
+	keys, err := a.apiKeyRepo.GetByUser(dbCtx, owner.UserID)
+	if err != nil {
+		a.lg.Warn("get api keys error", zap.Error(err))
+		return nil, fmt.Errorf("get api keys error: %w", err)
+	}
+
+	a.lg.Info("successful get api keys")
+	return keys, nil
+}
+
+// Revoke works for the owner of the key and for admins.
+func (a *APIKeyUsecase) Revoke(ctx context.Context, id int64) error {
+	a.lg.Info("revoke api key", zap.Int64("id", id))
+
+	owner, err := keyOwner(ctx)
+	if err != nil {
+		a.lg.Warn("revoke api key error: forbidden", zap.Error(err))
+		return err
+	}
+
+	if id <= 0 {
+		a.lg.Warn("revoke api key error: bad id", zap.Error(domain.ErrBadID))
+		return domain.ErrBadID
+	}
+
+	dbCtx, cancel := context.WithTimeout(ctx, a.dbTimeout)
+	defer cancel()
+
+	key, err := a.apiKeyRepo.Get(dbCtx, id)
+	if err != nil {
+		a.lg.Warn("revoke api key error", zap.Error(err))
+		return fmt.Errorf("revoke api key error: %w", err)
+	}
+
+	// keys of other users look missing to everybody but admins
+	if key.UserID != owner.UserID && !owner.Role.Can(domain.PermManageUsers) {
+		a.lg.Warn("revoke api key error: not owner", zap.Error(domain.ErrAPIKeyNotFound))
+		return domain.ErrAPIKeyNotFound
+	}
+
+	err = a.apiKeyRepo.Revoke(dbCtx, id)
+	if err != nil {
+		a.lg.Warn("revoke api key error", zap.Error(err))
+		return fmt.Errorf("revoke api key error: %w", err)
+	}
+
+	a.lg.Info("successful revoke api key", zap.Int64("id", id))
+	return nil
+}
+
+// Authenticate turns a key into the identity of its owner with the
+// current role of the owner and the scopes of the key.
+func (a *APIKeyUsecase) Authenticate(ctx context.Context, key string) (domain.Identity, error) {
+	if !strings.HasPrefix(key, apiKeyPrefix) {
+		a.lg.Warn("authenticate api key error: bad format")
+		return domain.Identity{}, domain.ErrInvalidAPIKey
+	}
+
+	dbCtx, cancel := context.WithTimeout(ctx, a.dbTimeout)
+	defer cancel()
+
+	apiKey, owner, err := a.apiKeyRepo.Use(dbCtx, token.Hash(key))
+	if err != nil {
+		a.lg.Warn("authenticate api key error", zap.Error(err))
+		return domain.Identity{}, fmt.Errorf("authenticate api key error: %w", err)
+	}
+
+	return domain.Identity{
+		UserID:    owner.ID,
+		Username:  owner.Username,
+		Role:      owner.Role,
+		APIKeyID:  apiKey.ID,
+		Scopes:    apiKey.Scopes,
+		RateLimit: apiKey.RateLimit,
+	}, nil
+}
@@ -0,0 +1,275 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"github.com/NastyaAR/music_library/internal/domain"
+	"github.com/go-playground/validator/v10"
+	"go.uber.org/zap"
+	"time"
+)
+
+type ArtistUsecase struct {
+	artistRepo domain.ArtistRepo
+	validate   *validator.Validate
+	lg         *zap.Logger
+	dbTimeout  time.Duration
+}
+
+func NewArtistUsecase(artistRepo domain.ArtistRepo, valid *validator.Validate, lg *zap.Logger) *ArtistUsecase {
+	lg.With(zap.String("component", "artist usecase"))
+	return &ArtistUsecase{
+		artistRepo: artistRepo,
+		validate:   valid,
+		lg:         lg,
+		dbTimeout:  time.Hour,
+	}
+}
+
+func (a *ArtistUsecase) validateArtist(artist *domain.Artist) error {
+	if artist == nil {
+		a.lg.Warn("artist error: nil request",
+			zap.Error(domain.ErrNilArtistRequest))
+		return domain.ErrNilArtistRequest
+	}
+
+	err := a.validate.Var(artist.Name, "required")
+	if err != nil {
+		a.lg.Warn("artist error: bad name",
+			zap.Error(domain.ErrBadArtistName))
+		return domain.ErrBadArtistName
+	}
+
+	if artist.FormedYear < 0 || artist.FormedYear > time.Now().Year() {
+		a.lg.Warn("artist error: bad formed year",
+			zap.Error(domain.ErrBadFormedYear))
+		return domain.ErrBadFormedYear
+	}
+
+	return nil
+}
+
+func (a *ArtistUsecase) Create(ctx context.Context, createReq *domain.Artist) (domain.Artist, error) {
+	a.lg.Info("create artist", zap.Any("request", createReq))
+
+	if err := authorize(ctx, domain.PermEditArtists); err != nil {
+		a.lg.Warn("create artist error: forbidden", zap.Error(err))
+		return domain.Artist{}, err
+	}
+
+	err := a.validateArtist(createReq)
+	if err != nil {
+		return domain.Artist{}, err
+	}
+
+	dbCtx, cancel := context.WithTimeout(ctx, a.dbTimeout)
+	defer cancel()
+
+	created, err := a.artistRepo.Add(dbCtx, createReq)
+	if err != nil {
+		a.lg.Warn("create artist error", zap.Error(err))
+		return domain.Artist{}, fmt.Errorf("create artist error: %w", err)
+	}
+
+	a.lg.Info("successful create artist")
+	return created, nil
+}
+
+func (a *ArtistUsecase) Delete(ctx context.Context, id int64) error {
+	a.lg.Info("delete artist", zap.Int64("id", id))
+
+	if err := authorize(ctx, domain.PermDeleteArtists); err != nil {
+		a.lg.Warn("delete artist error: forbidden", zap.Error(err))
+		return err
+	}
+
+	if id <= 0 {
+		a.lg.Warn("delete artist error: bad id",
+			zap.Error(domain.ErrBadID))
+		return domain.ErrBadID
+	}
+
+	dbCtx, cancel := context.WithTimeout(ctx, a.dbTimeout)
+	defer cancel()
+
+	err := a.artistRepo.Delete(dbCtx, id)
+	if err != nil {
+		a.lg.Warn("delete artist error", zap.Error(err))
+		return fmt.Errorf("delete artist error: %w", err)
+	}
+
+	a.lg.Info("successful delete artist")
+	return nil
+}
+
+func (a *ArtistUsecase) Update(ctx context.Context, id int64, updReq *domain.Artist) (domain.Artist, error) {
+	a.lg.Info("update artist", zap.Int64("id", id))
+
+	if err := authorize(ctx, domain.PermEditArtists); err != nil {
+		a.lg.Warn("update artist error: forbidden", zap.Error(err))
+		return domain.Artist{}, err
+	}
+
+	if id <= 0 {
+		a.lg.Warn("update artist error: bad id",
+			zap.Error(domain.ErrBadID))
+		return domain.Artist{}, domain.ErrBadID
+	}
+
+	err := a.validateArtist(updReq)
+	if err != nil {
+		return domain.Artist{}, err
+	}
+
+	dbCtx, cancel := context.WithTimeout(ctx, a.dbTimeout)
+	defer cancel()
+
+	updated, err := a.artistRepo.Update(dbCtx, id, updReq)
+	if err != nil {
+		a.lg.Warn("update artist error", zap.Error(err))
+		return domain.Artist{}, fmt.Errorf("update artist error: %w", err)
+	}
+
+	a.lg.Info("successful update artist")
+	return updated, nil
+}
+
+func (a *ArtistUsecase) Get(ctx context.Context, id int64) (domain.Artist, error) {
+	a.lg.Info("get artist", zap.Int64("id", id))
+
+	if id <= 0 {
+		a.lg.Warn("get artist error: bad id",
+			zap.Error(domain.ErrBadID))
+		return domain.Artist{}, domain.ErrBadID
+	}
+
+	dbCtx, cancel := context.WithTimeout(ctx, a.dbTimeout)
+	defer cancel()
+
+	artist, err := a.artistRepo.Get(dbCtx, id)
+	if err != nil {
+		a.lg.Warn("get artist error", zap.Error(err))
+		return domain.Artist{}, fmt.Errorf("get artist error: %w", err)
+	}
+
+	a.lg.Info("successful get artist")
+	return artist, nil
+}
+
+func (a *ArtistUsecase) GetAll(ctx context.Context, limit int, offset int) ([]domain.Artist, error) {
+	a.lg.Info("get artists", zap.Int("limit", limit), zap.Int("offset", offset))
+
+	if limit <= 0 {
+		a.lg.Warn("get artists error: bad limit",
+			zap.Error(domain.ErrBadLimit))
+		return nil, domain.ErrBadLimit
+	}
+
+	if offset < 1 {
+		a.lg.Warn("get artists error: bad offset",
+			zap.Error(domain.ErrBadOffset))
+		return nil, domain.ErrBadOffset
+	}
+
+	dbCtx, cancel := context.WithTimeout(ctx, a.dbTimeout)
+	defer cancel()
+
+	artists, err := a.artistRepo.GetAll(dbCtx, limit, (offset-1)*limit)
+	if err != nil {
+		a.lg.Warn("get artists error", zap.Error(err))
+		return nil, fmt.Errorf("get artists error: %w", err)
+	}
+
+	a.lg.Info("successful get artists")
+	return artists, nil
+}
+
+func (a *ArtistUsecase) GetSongs(ctx context.Context, id int64, limit int, offset int) ([]domain.Song, error) {
+	a.lg.Info("get artist songs", zap.Int64("id", id))
+
+	if id <= 0 {
+		a.lg.Warn("get artist songs error: bad id",
+			zap.Error(domain.ErrBadID))
+		return nil, domain.ErrBadID
+	}
+
+	if limit <= 0 {
+		a.lg.Warn("get artist songs error: bad limit",
+			zap.Error(domain.ErrBadLimit))
+		return nil, domain.ErrBadLimit
+	}
+
+	if offset < 1 {
+		a.lg.Warn("get artist songs error: bad offset",
+			zap.Error(domain.ErrBadOffset))
+		return nil, domain.ErrBadOffset
+	}
+
+	dbCtx, cancel := context.WithTimeout(ctx, a.dbTimeout)
+	defer cancel()
+
+	songs, err := a.artistRepo.GetSongs(dbCtx, id, limit, (offset-1)*limit)
+	if err != nil {
+		a.lg.Warn("get artist songs error", zap.Error(err))
+		return nil, fmt.Errorf("get artist songs error: %w", err)
+	}
+
+	a.lg.Info("successful get artist songs")
+	return songs, nil
+}
+
+func (a *ArtistUsecase) GetByIDs(ctx context.Context, ids []int64) ([]domain.Artist, error) {
+	a.lg.Info("get artists by ids", zap.Int("count", len(ids)))
+
+	ids, err := validateIDs(ids)
+	if err != nil {
+		a.lg.Warn("get artists by ids error: bad ids", zap.Error(err))
+		return nil, err
+	}
+	if len(ids) == 0 {
+		return []domain.Artist{}, nil
+	}
+
+	dbCtx, cancel := context.WithTimeout(ctx, a.dbTimeout)
+	defer cancel()
+
+	artists, err := a.artistRepo.GetByIDs(dbCtx, ids)
+	if err != nil {
+		a.lg.Warn("get artists by ids error", zap.Error(err))
+		return nil, fmt.Errorf("get artists by ids error: %w", err)
+	}
+
+	a.lg.Info("successful get artists by ids")
+	return artists, nil
+}
+
+func (a *ArtistUsecase) GetSongsByArtists(ctx context.Context, ids []int64, limit int) (map[int64][]domain.Song, error) {
+	a.lg.Info("get songs of artists", zap.Int("count", len(ids)), zap.Int("limit", limit))
+
+	if limit <= 0 {
+		a.lg.Warn("get songs of artists error: bad limit",
+			zap.Error(domain.ErrBadLimit))
+		return nil, domain.ErrBadLimit
+	}
+
+	ids, err := validateIDs(ids)
+	if err != nil {
+		a.lg.Warn("get songs of artists error: bad ids", zap.Error(err))
+		return nil, err
+	}
+	if len(ids) == 0 {
+		return map[int64][]domain.Song{}, nil
+	}
+
+	dbCtx, cancel := context.WithTimeout(ctx, a.dbTimeout)
+	defer cancel()
+
+	songs, err := a.artistRepo.GetSongsByArtists(dbCtx, ids, limit)
+	if err != nil {
+		a.lg.Warn("get songs of artists error", zap.Error(err))
+		return nil, fmt.Errorf("get songs of artists error: %w", err)
+	}
+
+	a.lg.Info("successful get songs of artists")
+	return songs, nil
+}
@@ -0,0 +1,26 @@
+package usecase
+
+import (
+	"github.com/NastyaAR/music_library/internal/domain"
+)
+
+// validateIDs checks ids of a batched get, duplicates are dropped.
+func validateIDs(ids []int64) ([]int64, error) {
+	if len(ids) > domain.MaxBatchIDs {
+		return nil, domain.ErrTooManyIDs
+	}
+
+	seen := make(map[int64]bool, len(ids))
+	unique := make([]int64, 0, len(ids))
+	for _, id := range ids {
+		if id <= 0 {
+			return nil, domain.ErrBadID
+		}
+		if !seen[id] {
+			seen[id] = true
+			unique = append(unique, id)
+		}
+	}
+
+	return unique, nil
+}
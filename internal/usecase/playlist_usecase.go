@@ -0,0 +1,301 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"github.com/NastyaAR/music_library/internal/domain"
+	"go.uber.org/zap"
+	"strings"
+	"time"
+)
+
+const maxPlaylistNameLen = 100
+
+type PlaylistUsecase struct {
+	playlistRepo domain.PlaylistRepo
+	lg           *zap.Logger
+	dbTimeout    time.Duration
+}
+
+func NewPlaylistUsecase(playlistRepo domain.PlaylistRepo, lg *zap.Logger) *PlaylistUsecase {
+	lg.With(zap.String("component", "playlist usecase"))
+	return &PlaylistUsecase{
+		playlistRepo: playlistRepo,
+		lg:           lg,
+		dbTimeout:    time.Hour,
+	}
+}
+
+func validatePlaylistName(name string) (string, error) {
+	name = strings.TrimSpace(name)
+	if name == "" || len(name) > maxPlaylistNameLen {
+		return "", domain.ErrBadPlaylistName
+	}
+	return name, nil
+}
+
+// own returns the playlist when the caller may change it. Playlists of
+// other users look missing, so private ones do not leak.
+func (p *PlaylistUsecase) own(ctx context.Context, id int64) (domain.Playlist, error) {
+	if err := authorize(ctx, domain.PermEditPlaylists); err != nil {
+		return domain.Playlist{}, err
+	}
+
+	if id <= 0 {
+		return domain.Playlist{}, domain.ErrBadID
+	}
+
+	playlist, err := p.playlistRepo.Get(ctx, id)
+	if err != nil {
+		return domain.Playlist{}, err
+	}
+
+	identity, _ := domain.IdentityFrom(ctx)
+	if playlist.UserID != identity.UserID {
+		return domain.Playlist{}, domain.ErrPlaylistNotFound
+	}
+
+	return playlist, nil
+}
+
+func (p *PlaylistUsecase) Create(ctx context.Context, name string, public bool) (domain.Playlist, error) {
+	p.lg.Info("create playlist", zap.String("name", name))
+
+	if err := authorize(ctx, domain.PermEditPlaylists); err != nil {
+		p.lg.Warn("create playlist error: forbidden", zap.Error(err))
+		return domain.Playlist{}, err
+	}
+
+	name, err := validatePlaylistName(name)
+	if err != nil {
+		p.lg.Warn("create playlist error: bad name", zap.Error(err))
+		return domain.Playlist{}, err
+	}
+
+	identity, _ := domain.IdentityFrom(ctx)
+
+	dbCtx, cancel := context.WithTimeout(ctx, p.dbTimeout)
+	defer cancel()
+
+	playlist, err := p.playlistRepo.Add(dbCtx, &domain.Playlist{
+		UserID: identity.UserID,
+		Name:   name,
+		Public: public,
+	})
+	if err != nil {
+		p.lg.Warn("create playlist error", zap.Error(err))
+		return domain.Playlist{}, fmt.Errorf("create playlist error: %w", err)
+	}
+
+	p.lg.Info("successful create playlist", zap.Int64("id", playlist.ID))
+	return playlist, nil
+}
+
+// Update renames and shares the playlist, nil fields stay as they are.
+func (p *PlaylistUsecase) Update(ctx context.Context, id int64, name *string, public *bool) (domain.Playlist, error) {
+	p.lg.Info("update playlist", zap.Int64("id", id))
+
+	dbCtx, cancel := context.WithTimeout(ctx, p.dbTimeout)
+	defer cancel()
+
+	playlist, err := p.own(dbCtx, id)
+	if err != nil {
+		p.lg.Warn("update playlist error", zap.Error(err))
+		return domain.Playlist{}, fmt.Errorf("update playlist error: %w", err)
+	}
+
+	if name != nil {
+		playlist.Name, err = validatePlaylistName(*name)
+		if err != nil {
+			p.lg.Warn("update playlist error: bad name", zap.Error(err))
+			return domain.Playlist{}, err
+		}
+	}
+	if public != nil {
+		playlist.Public = *public
+	}
+
+	updated, err := p.playlistRepo.Update(dbCtx, id, &playlist)
+	if err != nil {
+		p.lg.Warn("update playlist error", zap.Error(err))
+		return domain.Playlist{}, fmt.Errorf("update playlist error: %w", err)
+	}
+	updated.Items = playlist.Items
+
+	p.lg.Info("successful update playlist", zap.Int64("id", id))
+	return updated, nil
+}
+
+func (p *PlaylistUsecase) Delete(ctx context.Context, id int64) error {
+	p.lg.Info("delete playlist", zap.Int64("id", id))
+
+	dbCtx, cancel := context.WithTimeout(ctx, p.dbTimeout)
+	defer cancel()
+
+	_, err := p.own(dbCtx, id)
+	if err != nil {
+		p.lg.Warn("delete playlist error", zap.Error(err))
+		return fmt.Errorf("delete playlist error: %w", err)
+	}
+
+	err = p.playlistRepo.Delete(dbCtx, id)
+	if err != nil {
+		p.lg.Warn("delete playlist error", zap.Error(err))
+		return fmt.Errorf("delete playlist error: %w", err)
+	}
+
+	p.lg.Info("successful delete playlist", zap.Int64("id", id))
+	return nil
+}
+
+// Get returns a public playlist to anybody and a private one to its owner.
+func (p *PlaylistUsecase) Get(ctx context.Context, id int64) (domain.Playlist, error) {
+	p.lg.Info("get playlist", zap.Int64("id", id))
+
+	if id <= 0 {
+		p.lg.Warn("get playlist error: bad id", zap.Error(domain.ErrBadID))
+		return domain.Playlist{}, domain.ErrBadID
+	}
+
+	dbCtx, cancel := context.WithTimeout(ctx, p.dbTimeout)
+	defer cancel()
+
+	playlist, err := p.playlistRepo.Get(dbCtx, id)
+	if err != nil {
+		p.lg.Warn("get playlist error", zap.Error(err))
+		return domain.Playlist{}, fmt.Errorf("get playlist error: %w", err)
+	}
+
+	identity, ok := domain.IdentityFrom(ctx)
+	if !playlist.Public && (!ok || identity.UserID != playlist.UserID) {
+		p.lg.Warn("get playlist error: private", zap.Error(domain.ErrPlaylistNotFound))
+		return domain.Playlist{}, domain.ErrPlaylistNotFound
+	}
+
+	return playlist, nil
+}
+
+// GetAll returns the playlists of the caller without items.
+func (p *PlaylistUsecase) GetAll(ctx context.Context) ([]domain.Playlist, error) {
+	p.lg.Info("get playlists")
+
+	identity, ok := domain.IdentityFrom(ctx)
+	if !ok {
+		p.lg.Warn("get playlists error", zap.Error(domain.ErrUnauthorized))
+		return nil, domain.ErrUnauthorized
+	}
+
+	dbCtx, cancel := context.WithTimeout(ctx, p.dbTimeout)
+	defer cancel()
+
+	playlists, err := p.playlistRepo.GetByUser(dbCtx, identity.UserID)
+	if err != nil {
+		p.lg.Warn("get playlists error", zap.Error(err))
+		return nil, fmt.Errorf("get playlists error: %w", err)
+	}
+
+	p.lg.Info("successful get playlists")
+	return playlists, nil
+}
+
+func (p *PlaylistUsecase) AddItem(ctx context.Context, id int64, songID int64, position int) (domain.Playlist, error) {
+	p.lg.Info("add playlist item", zap.Int64("id", id), zap.Int64("song", songID))
+
+	if songID <= 0 {
+		p.lg.Warn("add playlist item error: bad song id", zap.Error(domain.ErrBadID))
+		return domain.Playlist{}, domain.ErrBadID
+	}
+
+	if position < 0 {
+		p.lg.Warn("add playlist item error: bad position", zap.Error(domain.ErrBadPlaylistPosition))
+		return domain.Playlist{}, domain.ErrBadPlaylistPosition
+	}
+
+	dbCtx, cancel := context.WithTimeout(ctx, p.dbTimeout)
+	defer cancel()
+
+	_, err := p.own(dbCtx, id)
+	if err != nil {
+		p.lg.Warn("add playlist item error", zap.Error(err))
+		return domain.Playlist{}, fmt.Errorf("add playlist item error: %w", err)
+	}
+
+	err = p.playlistRepo.AddItem(dbCtx, id, songID, position)
+	if err != nil {
+		p.lg.Warn("add playlist item error", zap.Error(err))
+		return domain.Playlist{}, fmt.Errorf("add playlist item error: %w", err)
+	}
+
+	p.lg.Info("successful add playlist item", zap.Int64("id", id))
+	return p.reload(dbCtx, id)
+}
+
+func (p *PlaylistUsecase) RemoveItem(ctx context.Context, id int64, itemID int64) (domain.Playlist, error) {
+	p.lg.Info("remove playlist item", zap.Int64("id", id), zap.Int64("item", itemID))
+
+	if itemID <= 0 {
+		p.lg.Warn("remove playlist item error: bad item id", zap.Error(domain.ErrBadID))
+		return domain.Playlist{}, domain.ErrBadID
+	}
+
+	dbCtx, cancel := context.WithTimeout(ctx, p.dbTimeout)
+	defer cancel()
+
+	_, err := p.own(dbCtx, id)
+	if err != nil {
+		p.lg.Warn("remove playlist item error", zap.Error(err))
+		return domain.Playlist{}, fmt.Errorf("remove playlist item error: %w", err)
+	}
+
+	err = p.playlistRepo.RemoveItem(dbCtx, id, itemID)
+	if err != nil {
+		p.lg.Warn("remove playlist item error", zap.Error(err))
+		return domain.Playlist{}, fmt.Errorf("remove playlist item error: %w", err)
+	}
+
+	p.lg.Info("successful remove playlist item", zap.Int64("id", id))
+	return p.reload(dbCtx, id)
+}
+
+func (p *PlaylistUsecase) MoveItem(ctx context.Context, id int64, itemID int64, position int) (domain.Playlist, error) {
+	p.lg.Info("move playlist item", zap.Int64("id", id), zap.Int64("item", itemID),
+		zap.Int("position", position))
+
+	if itemID <= 0 {
+		p.lg.Warn("move playlist item error: bad item id", zap.Error(domain.ErrBadID))
+		return domain.Playlist{}, domain.ErrBadID
+	}
+
+	if position < 1 {
+		p.lg.Warn("move playlist item error: bad position", zap.Error(domain.ErrBadPlaylistPosition))
+		return domain.Playlist{}, domain.ErrBadPlaylistPosition
+	}
+
+	dbCtx, cancel := context.WithTimeout(ctx, p.dbTimeout)
+	defer cancel()
+
+	_, err := p.own(dbCtx, id)
+	if err != nil {
+		p.lg.Warn("move playlist item error", zap.Error(err))
+		return domain.Playlist{}, fmt.Errorf("move playlist item error: %w", err)
+	}
+
+	err = p.playlistRepo.MoveItem(dbCtx, id, itemID, position)
+	if err != nil {
+		p.lg.Warn("move playlist item error", zap.Error(err))
+		return domain.Playlist{}, fmt.Errorf("move playlist item error: %w", err)
+	}
+
+	p.lg.Info("successful move playlist item", zap.Int64("id", id))
+	return p.reload(dbCtx, id)
+}
+
+// reload returns the playlist as it is after a change of its items.
+func (p *PlaylistUsecase) reload(ctx context.Context, id int64) (domain.Playlist, error) {
+	playlist, err := p.playlistRepo.Get(ctx, id)
+	if err != nil {
+		p.lg.Warn("reload playlist error", zap.Error(err))
+		return domain.Playlist{}, fmt.Errorf("reload playlist error: %w", err)
+	}
+	return playlist, nil
+}
@@ -0,0 +1,31 @@
+package usecase
+
+import (
+	"context"
+	"github.com/NastyaAR/music_library/internal/domain"
+)
+
+// authorize checks the caller put into ctx by the transport, so HTTP,
+// gRPC and GraphQL share the same rules. An api key also needs the scope
+// of every permission.
+func authorize(ctx context.Context, permissions ...domain.Permission) error {
+	identity, ok := domain.IdentityFrom(ctx)
+	if !ok {
+		return domain.ErrUnauthorized
+	}
+
+	for _, permission := range permissions {
+		if !identity.Role.Can(permission) {
+			return domain.ErrForbidden
+		}
+
+		if identity.APIKeyID != 0 {
+			scope, ok := domain.PermissionScope(permission)
+			if !ok || !identity.HasScope(scope) {
+				return domain.ErrForbidden
+			}
+		}
+	}
+
+	return nil
+}
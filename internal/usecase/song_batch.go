@@ -0,0 +1,84 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"github.com/NastyaAR/music_library/internal/domain"
+	"github.com/NastyaAR/music_library/internal/pkg/lyrics"
+	"go.uber.org/zap"
+)
+
+// GetByIDs returns the songs found among ids, missing and deleted songs
+// are left out.
+func (s *SongUsecase) GetByIDs(ctx context.Context, ids []int64) ([]domain.Song, error) {
+	s.lg.Info("get songs by ids", zap.Int("count", len(ids)))
+
+	ids, err := validateIDs(ids)
+	if err != nil {
+		s.lg.Warn("get by ids error: bad ids", zap.Error(err))
+		return nil, err
+	}
+	if len(ids) == 0 {
+		return []domain.Song{}, nil
+	}
+
+	dbCtx, cancel := context.WithTimeout(ctx, s.dbTimeout)
+	defer cancel()
+
+	songs, err := s.songRepo.GetByIDs(dbCtx, ids)
+	if err != nil {
+		s.lg.Warn("get by ids error", zap.Error(err))
+		return nil, fmt.Errorf("get by ids error: %w", err)
+	}
+
+	s.lg.Info("successful get by ids")
+	return songs, nil
+}
+
+// GetCoupletsByIDs gets couplet number offset of several songs with two
+// queries. Songs that are missing or have fewer couplets are left out.
+func (s *SongUsecase) GetCoupletsByIDs(ctx context.Context, ids []int64, offset int) (map[int64]domain.Couplet, error) {
+	s.lg.Info("get couplets by ids", zap.Int("count", len(ids)), zap.Int("offset", offset))
+
+	if offset < 1 {
+		s.lg.Warn("get couplets by ids error: bad offset",
+			zap.Error(domain.ErrBadOffset))
+		return nil, domain.ErrBadOffset
+	}
+
+	songs, err := s.GetByIDs(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	couplets := make(map[int64]domain.Couplet, len(songs))
+	if len(songs) == 0 {
+		return couplets, nil
+	}
+
+	found := make([]int64, 0, len(songs))
+	for _, song := range songs {
+		found = append(found, song.ID)
+	}
+
+	dbCtx, cancel := context.WithTimeout(ctx, s.dbTimeout)
+	defer cancel()
+
+	lines, err := s.songRepo.GetSyncedLyricsByIDs(dbCtx, found)
+	if err != nil {
+		s.lg.Warn("get couplets by ids error", zap.Error(err))
+		return nil, fmt.Errorf("get couplets by ids error: %w", err)
+	}
+
+	for i := range songs {
+		sections := songSections(&songs[i])
+		section, ok := lyrics.Find(sections, domain.SectionVerse, offset)
+		if !ok {
+			continue
+		}
+		couplets[songs[i].ID] = coupletOf(sections, section, lines[songs[i].ID])
+	}
+
+	s.lg.Info("successful get couplets by ids")
+	return couplets, nil
+}
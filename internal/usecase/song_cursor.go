@@ -0,0 +1,63 @@
+package usecase
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"github.com/NastyaAR/music_library/internal/domain"
+	"time"
+)
+
+type cursorPayload struct {
+	ID          int64  `json:"i"`
+	Group       string `json:"g,omitempty"`
+	Name        string `json:"n,omitempty"`
+	ReleaseDate string `json:"d,omitempty"`
+	Backward    bool   `json:"b,omitempty"`
+	Sort        string `json:"s,omitempty"`
+}
+
+const cursorDateLayout = "2006-01-02"
+
+// encodeCursor stores the sort column values of the boundary song so that
+// the next query can continue right after (or before) it.
+func encodeCursor(song domain.Song, backward bool, sort string) string {
+	payload := cursorPayload{
+		ID:          song.ID,
+		Group:       song.Group,
+		Name:        song.Name,
+		ReleaseDate: song.ReleaseDate.Format(cursorDateLayout),
+		Backward:    backward,
+		Sort:        sort,
+	}
+
+	data, _ := json.Marshal(payload)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func decodeCursor(cursor string, sort string) (domain.SongCursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return domain.SongCursor{}, domain.ErrBadCursor
+	}
+
+	var payload cursorPayload
+	err = json.Unmarshal(data, &payload)
+	if err != nil || payload.ID <= 0 || payload.Sort != sort {
+		return domain.SongCursor{}, domain.ErrBadCursor
+	}
+
+	releaseDate, err := time.Parse(cursorDateLayout, payload.ReleaseDate)
+	if err != nil {
+		return domain.SongCursor{}, domain.ErrBadCursor
+	}
+
+	return domain.SongCursor{
+		Boundary: domain.Song{
+			ID:          payload.ID,
+			Group:       payload.Group,
+			Name:        payload.Name,
+			ReleaseDate: releaseDate,
+		},
+		Backward: payload.Backward,
+	}, nil
+}
@@ -0,0 +1,33 @@
+package usecase
+
+import (
+	"context"
+	"github.com/NastyaAR/music_library/internal/domain"
+	"go.uber.org/zap"
+)
+
+// Export streams all songs matching the filter to fn. It is not bound by
+// dbTimeout since a full export may take long, it stops when ctx is done.
+func (s *SongUsecase) Export(ctx context.Context, filter *domain.SongFilter, fn domain.ExportFunc) error {
+	s.lg.Info("export songs", zap.Any("filter", filter))
+
+	if filter == nil || fn == nil {
+		s.lg.Warn("export error: nil request", zap.Error(domain.ErrNilCreateSongRequest))
+		return domain.ErrNilCreateSongRequest
+	}
+
+	err := validateFilter(filter)
+	if err != nil {
+		s.lg.Warn("export error: bad filter", zap.Error(err))
+		return err
+	}
+
+	err = s.songRepo.Export(ctx, filter, fn)
+	if err != nil {
+		s.lg.Warn("export error", zap.Error(err))
+		return err
+	}
+
+	s.lg.Info("successful export")
+	return nil
+}
@@ -0,0 +1,243 @@
+package usecase
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"github.com/NastyaAR/music_library/internal/domain"
+	"github.com/NastyaAR/music_library/internal/pkg/lyrics"
+	"go.uber.org/zap"
+	"sync"
+	"time"
+)
+
+// importJobTTL is how long finished import jobs can be looked up.
+const importJobTTL = 24 * time.Hour
+
+// importJobs keeps background imports in memory, they are lost on restart.
+type importJobs struct {
+	mu   sync.Mutex
+	jobs map[string]*domain.ImportJob
+}
+
+func newImportJobs() *importJobs {
+	return &importJobs{jobs: make(map[string]*domain.ImportJob)}
+}
+
+func (j *importJobs) add(job *domain.ImportJob) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	for id, old := range j.jobs {
+		if old.Status != domain.ImportJobRunning && time.Since(old.FinishedAt) > importJobTTL {
+			delete(j.jobs, id)
+		}
+	}
+	j.jobs[job.ID] = job
+}
+
+func (j *importJobs) finish(id string, report *domain.ImportReport, err error) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	job := j.jobs[id]
+	job.FinishedAt = time.Now()
+	if err != nil {
+		job.Status = domain.ImportJobFailed
+		job.Error = err.Error()
+		return
+	}
+	job.Status = domain.ImportJobFinished
+	job.Report = report
+}
+
+func (j *importJobs) get(id string) (domain.ImportJob, bool) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	job, ok := j.jobs[id]
+	if !ok {
+		return domain.ImportJob{}, false
+	}
+	return *job, true
+}
+
+func newJobID() (string, error) {
+	b := make([]byte, 16)
+	_, err := rand.Read(b)
+	if err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+func validateImportRow(song *domain.Song) error {
+	if song.Group == "" {
+		return domain.ErrBadGroup
+	}
+	if song.Name == "" {
+		return domain.ErrBadName
+	}
+	return validateTrack(song)
+}
+
+// importPermissions of an import in mode, overwriting existing songs is
+// also an update.
+func importPermissions(mode domain.ImportMode) []domain.Permission {
+	if mode == domain.ImportOverwrite {
+		return []domain.Permission{domain.PermImportSongs, domain.PermUpdateSong}
+	}
+	return []domain.Permission{domain.PermImportSongs}
+}
+
+// Import writes the valid rows and reports every row. Unlike Create it
+// does not ask the info provider for missing details.
+func (s *SongUsecase) Import(ctx context.Context, rows []domain.ImportRow, mode domain.ImportMode) (domain.ImportReport, error) {
+	s.lg.Info("import songs", zap.Int("rows", len(rows)), zap.String("mode", string(mode)))
+
+	if err := authorize(ctx, importPermissions(mode)...); err != nil {
+		s.lg.Warn("import error: forbidden", zap.Error(err))
+		return domain.ImportReport{}, err
+	}
+
+	if !mode.IsValid() {
+		s.lg.Warn("import error: bad mode",
+			zap.Error(domain.ErrBadImportMode))
+		return domain.ImportReport{}, domain.ErrBadImportMode
+	}
+
+	results := make(map[int]domain.ImportRowResult, len(rows))
+	valid := make([]domain.ImportRow, 0, len(rows))
+	for _, row := range rows {
+		err := row.Err
+		if err == nil {
+			err = validateImportRow(&row.Song)
+		}
+		if err != nil {
+			results[row.Row] = domain.ImportRowResult{Row: row.Row,
+				Status: domain.ImportFailed, Error: err.Error()}
+			continue
+		}
+
+		row.Song.Sections = lyrics.Parse(row.Song.Text)
+		valid = append(valid, row)
+	}
+
+	report := domain.ImportReport{Rows: make([]domain.ImportRowResult, 0, len(rows))}
+	if mode == domain.ImportFail && len(results) > 0 {
+		report.Aborted = true
+		valid = valid[:0]
+	}
+
+	if len(valid) > 0 {
+		dbCtx, cancel := context.WithTimeout(ctx, s.dbTimeout)
+		defer cancel()
+
+		written, err := s.songRepo.Import(dbCtx, valid, mode)
+		if err != nil {
+			s.lg.Warn("import error", zap.Error(err))
+			return domain.ImportReport{}, fmt.Errorf("import error: %w", err)
+		}
+
+		for _, result := range written {
+			results[result.Row] = result
+		}
+	}
+
+	for _, row := range rows {
+		result, ok := results[row.Row]
+		if !ok {
+			result = domain.ImportRowResult{Row: row.Row, Status: domain.ImportSkipped}
+		}
+		if mode == domain.ImportFail && result.Status == domain.ImportFailed {
+			report.Aborted = true
+		}
+
+		switch result.Status {
+		case domain.ImportCreated:
+			report.Created++
+		case domain.ImportUpdated:
+			report.Updated++
+		case domain.ImportSkipped:
+			report.Skipped++
+		case domain.ImportFailed:
+			report.Failed++
+		}
+		report.Rows = append(report.Rows, result)
+	}
+
+	s.lg.Info("successful import songs", zap.Int("created", report.Created),
+		zap.Int("updated", report.Updated), zap.Int("failed", report.Failed))
+	return report, nil
+}
+
+// StartImport runs the import in the background, its progress is read
+// with GetImportJob.
+func (s *SongUsecase) StartImport(ctx context.Context, rows []domain.ImportRow, mode domain.ImportMode) (domain.ImportJob, error) {
+	s.lg.Info("start import job", zap.Int("rows", len(rows)))
+
+	if err := authorize(ctx, importPermissions(mode)...); err != nil {
+		s.lg.Warn("start import error: forbidden", zap.Error(err))
+		return domain.ImportJob{}, err
+	}
+
+	if !mode.IsValid() {
+		s.lg.Warn("start import error: bad mode",
+			zap.Error(domain.ErrBadImportMode))
+		return domain.ImportJob{}, domain.ErrBadImportMode
+	}
+
+	id, err := newJobID()
+	if err != nil {
+		s.lg.Warn("start import error", zap.Error(err))
+		return domain.ImportJob{}, fmt.Errorf("start import error: %w", err)
+	}
+
+	// the job outlives the request, it only keeps the caller
+	identity, _ := domain.IdentityFrom(ctx)
+
+	job := &domain.ImportJob{
+		ID:        id,
+		OwnerID:   identity.UserID,
+		Status:    domain.ImportJobRunning,
+		Mode:      mode,
+		Total:     len(rows),
+		CreatedAt: time.Now(),
+	}
+	s.importJobs.add(job)
+	started := *job
+
+	go func() {
+		report, err := s.Import(domain.WithIdentity(context.Background(), identity), rows, mode)
+		if err != nil {
+			s.lg.Warn("import job error", zap.String("job", id), zap.Error(err))
+			s.importJobs.finish(id, nil, err)
+			return
+		}
+		s.importJobs.finish(id, &report, nil)
+	}()
+
+	s.lg.Info("successful start import job", zap.String("job", id))
+	return started, nil
+}
+
+func (s *SongUsecase) GetImportJob(ctx context.Context, id string) (domain.ImportJob, error) {
+	s.lg.Info("get import job", zap.String("job", id))
+
+	identity, ok := domain.IdentityFrom(ctx)
+	if !ok {
+		s.lg.Warn("get import job error", zap.Error(domain.ErrUnauthorized))
+		return domain.ImportJob{}, domain.ErrUnauthorized
+	}
+
+	// other users get the same answer as for a missing job, so job ids
+	// can not be probed
+	job, ok := s.importJobs.get(id)
+	if !ok || job.OwnerID != identity.UserID {
+		s.lg.Warn("get import job error", zap.Error(domain.ErrImportJobNotFound))
+		return domain.ImportJob{}, domain.ErrImportJobNotFound
+	}
+
+	return job, nil
+}
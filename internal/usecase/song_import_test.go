@@ -0,0 +1,39 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"github.com/NastyaAR/music_library/internal/domain"
+	"testing"
+	"time"
+)
+
+func TestGetImportJobOwner(t *testing.T) {
+	usecase, _, _ := newEnrichingUsecase(t)
+	usecase.importJobs.add(&domain.ImportJob{
+		ID:        "job",
+		OwnerID:   1,
+		Status:    domain.ImportJobRunning,
+		CreatedAt: time.Now(),
+	})
+
+	job, err := usecase.GetImportJob(editorCtx(), "job")
+	if err != nil || job.ID != "job" {
+		t.Fatalf("GetImportJob() by owner = %+v, %v", job, err)
+	}
+
+	other := domain.WithIdentity(context.Background(), domain.Identity{
+		UserID:   2,
+		Username: "other",
+		Role:     domain.RoleAdmin,
+	})
+	_, err = usecase.GetImportJob(other, "job")
+	if !errors.Is(err, domain.ErrImportJobNotFound) {
+		t.Errorf("GetImportJob() by another user error = %v, want %v", err, domain.ErrImportJobNotFound)
+	}
+
+	_, err = usecase.GetImportJob(context.Background(), "job")
+	if !errors.Is(err, domain.ErrUnauthorized) {
+		t.Errorf("GetImportJob() anonymous error = %v, want %v", err, domain.ErrUnauthorized)
+	}
+}
@@ -0,0 +1,117 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"github.com/NastyaAR/music_library/internal/domain"
+	"github.com/NastyaAR/music_library/internal/pkg/diff"
+	"github.com/NastyaAR/music_library/internal/pkg/lyrics"
+	"go.uber.org/zap"
+)
+
+func (s *SongUsecase) GetRevisions(ctx context.Context, id int64) ([]domain.SongRevision, error) {
+	s.lg.Info("get revisions", zap.Int64("id", id))
+
+	if id <= 0 {
+		s.lg.Warn("get revisions error: bad id",
+			zap.Error(domain.ErrBadID))
+		return nil, domain.ErrBadID
+	}
+
+	dbCtx, cancel := context.WithTimeout(ctx, s.dbTimeout)
+	defer cancel()
+
+	revisions, err := s.songRepo.GetRevisions(dbCtx, id)
+	if err != nil {
+		s.lg.Warn("get revisions error", zap.Error(err))
+		return nil, fmt.Errorf("get revisions error: %w", err)
+	}
+
+	s.lg.Info("successful get revisions")
+	return revisions, nil
+}
+
+func (s *SongUsecase) GetRevision(ctx context.Context, id int64, revision int) (domain.SongRevision, error) {
+	s.lg.Info("get revision", zap.Int64("id", id), zap.Int("revision", revision))
+
+	if id <= 0 {
+		s.lg.Warn("get revision error: bad id",
+			zap.Error(domain.ErrBadID))
+		return domain.SongRevision{}, domain.ErrBadID
+	}
+
+	if revision < 1 {
+		s.lg.Warn("get revision error: bad revision",
+			zap.Error(domain.ErrBadRevision))
+		return domain.SongRevision{}, domain.ErrBadRevision
+	}
+
+	dbCtx, cancel := context.WithTimeout(ctx, s.dbTimeout)
+	defer cancel()
+
+	rev, err := s.songRepo.GetRevision(dbCtx, id, revision)
+	if err != nil {
+		s.lg.Warn("get revision error", zap.Error(err))
+		return domain.SongRevision{}, fmt.Errorf("get revision error: %w", err)
+	}
+
+	s.lg.Info("successful get revision")
+	return rev, nil
+}
+
+// DiffRevisions returns a line-level diff of the song text between two
+// revisions.
+func (s *SongUsecase) DiffRevisions(ctx context.Context, id int64, from int, to int) ([]domain.DiffLine, error) {
+	s.lg.Info("diff revisions", zap.Int64("id", id),
+		zap.Int("from", from), zap.Int("to", to))
+
+	fromRev, err := s.GetRevision(ctx, id, from)
+	if err != nil {
+		return nil, err
+	}
+
+	toRev, err := s.GetRevision(ctx, id, to)
+	if err != nil {
+		return nil, err
+	}
+
+	lines, err := diff.Lines(fromRev.Song.Text, toRev.Song.Text)
+	if err != nil {
+		s.lg.Warn("diff revisions error", zap.Error(err))
+		return nil, fmt.Errorf("diff revisions error: %w", err)
+	}
+
+	s.lg.Info("successful diff revisions")
+	return lines, nil
+}
+
+// RestoreRevision brings the song back to the state of the revision, the
+// restore itself becomes a new revision.
+func (s *SongUsecase) RestoreRevision(ctx context.Context, id int64, revision int) (domain.Song, error) {
+	s.lg.Info("restore revision", zap.Int64("id", id), zap.Int("revision", revision))
+
+	if err := authorize(ctx, domain.PermRestoreSong); err != nil {
+		s.lg.Warn("restore revision error: forbidden", zap.Error(err))
+		return domain.Song{}, err
+	}
+
+	rev, err := s.GetRevision(ctx, id, revision)
+	if err != nil {
+		return domain.Song{}, err
+	}
+
+	song := rev.Song
+	song.Sections = lyrics.Parse(song.Text)
+
+	dbCtx, cancel := context.WithTimeout(ctx, s.dbTimeout)
+	defer cancel()
+
+	restored, err := s.songRepo.Restore(dbCtx, &song)
+	if err != nil {
+		s.lg.Warn("restore revision error", zap.Error(err))
+		return domain.Song{}, fmt.Errorf("restore revision error: %w", err)
+	}
+
+	s.lg.Info("successful restore revision")
+	return restored, nil
+}
@@ -0,0 +1,94 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"github.com/NastyaAR/music_library/internal/domain"
+	"go.uber.org/zap"
+	"time"
+)
+
+// GetTrash is for those who can restore the songs, deleted songs are not
+// public.
+func (s *SongUsecase) GetTrash(ctx context.Context, limit int, offset int) ([]domain.TrashedSong, error) {
+	s.lg.Info("get trash", zap.Int("limit", limit), zap.Int("offset", offset))
+
+	if err := authorize(ctx, domain.PermRestoreSong); err != nil {
+		s.lg.Warn("get trash error: forbidden", zap.Error(err))
+		return nil, err
+	}
+
+	if limit <= 0 {
+		s.lg.Warn("get trash error: bad limit",
+			zap.Error(domain.ErrBadLimit))
+		return nil, domain.ErrBadLimit
+	}
+
+	if offset < 1 {
+		s.lg.Warn("get trash error: bad offset",
+			zap.Error(domain.ErrBadOffset))
+		return nil, domain.ErrBadOffset
+	}
+
+	dbCtx, cancel := context.WithTimeout(ctx, s.dbTimeout)
+	defer cancel()
+
+	songs, err := s.songRepo.GetDeleted(dbCtx, limit, (offset-1)*limit)
+	if err != nil {
+		s.lg.Warn("get trash error", zap.Error(err))
+		return nil, fmt.Errorf("get trash error: %w", err)
+	}
+
+	s.lg.Info("successful get trash")
+	return songs, nil
+}
+
+func (s *SongUsecase) RestoreFromTrash(ctx context.Context, id int64) (domain.Song, error) {
+	s.lg.Info("restore from trash", zap.Int64("id", id))
+
+	if err := authorize(ctx, domain.PermRestoreSong); err != nil {
+		s.lg.Warn("restore from trash error: forbidden", zap.Error(err))
+		return domain.Song{}, err
+	}
+
+	if id <= 0 {
+		s.lg.Warn("restore from trash error: bad id",
+			zap.Error(domain.ErrBadID))
+		return domain.Song{}, domain.ErrBadID
+	}
+
+	dbCtx, cancel := context.WithTimeout(ctx, s.dbTimeout)
+	defer cancel()
+
+	song, err := s.songRepo.RestoreDeleted(dbCtx, id)
+	if err != nil {
+		s.lg.Warn("restore from trash error", zap.Error(err))
+		return domain.Song{}, fmt.Errorf("restore from trash error: %w", err)
+	}
+
+	s.lg.Info("successful restore from trash")
+	return song, nil
+}
+
+// PurgeTrash removes songs that stayed in the trash longer than retention.
+func (s *SongUsecase) PurgeTrash(ctx context.Context, retention time.Duration) (int64, error) {
+	s.lg.Info("purge trash", zap.Duration("retention", retention))
+
+	if retention <= 0 {
+		s.lg.Warn("purge trash error: bad retention",
+			zap.Error(domain.ErrBadRetention))
+		return 0, domain.ErrBadRetention
+	}
+
+	dbCtx, cancel := context.WithTimeout(ctx, s.dbTimeout)
+	defer cancel()
+
+	purged, err := s.songRepo.PurgeDeleted(dbCtx, time.Now().Add(-retention))
+	if err != nil {
+		s.lg.Warn("purge trash error", zap.Error(err))
+		return 0, fmt.Errorf("purge trash error: %w", err)
+	}
+
+	s.lg.Info("successful purge trash", zap.Int64("purged", purged))
+	return purged, nil
+}
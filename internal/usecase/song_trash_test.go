@@ -0,0 +1,35 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"github.com/NastyaAR/music_library/internal/domain"
+	"testing"
+)
+
+func (f *fakeSongRepo) GetDeleted(ctx context.Context, limit int, offset int) ([]domain.TrashedSong, error) {
+	return []domain.TrashedSong{}, nil
+}
+
+func TestGetTrashNeedsModerator(t *testing.T) {
+	usecase, _, _ := newEnrichingUsecase(t)
+
+	_, err := usecase.GetTrash(context.Background(), 10, 1)
+	if !errors.Is(err, domain.ErrUnauthorized) {
+		t.Errorf("anonymous GetTrash() error = %v, want %v", err, domain.ErrUnauthorized)
+	}
+
+	_, err = usecase.GetTrash(editorCtx(), 10, 1)
+	if !errors.Is(err, domain.ErrForbidden) {
+		t.Errorf("editor GetTrash() error = %v, want %v", err, domain.ErrForbidden)
+	}
+
+	moderator := domain.WithIdentity(context.Background(), domain.Identity{
+		UserID:   3,
+		Username: "moderator",
+		Role:     domain.RoleModerator,
+	})
+	if _, err = usecase.GetTrash(moderator, 10, 1); err != nil {
+		t.Errorf("moderator GetTrash() error = %v", err)
+	}
+}
@@ -4,6 +4,8 @@ import (
 	"context"
 	"fmt"
 	"github.com/NastyaAR/music_library/internal/domain"
+	"github.com/NastyaAR/music_library/internal/pkg/lrc"
+	"github.com/NastyaAR/music_library/internal/pkg/lyrics"
 	"github.com/go-playground/validator/v10"
 	"go.uber.org/zap"
 	"strings"
@@ -11,19 +13,55 @@ import (
 )
 
 type SongUsecase struct {
-	songRepo  domain.SongRepo
-	validate  *validator.Validate
-	lg        *zap.Logger
-	dbTimeout time.Duration
+	songRepo       domain.SongRepo
+	infoProvider   domain.SongInfoProvider
+	validate       *validator.Validate
+	lg             *zap.Logger
+	dbTimeout      time.Duration
+	searchLanguage domain.SearchLanguage
+	importJobs     *importJobs
 }
 
-func NewSongUsecase(songRepo domain.SongRepo, valid *validator.Validate, lg *zap.Logger) *SongUsecase {
+func NewSongUsecase(songRepo domain.SongRepo, infoProvider domain.SongInfoProvider,
+	searchLanguage domain.SearchLanguage, valid *validator.Validate, lg *zap.Logger) *SongUsecase {
 	lg.With(zap.String("component", "song usecase"))
 	return &SongUsecase{
-		songRepo:  songRepo,
-		validate:  valid,
-		lg:        lg,
-		dbTimeout: time.Hour,
+		songRepo:       songRepo,
+		infoProvider:   infoProvider,
+		validate:       valid,
+		lg:             lg,
+		dbTimeout:      time.Hour,
+		searchLanguage: searchLanguage,
+		importJobs:     newImportJobs(),
+	}
+}
+
+func needsEnrichment(song *domain.Song) bool {
+	return song.ReleaseDate.IsZero() || song.Text == "" || song.Link == ""
+}
+
+// enrich fills missing release date, text and link from the info provider.
+// Provider failures are only logged so that create keeps working when the
+// details service is down.
+func (s *SongUsecase) enrich(ctx context.Context, song *domain.Song) {
+	if s.infoProvider == nil || !needsEnrichment(song) {
+		return
+	}
+
+	detail, err := s.infoProvider.GetInfo(ctx, song.Group, song.Name)
+	if err != nil {
+		s.lg.Warn("enrich song error", zap.Error(err))
+		return
+	}
+
+	if song.ReleaseDate.IsZero() {
+		song.ReleaseDate = detail.ReleaseDate
+	}
+	if song.Text == "" {
+		song.Text = detail.Text
+	}
+	if song.Link == "" {
+		song.Link = detail.Link
 	}
 }
 
@@ -31,6 +69,11 @@ func (s *SongUsecase) Create(ctx context.Context,
 	createReq *domain.Song) (domain.Song, error) {
 	s.lg.Info("create song", zap.Any("request", *createReq))
 
+	if err := authorize(ctx, domain.PermCreateSong); err != nil {
+		s.lg.Warn("create error: forbidden", zap.Error(err))
+		return domain.Song{}, err
+	}
+
 	if createReq == nil {
 		s.lg.Warn("create error: nil request",
 			zap.Error(domain.ErrNilCreateSongRequest))
@@ -51,6 +94,15 @@ func (s *SongUsecase) Create(ctx context.Context,
 		return domain.Song{}, domain.ErrBadName
 	}
 
+	err = validateTrack(createReq)
+	if err != nil {
+		s.lg.Warn("create error: bad track", zap.Error(err))
+		return domain.Song{}, err
+	}
+
+	s.enrich(ctx, createReq)
+	createReq.Sections = lyrics.Parse(createReq.Text)
+
 	dbCtx, cancel := context.WithTimeout(ctx, s.dbTimeout)
 	defer cancel()
 
@@ -58,17 +110,22 @@ func (s *SongUsecase) Create(ctx context.Context,
 	if err != nil {
 		s.lg.Warn("create error", zap.Error(err))
 		return domain.Song{},
-			fmt.Errorf("create error: %v", err.Error())
+			fmt.Errorf("create error: %w", err)
 	}
 
 	s.lg.Info("successful create song")
 	return created, nil
 }
 
-func (s *SongUsecase) Delete(ctx context.Context, group string, name string) error {
+func (s *SongUsecase) Delete(ctx context.Context, group string, name string, version int) error {
 	s.lg.Info("delete song", zap.String("group", group),
 		zap.String("name", name))
 
+	if err := authorize(ctx, domain.PermDeleteSong); err != nil {
+		s.lg.Warn("delete error: forbidden", zap.Error(err))
+		return err
+	}
+
 	if group == "" {
 		s.lg.Warn("delete error: bad group",
 			zap.Error(domain.ErrBadGroup))
@@ -84,97 +141,310 @@ func (s *SongUsecase) Delete(ctx context.Context, group string, name string) err
 	dbCtx, cancel := context.WithTimeout(ctx, s.dbTimeout)
 	defer cancel()
 
-	err := s.songRepo.Delete(dbCtx, group, name)
+	err := s.songRepo.Delete(dbCtx, group, name, version)
 	if err != nil {
 		s.lg.Warn("delete error", zap.Error(err))
-		return fmt.Errorf("delete error: %v", err.Error())
+		return fmt.Errorf("delete error: %w", err)
 	}
 
 	s.lg.Info("successful delete")
 	return nil
 }
 
-func (s *SongUsecase) Update(ctx context.Context, group string, name string, updReq *domain.Song) (domain.Song, error) {
-	s.lg.Info("update song", zap.Any("request", *updReq))
+func (s *SongUsecase) validatePatch(patch *domain.SongPatch) error {
+	if patch == nil {
+		s.lg.Warn("update error: nil request",
+			zap.Error(domain.ErrNilCreateSongRequest))
+		return domain.ErrNilCreateSongRequest
+	}
 
-	if group == "" {
+	if patch.Group.Set && (patch.Group.Value == nil || *patch.Group.Value == "") {
 		s.lg.Warn("update error: bad group",
 			zap.Error(domain.ErrBadGroup))
-		return domain.Song{}, domain.ErrBadGroup
+		return domain.ErrBadGroup
 	}
 
-	if name == "" {
+	if patch.Name.Set && (patch.Name.Value == nil || *patch.Name.Value == "") {
 		s.lg.Warn("update error: bad name",
 			zap.Error(domain.ErrBadName))
-		return domain.Song{}, domain.ErrBadName
+		return domain.ErrBadName
 	}
 
-	if updReq == nil {
-		s.lg.Warn("update error: nil request",
-			zap.Error(domain.ErrNilCreateSongRequest))
-		return domain.Song{}, domain.ErrNilCreateSongRequest
+	if (patch.AlbumID.Value != nil && *patch.AlbumID.Value < 0) ||
+		(patch.TrackNumber.Value != nil && *patch.TrackNumber.Value < 0) ||
+		(patch.TrackNumber.Value != nil && *patch.TrackNumber.Value > 0 && patch.AlbumID.Cleared()) {
+		s.lg.Warn("update error: bad track",
+			zap.Error(domain.ErrBadTrackNumber))
+		return domain.ErrBadTrackNumber
 	}
 
-	err := s.validate.Var(updReq.Group, "required")
-	if err != nil {
+	if patch.Text.Set {
+		text := ""
+		if patch.Text.Value != nil {
+			text = *patch.Text.Value
+		}
+		patch.Sections = lyrics.Parse(text)
+	}
+
+	return nil
+}
+
+func validateTrack(song *domain.Song) error {
+	if song.AlbumID < 0 || song.TrackNumber < 0 {
+		return domain.ErrBadTrackNumber
+	}
+
+	if song.TrackNumber > 0 && song.AlbumID == 0 {
+		return domain.ErrBadTrackNumber
+	}
+
+	return nil
+}
+
+func (s *SongUsecase) Update(ctx context.Context, group string, name string, patch *domain.SongPatch) (domain.Song, error) {
+	s.lg.Info("update song", zap.String("group", group),
+		zap.String("name", name))
+
+	if err := authorize(ctx, domain.PermUpdateSong); err != nil {
+		s.lg.Warn("update error: forbidden", zap.Error(err))
+		return domain.Song{}, err
+	}
+
+	if group == "" {
 		s.lg.Warn("update error: bad group",
 			zap.Error(domain.ErrBadGroup))
 		return domain.Song{}, domain.ErrBadGroup
 	}
 
-	err = s.validate.Var(updReq.Name, "required")
-	if err != nil {
+	if name == "" {
 		s.lg.Warn("update error: bad name",
 			zap.Error(domain.ErrBadName))
 		return domain.Song{}, domain.ErrBadName
 	}
 
+	err := s.validatePatch(patch)
+	if err != nil {
+		return domain.Song{}, err
+	}
+
 	dbCtx, cancel := context.WithTimeout(ctx, s.dbTimeout)
 	defer cancel()
 
-	updated, err := s.songRepo.Update(dbCtx, group, name, updReq)
+	updated, err := s.songRepo.Update(dbCtx, group, name, patch)
 	if err != nil {
 		s.lg.Warn("update error", zap.Error(err))
-		return domain.Song{}, fmt.Errorf("update error: %v", err.Error())
+		return domain.Song{}, fmt.Errorf("update error: %w", err)
 	}
 
 	s.lg.Info("successful update")
 	return updated, nil
 }
 
-func (s *SongUsecase) GetSongs(ctx context.Context, filter *domain.Song,
-	limit int, offset int) ([]domain.Song, error) {
-	s.lg.Info("get songs", zap.Any("filter", *filter))
+var allowedSortFields = map[domain.SortField]bool{
+	domain.SortByID:          true,
+	domain.SortByGroup:       true,
+	domain.SortByName:        true,
+	domain.SortByReleaseDate: true,
+}
 
-	if filter == nil {
+func validateSort(keys []domain.SortKey) error {
+	seen := make(map[domain.SortField]bool)
+	for _, key := range keys {
+		if !allowedSortFields[key.Field] || seen[key.Field] {
+			return domain.ErrBadSort
+		}
+		seen[key.Field] = true
+	}
+
+	return nil
+}
+
+// resolveReleaseRange turns year and decade shortcuts into release date
+// bounds and narrows them with explicit released_from/released_to.
+func resolveReleaseRange(filter *domain.SongFilter) error {
+	from, to := filter.ReleasedFrom, filter.ReleasedTo
+
+	narrow := func(start time.Time, end time.Time) {
+		if from.IsZero() || start.After(from) {
+			from = start
+		}
+		if to.IsZero() || end.Before(to) {
+			to = end
+		}
+	}
+
+	if filter.Year != 0 {
+		if filter.Year < 1 || filter.Year > 9999 {
+			return domain.ErrBadReleaseRange
+		}
+		narrow(time.Date(filter.Year, time.January, 1, 0, 0, 0, 0, time.UTC),
+			time.Date(filter.Year, time.December, 31, 0, 0, 0, 0, time.UTC))
+	}
+
+	if filter.Decade != 0 {
+		if filter.Decade < 1 || filter.Decade > 9990 || filter.Decade%10 != 0 {
+			return domain.ErrBadReleaseRange
+		}
+		narrow(time.Date(filter.Decade, time.January, 1, 0, 0, 0, 0, time.UTC),
+			time.Date(filter.Decade+9, time.December, 31, 0, 0, 0, 0, time.UTC))
+	}
+
+	if !from.IsZero() && !to.IsZero() && from.After(to) {
+		return domain.ErrBadReleaseRange
+	}
+
+	filter.ReleasedFrom, filter.ReleasedTo = from, to
+	return nil
+}
+
+func (s *SongUsecase) GetSongs(ctx context.Context, filter *domain.SongFilter,
+	page *domain.Pagination) (domain.SongsPage, error) {
+	s.lg.Info("get songs", zap.Any("filter", filter), zap.Any("page", page))
+
+	if filter == nil || page == nil {
 		s.lg.Warn("getsongs error: nil request",
 			zap.Error(domain.ErrNilCreateSongRequest))
-		return nil, domain.ErrNilCreateSongRequest
+		return domain.SongsPage{}, domain.ErrNilCreateSongRequest
 	}
 
-	if limit <= 0 {
+	err := validateFilter(filter)
+	if err != nil {
+		s.lg.Warn("getsongs error: bad filter", zap.Error(err))
+		return domain.SongsPage{}, err
+	}
+
+	if page.Limit <= 0 {
 		s.lg.Warn("getsongs error: bad limit",
 			zap.Error(domain.ErrBadLimit))
-		return nil, domain.ErrBadLimit
+		return domain.SongsPage{}, domain.ErrBadLimit
 	}
 
-	if offset < 1 {
+	if page.Page < 0 {
 		s.lg.Warn("getsongs error: bad offset",
 			zap.Error(domain.ErrBadOffset))
-		return nil, domain.ErrBadOffset
+		return domain.SongsPage{}, domain.ErrBadOffset
 	}
 
 	dbCtx, cancel := context.WithTimeout(ctx, s.dbTimeout)
 	defer cancel()
 
-	songs, err := s.songRepo.GetAll(dbCtx, filter, limit, offset-1)
+	var result domain.SongsPage
+	if page.Page > 0 && page.Cursor == "" {
+		result, err = s.getSongsPage(dbCtx, filter, page)
+	} else {
+		result, err = s.getSongsByCursor(dbCtx, filter, page)
+	}
 	if err != nil {
 		s.lg.Warn("getsongs error", zap.Error(err))
-		return nil, fmt.Errorf("getsongs error: %v", err.Error())
+		return domain.SongsPage{}, err
+	}
+
+	if page.Cursor != "" {
+		s.lg.Info("successful getsongs")
+		return result, nil
+	}
+
+	total, err := s.songRepo.Count(dbCtx, filter)
+	if err != nil {
+		s.lg.Warn("getsongs error", zap.Error(err))
+		return domain.SongsPage{}, fmt.Errorf("getsongs error: %w", err)
+	}
+	result.Total = &total
+
+	if page.Page > 0 {
+		result.HasMore = page.Page*page.Limit < total
+		if result.HasMore && len(result.Songs) > 0 && !filter.HasSimilarity() {
+			result.NextCursor = encodeCursor(result.Songs[len(result.Songs)-1],
+				false, filter.SortSignature())
+		}
 	}
 
 	s.lg.Info("successful getsongs")
-	return songs, nil
+	return result, nil
+}
+
+// validateFilter checks match modes and sort keys and turns year and decade
+// into a release date range.
+func validateFilter(filter *domain.SongFilter) error {
+	for _, f := range filter.StringFilters() {
+		if !f.Match.IsValid() {
+			return domain.ErrBadMatchMode
+		}
+	}
+
+	err := validateSort(filter.Sort)
+	if err != nil {
+		return err
+	}
+
+	return resolveReleaseRange(filter)
+}
+
+// getSongsPage serves the legacy page-number mode, page starts from 1.
+func (s *SongUsecase) getSongsPage(ctx context.Context, filter *domain.SongFilter,
+	page *domain.Pagination) (domain.SongsPage, error) {
+	songs, err := s.songRepo.GetAll(ctx, filter, page.Limit, (page.Page-1)*page.Limit)
+	if err != nil {
+		return domain.SongsPage{}, fmt.Errorf("getsongs error: %w", err)
+	}
+
+	result := domain.SongsPage{Songs: songs}
+	if page.Page > 1 && len(songs) > 0 && !filter.HasSimilarity() {
+		result.PrevCursor = encodeCursor(songs[0], true, filter.SortSignature())
+	}
+
+	return result, nil
+}
+
+// getSongsByCursor reads one extra row to find out whether there is
+// a page beyond the requested one.
+func (s *SongUsecase) getSongsByCursor(ctx context.Context, filter *domain.SongFilter,
+	page *domain.Pagination) (domain.SongsPage, error) {
+	if filter.HasSimilarity() && page.Cursor != "" {
+		return domain.SongsPage{}, domain.ErrBadCursor
+	}
+
+	var cursor *domain.SongCursor
+	if page.Cursor != "" {
+		decoded, err := decodeCursor(page.Cursor, filter.SortSignature())
+		if err != nil {
+			return domain.SongsPage{}, err
+		}
+		cursor = &decoded
+	}
+
+	songs, err := s.songRepo.GetByCursor(ctx, filter, cursor, page.Limit+1)
+	if err != nil {
+		return domain.SongsPage{}, fmt.Errorf("getsongs error: %w", err)
+	}
+
+	backward := cursor != nil && cursor.Backward
+	extra := len(songs) > page.Limit
+	if extra && backward {
+		songs = songs[1:]
+	} else if extra {
+		songs = songs[:page.Limit]
+	}
+
+	result := domain.SongsPage{Songs: songs}
+	if len(songs) == 0 || filter.HasSimilarity() {
+		result.HasMore = extra
+		return result, nil
+	}
+
+	sort := filter.SortSignature()
+	hasNext := (extra && !backward) || backward
+	hasPrev := (extra && backward) || (cursor != nil && !backward)
+
+	if hasNext {
+		result.NextCursor = encodeCursor(songs[len(songs)-1], false, sort)
+	}
+	if hasPrev {
+		result.PrevCursor = encodeCursor(songs[0], true, sort)
+	}
+	result.HasMore = hasNext
+
+	return result, nil
 }
 
 func (s *SongUsecase) Get(ctx context.Context, group string, name string) (domain.Song, error) {
@@ -199,7 +469,7 @@ func (s *SongUsecase) Get(ctx context.Context, group string, name string) (domai
 	song, err := s.songRepo.Get(dbCtx, group, name)
 	if err != nil {
 		s.lg.Warn("get error", zap.Error(err))
-		return domain.Song{}, fmt.Errorf("get error: %v", err.Error())
+		return domain.Song{}, fmt.Errorf("get error: %w", err)
 	}
 
 	s.lg.Info("successful get song")
@@ -207,26 +477,26 @@ func (s *SongUsecase) Get(ctx context.Context, group string, name string) (domai
 }
 
 func (s *SongUsecase) GetСouplet(ctx context.Context,
-	group string, name string, offset int) (string, error) {
+	group string, name string, offset int) (domain.Couplet, error) {
 	s.lg.Info("getcouplet", zap.String("group", group),
 		zap.String("name", name))
 
 	if group == "" {
 		s.lg.Warn("getcouplet error: bad group",
 			zap.Error(domain.ErrBadGroup))
-		return "", domain.ErrBadGroup
+		return domain.Couplet{}, domain.ErrBadGroup
 	}
 
 	if name == "" {
 		s.lg.Warn("getcouplet error: bad name",
 			zap.Error(domain.ErrBadName))
-		return "", domain.ErrBadName
+		return domain.Couplet{}, domain.ErrBadName
 	}
 
 	if offset < 1 {
 		s.lg.Warn("getcouplet error: bad offset",
 			zap.Error(domain.ErrBadOffset))
-		return "", domain.ErrBadOffset
+		return domain.Couplet{}, domain.ErrBadOffset
 	}
 
 	dbCtx, cancel := context.WithTimeout(ctx, s.dbTimeout)
@@ -235,15 +505,379 @@ func (s *SongUsecase) GetСouplet(ctx context.Context,
 	song, err := s.songRepo.Get(dbCtx, group, name)
 	if err != nil {
 		s.lg.Warn("getcouplet error", zap.Error(err))
-		return "", fmt.Errorf("getcouplet error: %v", err.Error())
+		return domain.Couplet{}, fmt.Errorf("getcouplet error: %w", err)
 	}
 
-	couplets := strings.Split(song.Text, "\n\n")
-
-	if offset-1 >= len(couplets) {
+	couplet, err := s.getCouplet(dbCtx, &song, offset)
+	if err != nil {
 		s.lg.Warn("getcouplet error", zap.Error(err))
-		return "", fmt.Errorf("getcouplet error: %v", domain.ErrBadOffset)
+		return domain.Couplet{}, fmt.Errorf("getcouplet error: %w", err)
+	}
+
+	return couplet, nil
+}
+
+// songSections returns the stored sections, songs saved before lyrics
+// parsing existed are parsed on the fly.
+func songSections(song *domain.Song) []domain.LyricsSection {
+	if song.Sections != nil {
+		return song.Sections
+	}
+	return lyrics.Parse(song.Text)
+}
+
+// getCouplet returns the offset-th verse of the song. Lyrics without
+// markers consist of verses only, so this matches splitting on blank lines.
+// The time range is filled in when the song has synced lyrics.
+func (s *SongUsecase) getCouplet(ctx context.Context, song *domain.Song, offset int) (domain.Couplet, error) {
+	sections := songSections(song)
+	section, ok := lyrics.Find(sections, domain.SectionVerse, offset)
+	if !ok {
+		return domain.Couplet{}, domain.ErrBadOffset
+	}
+
+	lines, err := s.songRepo.GetSyncedLyrics(ctx, song.ID)
+	if err != nil {
+		return domain.Couplet{}, err
+	}
+
+	return coupletOf(sections, section, lines), nil
+}
+
+func coupletOf(sections []domain.LyricsSection, section domain.LyricsSection,
+	lines []domain.SyncedLine) domain.Couplet {
+	couplet := domain.Couplet{Text: section.Text()}
+	couplet.StartMs, couplet.EndMs, couplet.Synced = lrc.SectionRange(sections, lines, section.Order)
+	return couplet
+}
+
+func (s *SongUsecase) DeleteByID(ctx context.Context, id int64, version int) error {
+	s.lg.Info("delete song by id", zap.Int64("id", id))
+
+	if err := authorize(ctx, domain.PermDeleteSong); err != nil {
+		s.lg.Warn("delete by id error: forbidden", zap.Error(err))
+		return err
+	}
+
+	if id <= 0 {
+		s.lg.Warn("delete by id error: bad id",
+			zap.Error(domain.ErrBadID))
+		return domain.ErrBadID
+	}
+
+	dbCtx, cancel := context.WithTimeout(ctx, s.dbTimeout)
+	defer cancel()
+
+	err := s.songRepo.DeleteByID(dbCtx, id, version)
+	if err != nil {
+		s.lg.Warn("delete by id error", zap.Error(err))
+		return fmt.Errorf("delete error: %w", err)
+	}
+
+	s.lg.Info("successful delete by id")
+	return nil
+}
+
+func (s *SongUsecase) UpdateByID(ctx context.Context, id int64, patch *domain.SongPatch) (domain.Song, error) {
+	s.lg.Info("update song by id", zap.Int64("id", id))
+
+	if err := authorize(ctx, domain.PermUpdateSong); err != nil {
+		s.lg.Warn("update by id error: forbidden", zap.Error(err))
+		return domain.Song{}, err
+	}
+
+	if id <= 0 {
+		s.lg.Warn("update by id error: bad id",
+			zap.Error(domain.ErrBadID))
+		return domain.Song{}, domain.ErrBadID
+	}
+
+	err := s.validatePatch(patch)
+	if err != nil {
+		return domain.Song{}, err
+	}
+
+	dbCtx, cancel := context.WithTimeout(ctx, s.dbTimeout)
+	defer cancel()
+
+	updated, err := s.songRepo.UpdateByID(dbCtx, id, patch)
+	if err != nil {
+		s.lg.Warn("update by id error", zap.Error(err))
+		return domain.Song{}, fmt.Errorf("update error: %w", err)
+	}
+
+	s.lg.Info("successful update by id")
+	return updated, nil
+}
+
+func (s *SongUsecase) GetByID(ctx context.Context, id int64) (domain.Song, error) {
+	s.lg.Info("get by id", zap.Int64("id", id))
+
+	if id <= 0 {
+		s.lg.Warn("get by id error: bad id",
+			zap.Error(domain.ErrBadID))
+		return domain.Song{}, domain.ErrBadID
+	}
+
+	dbCtx, cancel := context.WithTimeout(ctx, s.dbTimeout)
+	defer cancel()
+
+	song, err := s.songRepo.GetByID(dbCtx, id)
+	if err != nil {
+		s.lg.Warn("get by id error", zap.Error(err))
+		return domain.Song{}, fmt.Errorf("get error: %w", err)
+	}
+
+	s.lg.Info("successful get song by id")
+	return song, nil
+}
+
+func (s *SongUsecase) GetCoupletByID(ctx context.Context, id int64, offset int) (domain.Couplet, error) {
+	s.lg.Info("getcouplet by id", zap.Int64("id", id))
+
+	if id <= 0 {
+		s.lg.Warn("getcouplet by id error: bad id",
+			zap.Error(domain.ErrBadID))
+		return domain.Couplet{}, domain.ErrBadID
+	}
+
+	if offset < 1 {
+		s.lg.Warn("getcouplet by id error: bad offset",
+			zap.Error(domain.ErrBadOffset))
+		return domain.Couplet{}, domain.ErrBadOffset
+	}
+
+	dbCtx, cancel := context.WithTimeout(ctx, s.dbTimeout)
+	defer cancel()
+
+	song, err := s.songRepo.GetByID(dbCtx, id)
+	if err != nil {
+		s.lg.Warn("getcouplet by id error", zap.Error(err))
+		return domain.Couplet{}, fmt.Errorf("getcouplet error: %w", err)
+	}
+
+	couplet, err := s.getCouplet(dbCtx, &song, offset)
+	if err != nil {
+		s.lg.Warn("getcouplet by id error", zap.Error(err))
+		return domain.Couplet{}, fmt.Errorf("getcouplet error: %w", err)
+	}
+
+	return couplet, nil
+}
+
+func (s *SongUsecase) Search(ctx context.Context, query string, lang domain.SearchLanguage,
+	limit int, offset int) ([]domain.SongSearchResult, error) {
+	s.lg.Info("search songs", zap.String("query", query),
+		zap.String("lang", string(lang)))
+
+	if strings.TrimSpace(query) == "" {
+		s.lg.Warn("search error: bad query",
+			zap.Error(domain.ErrBadSearchQuery))
+		return nil, domain.ErrBadSearchQuery
+	}
+
+	if lang == "" {
+		lang = s.searchLanguage
+	}
+
+	if !lang.IsValid() {
+		s.lg.Warn("search error: bad language",
+			zap.Error(domain.ErrBadSearchLanguage))
+		return nil, domain.ErrBadSearchLanguage
+	}
+
+	if limit <= 0 {
+		s.lg.Warn("search error: bad limit",
+			zap.Error(domain.ErrBadLimit))
+		return nil, domain.ErrBadLimit
+	}
+
+	if offset < 1 {
+		s.lg.Warn("search error: bad offset",
+			zap.Error(domain.ErrBadOffset))
+		return nil, domain.ErrBadOffset
+	}
+
+	dbCtx, cancel := context.WithTimeout(ctx, s.dbTimeout)
+	defer cancel()
+
+	results, err := s.songRepo.Search(dbCtx, query, lang, limit, (offset-1)*limit)
+	if err != nil {
+		s.lg.Warn("search error", zap.Error(err))
+		return nil, fmt.Errorf("search error: %w", err)
+	}
+
+	s.lg.Info("successful search songs")
+	return results, nil
+}
+
+func (s *SongUsecase) GetSections(ctx context.Context, id int64) ([]domain.LyricsSection, error) {
+	s.lg.Info("get sections", zap.Int64("id", id))
+
+	if id <= 0 {
+		s.lg.Warn("get sections error: bad id",
+			zap.Error(domain.ErrBadID))
+		return nil, domain.ErrBadID
+	}
+
+	dbCtx, cancel := context.WithTimeout(ctx, s.dbTimeout)
+	defer cancel()
+
+	song, err := s.songRepo.GetByID(dbCtx, id)
+	if err != nil {
+		s.lg.Warn("get sections error", zap.Error(err))
+		return nil, fmt.Errorf("get sections error: %w", err)
+	}
+
+	s.lg.Info("successful get sections")
+	return songSections(&song), nil
+}
+
+func (s *SongUsecase) GetSection(ctx context.Context, id int64,
+	sectionType domain.SectionType, index int) (domain.LyricsSection, error) {
+	s.lg.Info("get section", zap.Int64("id", id),
+		zap.String("type", string(sectionType)), zap.Int("index", index))
+
+	if !sectionType.IsValid() {
+		s.lg.Warn("get section error: bad type",
+			zap.Error(domain.ErrBadSectionType))
+		return domain.LyricsSection{}, domain.ErrBadSectionType
+	}
+
+	if index < 1 {
+		s.lg.Warn("get section error: bad offset",
+			zap.Error(domain.ErrBadOffset))
+		return domain.LyricsSection{}, domain.ErrBadOffset
+	}
+
+	sections, err := s.GetSections(ctx, id)
+	if err != nil {
+		return domain.LyricsSection{}, err
+	}
+
+	section, ok := lyrics.Find(sections, sectionType, index)
+	if !ok {
+		s.lg.Warn("get section error", zap.Error(domain.ErrSectionNotFound))
+		return domain.LyricsSection{}, domain.ErrSectionNotFound
+	}
+
+	s.lg.Info("successful get section")
+	return section, nil
+}
+
+func (s *SongUsecase) ImportLRC(ctx context.Context, id int64, text string) ([]domain.SyncedLine, error) {
+	s.lg.Info("import lrc", zap.Int64("id", id))
+
+	if err := authorize(ctx, domain.PermUpdateSong); err != nil {
+		s.lg.Warn("import lrc error: forbidden", zap.Error(err))
+		return nil, err
+	}
+
+	if id <= 0 {
+		s.lg.Warn("import lrc error: bad id",
+			zap.Error(domain.ErrBadID))
+		return nil, domain.ErrBadID
+	}
+
+	lines, err := lrc.Parse(text)
+	if err != nil {
+		s.lg.Warn("import lrc error: bad lrc", zap.Error(err))
+		return nil, domain.ErrBadLRC
+	}
+
+	dbCtx, cancel := context.WithTimeout(ctx, s.dbTimeout)
+	defer cancel()
+
+	_, err = s.songRepo.GetByID(dbCtx, id)
+	if err != nil {
+		s.lg.Warn("import lrc error", zap.Error(err))
+		return nil, fmt.Errorf("import lrc error: %w", err)
+	}
+
+	err = s.songRepo.SetSyncedLyrics(dbCtx, id, lines)
+	if err != nil {
+		s.lg.Warn("import lrc error", zap.Error(err))
+		return nil, fmt.Errorf("import lrc error: %w", err)
+	}
+
+	s.lg.Info("successful import lrc")
+	return lines, nil
+}
+
+// syncedLyrics returns the synced lines of the song, failing when the
+// song has none.
+func (s *SongUsecase) syncedLyrics(ctx context.Context, id int64) (domain.Song, []domain.SyncedLine, error) {
+	song, err := s.songRepo.GetByID(ctx, id)
+	if err != nil {
+		return domain.Song{}, nil, err
+	}
+
+	lines, err := s.songRepo.GetSyncedLyrics(ctx, id)
+	if err != nil {
+		return domain.Song{}, nil, err
+	}
+
+	if len(lines) == 0 {
+		return domain.Song{}, nil, domain.ErrSyncedLyricsNotFound
+	}
+
+	return song, lines, nil
+}
+
+func (s *SongUsecase) ExportLRC(ctx context.Context, id int64) (string, error) {
+	s.lg.Info("export lrc", zap.Int64("id", id))
+
+	if id <= 0 {
+		s.lg.Warn("export lrc error: bad id",
+			zap.Error(domain.ErrBadID))
+		return "", domain.ErrBadID
+	}
+
+	dbCtx, cancel := context.WithTimeout(ctx, s.dbTimeout)
+	defer cancel()
+
+	song, lines, err := s.syncedLyrics(dbCtx, id)
+	if err != nil {
+		s.lg.Warn("export lrc error", zap.Error(err))
+		return "", fmt.Errorf("export lrc error: %w", err)
+	}
+
+	s.lg.Info("successful export lrc")
+	return lrc.Format(lines, lrc.Meta{Artist: song.Group, Title: song.Name}), nil
+}
+
+const maxNextLines = 50
+
+func (s *SongUsecase) GetLyricsAt(ctx context.Context, id int64, ms int, next int) (domain.LyricsAt, error) {
+	s.lg.Info("get lyrics at", zap.Int64("id", id), zap.Int("ms", ms))
+
+	if id <= 0 {
+		s.lg.Warn("get lyrics at error: bad id",
+			zap.Error(domain.ErrBadID))
+		return domain.LyricsAt{}, domain.ErrBadID
+	}
+
+	if ms < 0 {
+		s.lg.Warn("get lyrics at error: bad position",
+			zap.Error(domain.ErrBadPosition))
+		return domain.LyricsAt{}, domain.ErrBadPosition
+	}
+
+	if next < 0 || next > maxNextLines {
+		s.lg.Warn("get lyrics at error: bad limit",
+			zap.Error(domain.ErrBadLimit))
+		return domain.LyricsAt{}, domain.ErrBadLimit
+	}
+
+	dbCtx, cancel := context.WithTimeout(ctx, s.dbTimeout)
+	defer cancel()
+
+	_, lines, err := s.syncedLyrics(dbCtx, id)
+	if err != nil {
+		s.lg.Warn("get lyrics at error", zap.Error(err))
+		return domain.LyricsAt{}, fmt.Errorf("get lyrics at error: %w", err)
 	}
 
-	return couplets[offset-1], nil
+	s.lg.Info("successful get lyrics at")
+	return lrc.At(lines, ms, next), nil
 }
@@ -0,0 +1,176 @@
+package usecase
+
+import (
+	"context"
+	"github.com/NastyaAR/music_library/internal/domain"
+	"github.com/NastyaAR/music_library/internal/pkg/circuit_breaker"
+	"github.com/NastyaAR/music_library/internal/provider/song_info"
+	"github.com/NastyaAR/music_library/internal/provider/song_info/song_infotest"
+	"github.com/go-playground/validator/v10"
+	"go.uber.org/zap"
+	"testing"
+	"time"
+)
+
+// fakeSongRepo stores added songs and counts Count calls, the rest of
+// domain.SongRepo is not used by these tests.
+type fakeSongRepo struct {
+	domain.SongRepo
+	added  []domain.Song
+	counts int
+}
+
+func (f *fakeSongRepo) Add(ctx context.Context, song *domain.Song) (domain.Song, error) {
+	created := *song
+	created.ID = int64(len(f.added) + 1)
+	created.Version = 1
+	f.added = append(f.added, created)
+	return created, nil
+}
+
+func (f *fakeSongRepo) GetByCursor(ctx context.Context, filter *domain.SongFilter,
+	cursor *domain.SongCursor, limit int) ([]domain.Song, error) {
+	from := 0
+	if cursor != nil {
+		for from < len(f.added) && f.added[from].ID <= cursor.Boundary.ID {
+			from++
+		}
+	}
+	return f.added[from:min(from+limit, len(f.added))], nil
+}
+
+func (f *fakeSongRepo) Count(ctx context.Context, filter *domain.SongFilter) (int, error) {
+	f.counts++
+	return len(f.added), nil
+}
+
+func newEnrichingUsecase(t *testing.T) (*SongUsecase, *fakeSongRepo, *song_infotest.FakeServer) {
+	t.Helper()
+
+	server := song_infotest.NewFakeServer()
+	t.Cleanup(server.Close)
+
+	provider := song_info.NewHTTPSongInfoProvider(server.URL, time.Second, 1, time.Millisecond,
+		circuit_breaker.NewCircuitBreaker(5, time.Minute), zap.NewNop())
+	repo := &fakeSongRepo{}
+
+	return NewSongUsecase(repo, provider, domain.SearchLanguage("russian"), validator.New(),
+		zap.NewNop()), repo, server
+}
+
+func editorCtx() context.Context {
+	return domain.WithIdentity(context.Background(), domain.Identity{
+		UserID:   1,
+		Username: "editor",
+		Role:     domain.RoleEditor,
+	})
+}
+
+func TestCreateEnrichesMissingFields(t *testing.T) {
+	usecase, repo, server := newEnrichingUsecase(t)
+	released := time.Date(2006, 7, 16, 0, 0, 0, 0, time.UTC)
+	server.AddSong("Muse", "Supermassive Black Hole", domain.SongDetail{
+		ReleaseDate: released,
+		Text:        "Ooh baby, don't you know I suffer?",
+		Link:        "https://example.com/from-provider",
+	})
+
+	created, err := usecase.Create(editorCtx(), &domain.Song{
+		Group: "Muse",
+		Name:  "Supermassive Black Hole",
+		Link:  "https://example.com/from-client",
+	})
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+
+	if !created.ReleaseDate.Equal(released) {
+		t.Errorf("ReleaseDate = %v, want %v", created.ReleaseDate, released)
+	}
+	if created.Text != "Ooh baby, don't you know I suffer?" {
+		t.Errorf("Text = %q, want the provider text", created.Text)
+	}
+	if created.Link != "https://example.com/from-client" {
+		t.Errorf("Link = %q, the client value must win", created.Link)
+	}
+	if len(repo.added) != 1 {
+		t.Errorf("songs added = %d, want 1", len(repo.added))
+	}
+}
+
+func TestCreateSkipsProviderForCompleteSongs(t *testing.T) {
+	usecase, _, server := newEnrichingUsecase(t)
+
+	_, err := usecase.Create(editorCtx(), &domain.Song{
+		Group:       "Muse",
+		Name:        "Uprising",
+		ReleaseDate: time.Date(2009, 9, 7, 0, 0, 0, 0, time.UTC),
+		Text:        "Paranoia is in bloom",
+		Link:        "https://example.com/uprising",
+	})
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+
+	if server.Requests() != 0 {
+		t.Errorf("provider requests = %d, want 0", server.Requests())
+	}
+}
+
+func TestCreateWhileProviderIsDown(t *testing.T) {
+	usecase, repo, server := newEnrichingUsecase(t)
+	server.SetDown(true)
+
+	created, err := usecase.Create(editorCtx(), &domain.Song{
+		Group: "Muse",
+		Name:  "Uprising",
+		Text:  "Paranoia is in bloom",
+	})
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+
+	if created.Text != "Paranoia is in bloom" || !created.ReleaseDate.IsZero() || created.Link != "" {
+		t.Errorf("Create() = %+v, want only the client fields", created)
+	}
+	if len(repo.added) != 1 {
+		t.Errorf("songs added = %d, want 1", len(repo.added))
+	}
+	if server.Requests() == 0 {
+		t.Errorf("provider was not called")
+	}
+}
+
+func TestGetSongsCountsFirstPage(t *testing.T) {
+	usecase, repo, _ := newEnrichingUsecase(t)
+	for i := int64(1); i <= 3; i++ {
+		repo.added = append(repo.added, domain.Song{ID: i, Group: "Muse", Name: "Song"})
+	}
+
+	first, err := usecase.GetSongs(context.Background(), &domain.SongFilter{},
+		&domain.Pagination{Limit: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if first.Total == nil || *first.Total != 3 {
+		t.Fatalf("first page total = %v, want 3", first.Total)
+	}
+	if first.NextCursor == "" {
+		t.Fatal("first page has no next cursor")
+	}
+
+	next, err := usecase.GetSongs(context.Background(), &domain.SongFilter{},
+		&domain.Pagination{Limit: 2, Cursor: first.NextCursor})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if next.Total != nil {
+		t.Errorf("cursor page total = %d, want none", *next.Total)
+	}
+	if len(next.Songs) != 1 || next.HasMore {
+		t.Errorf("cursor page = %d songs, has more %v, want 1 and false", len(next.Songs), next.HasMore)
+	}
+	if repo.counts != 1 {
+		t.Errorf("Count called %d times, want 1", repo.counts)
+	}
+}
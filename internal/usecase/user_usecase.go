@@ -0,0 +1,341 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/NastyaAR/music_library/internal/domain"
+	"github.com/NastyaAR/music_library/internal/pkg/token"
+	"go.uber.org/zap"
+	"golang.org/x/crypto/bcrypt"
+	"regexp"
+	"time"
+)
+
+var usernamePattern = regexp.MustCompile(`^[a-zA-Z0-9._-]{3,32}$`)
+
+const (
+	minPasswordLen = 8
+	// bcrypt ignores bytes after the 72nd
+	maxPasswordLen = 72
+)
+
+// dummyHash is compared against when the user does not exist, so that
+// a login takes as long for unknown and known usernames.
+var dummyHash, _ = bcrypt.GenerateFromPassword([]byte("dummy password"), bcrypt.DefaultCost)
+
+type UserUsecase struct {
+	userRepo   domain.UserRepo
+	tokens     *token.Manager
+	refreshTTL time.Duration
+	lg         *zap.Logger
+	dbTimeout  time.Duration
+}
+
+// NewUserUsecase: registered users start as viewers, the admin is seeded
+// with SeedAdmin.
+func NewUserUsecase(userRepo domain.UserRepo, tokens *token.Manager, refreshTTL time.Duration,
+	lg *zap.Logger) *UserUsecase {
+	lg.With(zap.String("component", "user usecase"))
+	return &UserUsecase{
+		userRepo:   userRepo,
+		tokens:     tokens,
+		refreshTTL: refreshTTL,
+		lg:         lg,
+		dbTimeout:  time.Hour,
+	}
+}
+
+func validateCredentials(username string, password string) error {
+	if !usernamePattern.MatchString(username) {
+		return domain.ErrBadUsername
+	}
+
+	if len(password) < minPasswordLen || len(password) > maxPasswordLen {
+		return domain.ErrBadPassword
+	}
+
+	return nil
+}
+
+func (u *UserUsecase) Register(ctx context.Context, username string, password string) (domain.User, error) {
+	u.lg.Info("register user", zap.String("username", username))
+
+	err := validateCredentials(username, password)
+	if err != nil {
+		u.lg.Warn("register error: bad credentials", zap.Error(err))
+		return domain.User{}, err
+	}
+
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		u.lg.Warn("register error: hash", zap.Error(err))
+		return domain.User{}, domain.ErrInternalServer
+	}
+
+	dbCtx, cancel := context.WithTimeout(ctx, u.dbTimeout)
+	defer cancel()
+
+	user, err := u.userRepo.Add(dbCtx, &domain.User{Username: username, PasswordHash: string(hash),
+		Role: domain.RoleViewer})
+	if err != nil {
+		u.lg.Warn("register error", zap.Error(err))
+		return domain.User{}, fmt.Errorf("register error: %w", err)
+	}
+
+	u.lg.Info("successful register")
+	return user, nil
+}
+
+// SeedAdmin is run at startup with the admin from the config, the password
+// comes already hashed with bcrypt so that it is not kept in plain text.
+func (u *UserUsecase) SeedAdmin(ctx context.Context, username string, passwordHash string) (domain.User, error) {
+	u.lg.Info("seed admin", zap.String("username", username))
+
+	if !usernamePattern.MatchString(username) {
+		u.lg.Warn("seed admin error: bad username")
+		return domain.User{}, domain.ErrBadUsername
+	}
+
+	if _, err := bcrypt.Cost([]byte(passwordHash)); err != nil {
+		u.lg.Warn("seed admin error: bad password hash", zap.Error(err))
+		return domain.User{}, domain.ErrBadPasswordHash
+	}
+
+	dbCtx, cancel := context.WithTimeout(ctx, u.dbTimeout)
+	defer cancel()
+
+	user, err := u.userRepo.SeedAdmin(dbCtx, &domain.User{Username: username, PasswordHash: passwordHash,
+		Role: domain.RoleAdmin})
+	if err != nil {
+		u.lg.Warn("seed admin error", zap.Error(err))
+		return domain.User{}, fmt.Errorf("seed admin error: %w", err)
+	}
+
+	u.lg.Info("successful seeding admin")
+	return user, nil
+}
+
+// issue gives a new access token and stores a new refresh token.
+func (u *UserUsecase) issue(ctx context.Context, user domain.User) (domain.TokenPair, error) {
+	now := time.Now()
+
+	access, accessExpiresAt, err := u.tokens.Issue(domain.Identity{
+		UserID:   user.ID,
+		Username: user.Username,
+		Role:     user.Role,
+	}, now)
+	if err != nil {
+		return domain.TokenPair{}, err
+	}
+
+	refresh, refreshHash, err := token.NewOpaque()
+	if err != nil {
+		return domain.TokenPair{}, err
+	}
+
+	refreshExpiresAt := now.Add(u.refreshTTL)
+	err = u.userRepo.AddRefreshToken(ctx, user.ID, refreshHash, refreshExpiresAt)
+	if err != nil {
+		return domain.TokenPair{}, err
+	}
+
+	return domain.TokenPair{
+		AccessToken:      access,
+		AccessExpiresAt:  accessExpiresAt,
+		RefreshToken:     refresh,
+		RefreshExpiresAt: refreshExpiresAt,
+	}, nil
+}
+
+func (u *UserUsecase) Login(ctx context.Context, username string, password string) (domain.TokenPair, error) {
+	u.lg.Info("login", zap.String("username", username))
+
+	dbCtx, cancel := context.WithTimeout(ctx, u.dbTimeout)
+	defer cancel()
+
+	user, err := u.userRepo.GetByUsername(dbCtx, username)
+	if errors.Is(err, domain.ErrUserNotFound) {
+		_ = bcrypt.CompareHashAndPassword(dummyHash, []byte(password))
+		u.lg.Warn("login error: unknown user")
+		return domain.TokenPair{}, domain.ErrInvalidCredentials
+	}
+	if err != nil {
+		u.lg.Warn("login error", zap.Error(err))
+		return domain.TokenPair{}, fmt.Errorf("login error: %w", err)
+	}
+
+	err = bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte(password))
+	if err != nil {
+		u.lg.Warn("login error: wrong password", zap.Int64("user", user.ID))
+		return domain.TokenPair{}, domain.ErrInvalidCredentials
+	}
+
+	pair, err := u.issue(dbCtx, user)
+	if err != nil {
+		u.lg.Warn("login error: issue tokens", zap.Error(err))
+		return domain.TokenPair{}, fmt.Errorf("login error: %w", err)
+	}
+
+	u.lg.Info("successful login", zap.Int64("user", user.ID))
+	return pair, nil
+}
+
+// Refresh trades a refresh token for a new pair, the old refresh token
+// stops working.
+func (u *UserUsecase) Refresh(ctx context.Context, refreshToken string) (domain.TokenPair, error) {
+	u.lg.Info("refresh tokens")
+
+	if refreshToken == "" {
+		u.lg.Warn("refresh error: empty token")
+		return domain.TokenPair{}, domain.ErrInvalidToken
+	}
+
+	dbCtx, cancel := context.WithTimeout(ctx, u.dbTimeout)
+	defer cancel()
+
+	userID, err := u.userRepo.UseRefreshToken(dbCtx, token.Hash(refreshToken))
+	if err != nil {
+		u.lg.Warn("refresh error", zap.Error(err))
+		return domain.TokenPair{}, fmt.Errorf("refresh error: %w", err)
+	}
+
+	user, err := u.userRepo.GetByID(dbCtx, userID)
+	if err != nil {
+		u.lg.Warn("refresh error", zap.Error(err))
+		return domain.TokenPair{}, fmt.Errorf("refresh error: %w", err)
+	}
+
+	pair, err := u.issue(dbCtx, user)
+	if err != nil {
+		u.lg.Warn("refresh error: issue tokens", zap.Error(err))
+		return domain.TokenPair{}, fmt.Errorf("refresh error: %w", err)
+	}
+
+	u.lg.Info("successful refresh", zap.Int64("user", user.ID))
+	return pair, nil
+}
+
+func (u *UserUsecase) Logout(ctx context.Context, refreshToken string) error {
+	u.lg.Info("logout")
+
+	if refreshToken == "" {
+		u.lg.Warn("logout error: empty token")
+		return domain.ErrInvalidToken
+	}
+
+	dbCtx, cancel := context.WithTimeout(ctx, u.dbTimeout)
+	defer cancel()
+
+	err := u.userRepo.RevokeRefreshToken(dbCtx, token.Hash(refreshToken))
+	if err != nil {
+		u.lg.Warn("logout error", zap.Error(err))
+		return fmt.Errorf("logout error: %w", err)
+	}
+
+	u.lg.Info("successful logout")
+	return nil
+}
+
+// Authenticate checks an access token without touching the database, so
+// a new role works after the next refresh.
+func (u *UserUsecase) Authenticate(ctx context.Context, accessToken string) (domain.Identity, error) {
+	identity, err := u.tokens.Parse(accessToken)
+	if err != nil {
+		u.lg.Warn("authenticate error", zap.Error(err))
+		return domain.Identity{}, err
+	}
+
+	return identity, nil
+}
+
+func (u *UserUsecase) Get(ctx context.Context, id int64) (domain.User, error) {
+	u.lg.Info("get user", zap.Int64("id", id))
+
+	if id <= 0 {
+		u.lg.Warn("get user error: bad id", zap.Error(domain.ErrBadID))
+		return domain.User{}, domain.ErrBadID
+	}
+
+	dbCtx, cancel := context.WithTimeout(ctx, u.dbTimeout)
+	defer cancel()
+
+	user, err := u.userRepo.GetByID(dbCtx, id)
+	if err != nil {
+		u.lg.Warn("get user error", zap.Error(err))
+		return domain.User{}, fmt.Errorf("get user error: %w", err)
+	}
+
+	return user, nil
+}
+
+func (u *UserUsecase) GetAll(ctx context.Context, limit int, offset int) ([]domain.User, error) {
+	u.lg.Info("get users", zap.Int("limit", limit), zap.Int("offset", offset))
+
+	if err := authorize(ctx, domain.PermManageUsers); err != nil {
+		u.lg.Warn("get users error: forbidden", zap.Error(err))
+		return nil, err
+	}
+
+	if limit <= 0 {
+		u.lg.Warn("get users error: bad limit",
+			zap.Error(domain.ErrBadLimit))
+		return nil, domain.ErrBadLimit
+	}
+
+	if offset < 1 {
+		u.lg.Warn("get users error: bad offset",
+			zap.Error(domain.ErrBadOffset))
+		return nil, domain.ErrBadOffset
+	}
+
+	dbCtx, cancel := context.WithTimeout(ctx, u.dbTimeout)
+	defer cancel()
+
+	users, err := u.userRepo.GetAll(dbCtx, limit, (offset-1)*limit)
+	if err != nil {
+		u.lg.Warn("get users error", zap.Error(err))
+		return nil, fmt.Errorf("get users error: %w", err)
+	}
+
+	u.lg.Info("successful get users")
+	return users, nil
+}
+
+func (u *UserUsecase) SetRole(ctx context.Context, id int64, role domain.Role) (domain.User, error) {
+	u.lg.Info("set role", zap.Int64("id", id), zap.String("role", string(role)))
+
+	if err := authorize(ctx, domain.PermManageUsers); err != nil {
+		u.lg.Warn("set role error: forbidden", zap.Error(err))
+		return domain.User{}, err
+	}
+
+	if id <= 0 {
+		u.lg.Warn("set role error: bad id", zap.Error(domain.ErrBadID))
+		return domain.User{}, domain.ErrBadID
+	}
+
+	if !role.IsValid() {
+		u.lg.Warn("set role error: bad role", zap.Error(domain.ErrBadRole))
+		return domain.User{}, domain.ErrBadRole
+	}
+
+	// so that the last admin can not lock everybody out
+	identity, _ := domain.IdentityFrom(ctx)
+	if identity.UserID == id {
+		u.lg.Warn("set role error: own role", zap.Error(domain.ErrOwnRole))
+		return domain.User{}, domain.ErrOwnRole
+	}
+
+	dbCtx, cancel := context.WithTimeout(ctx, u.dbTimeout)
+	defer cancel()
+
+	user, err := u.userRepo.SetRole(dbCtx, id, role)
+	if err != nil {
+		u.lg.Warn("set role error", zap.Error(err))
+		return domain.User{}, fmt.Errorf("set role error: %w", err)
+	}
+
+	u.lg.Info("successful set role", zap.Int64("id", id))
+	return user, nil
+}
@@ -0,0 +1,98 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"github.com/NastyaAR/music_library/internal/domain"
+	"github.com/NastyaAR/music_library/internal/pkg/token"
+	"go.uber.org/zap"
+	"golang.org/x/crypto/bcrypt"
+	"testing"
+	"time"
+)
+
+// fakeUserRepo keeps users by name, the rest of domain.UserRepo is not
+// used by these tests.
+type fakeUserRepo struct {
+	domain.UserRepo
+	users map[string]domain.User
+}
+
+func (f *fakeUserRepo) Add(ctx context.Context, user *domain.User) (domain.User, error) {
+	if _, ok := f.users[user.Username]; ok {
+		return domain.User{}, domain.ErrUserExists
+	}
+	created := *user
+	created.ID = int64(len(f.users) + 1)
+	f.users[user.Username] = created
+	return created, nil
+}
+
+func (f *fakeUserRepo) SeedAdmin(ctx context.Context, user *domain.User) (domain.User, error) {
+	seeded, ok := f.users[user.Username]
+	if !ok {
+		seeded.ID = int64(len(f.users) + 1)
+		seeded.Username = user.Username
+	}
+	seeded.PasswordHash = user.PasswordHash
+	seeded.Role = user.Role
+	f.users[user.Username] = seeded
+	return seeded, nil
+}
+
+func newUserUsecase() (*UserUsecase, *fakeUserRepo) {
+	repo := &fakeUserRepo{users: make(map[string]domain.User)}
+	tokens := token.NewManager("secret", "music_library", time.Minute)
+	return NewUserUsecase(repo, tokens, time.Hour, zap.NewNop()), repo
+}
+
+func TestRegisterNeverGivesAdmin(t *testing.T) {
+	users, _ := newUserUsecase()
+
+	user, err := users.Register(context.Background(), "admin", "password123")
+	if err != nil {
+		t.Fatalf("Register() error = %v", err)
+	}
+
+	if user.Role != domain.RoleViewer {
+		t.Errorf("Role = %q, want %q", user.Role, domain.RoleViewer)
+	}
+}
+
+func TestSeedAdmin(t *testing.T) {
+	users, repo := newUserUsecase()
+	ctx := context.Background()
+
+	// somebody took the name first through the public endpoint
+	_, err := users.Register(ctx, "admin", "squatter123")
+	if err != nil {
+		t.Fatalf("Register() error = %v", err)
+	}
+
+	hash, err := bcrypt.GenerateFromPassword([]byte("admin-password"), bcrypt.MinCost)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	admin, err := users.SeedAdmin(ctx, "admin", string(hash))
+	if err != nil {
+		t.Fatalf("SeedAdmin() error = %v", err)
+	}
+
+	if admin.Role != domain.RoleAdmin || repo.users["admin"].PasswordHash != string(hash) {
+		t.Errorf("SeedAdmin() = %+v, want an admin with the config password", admin)
+	}
+}
+
+func TestSeedAdminBadHash(t *testing.T) {
+	users, repo := newUserUsecase()
+
+	_, err := users.SeedAdmin(context.Background(), "admin", "plain password")
+	if !errors.Is(err, domain.ErrBadPasswordHash) {
+		t.Fatalf("SeedAdmin() error = %v, want %v", err, domain.ErrBadPasswordHash)
+	}
+
+	if len(repo.users) != 0 {
+		t.Errorf("users = %v, want none", repo.users)
+	}
+}
@@ -0,0 +1,4 @@
+alter table songs drop constraint songs_group_name_key;
+alter table songs drop constraint songs_pkey;
+alter table songs drop column id;
+alter table songs add primary key (song_group, name);
@@ -0,0 +1,4 @@
+alter table songs add column id bigserial;
+alter table songs drop constraint songs_pkey;
+alter table songs add primary key (id);
+alter table songs add constraint songs_group_name_key unique (song_group, name);
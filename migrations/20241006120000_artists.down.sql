@@ -0,0 +1,3 @@
+drop index if exists songs_artist_id_idx;
+alter table songs drop column artist_id;
+drop table if exists artists;
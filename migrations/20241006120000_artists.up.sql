@@ -0,0 +1,17 @@
+create table if not exists artists (
+    id bigserial primary key,
+    name text not null unique,
+    country text not null default '',
+    formed_year integer,
+    description text not null default '',
+    aliases text[] not null default '{}'
+);
+
+insert into artists (name)
+select distinct song_group from songs
+where song_group is not null
+on conflict (name) do nothing;
+
+alter table songs add column artist_id bigint references artists(id);
+update songs set artist_id = a.id from artists a where a.name = songs.song_group;
+create index if not exists songs_artist_id_idx on songs (artist_id);
@@ -0,0 +1,5 @@
+alter table songs drop constraint songs_album_track_key;
+alter table songs drop column track_number;
+alter table songs drop column album_id;
+drop index if exists albums_artist_id_idx;
+drop table if exists albums;
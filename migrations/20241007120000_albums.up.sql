@@ -0,0 +1,12 @@
+create table if not exists albums (
+    id bigserial primary key,
+    title text not null,
+    artist_id bigint not null references artists(id),
+    release_date date,
+    cover_link text not null default ''
+);
+create index if not exists albums_artist_id_idx on albums (artist_id);
+
+alter table songs add column album_id bigint references albums(id) on delete set null;
+alter table songs add column track_number integer;
+alter table songs add constraint songs_album_track_key unique (album_id, track_number);
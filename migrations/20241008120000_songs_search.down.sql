@@ -0,0 +1,4 @@
+drop index if exists songs_search_russian_idx;
+drop index if exists songs_search_english_idx;
+alter table songs drop column search_russian;
+alter table songs drop column search_english;
@@ -0,0 +1,12 @@
+alter table songs add column search_english tsvector generated always as (
+    setweight(to_tsvector('english', coalesce(name, '')), 'A') ||
+    setweight(to_tsvector('english', coalesce(text, '')), 'B')
+) stored;
+
+alter table songs add column search_russian tsvector generated always as (
+    setweight(to_tsvector('russian', coalesce(name, '')), 'A') ||
+    setweight(to_tsvector('russian', coalesce(text, '')), 'B')
+) stored;
+
+create index if not exists songs_search_english_idx on songs using gin (search_english);
+create index if not exists songs_search_russian_idx on songs using gin (search_russian);
@@ -0,0 +1,2 @@
+drop index if exists songs_name_trgm_idx;
+drop index if exists songs_group_trgm_idx;
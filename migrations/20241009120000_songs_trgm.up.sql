@@ -0,0 +1,4 @@
+create extension if not exists pg_trgm;
+
+create index if not exists songs_group_trgm_idx on songs using gin (song_group gin_trgm_ops);
+create index if not exists songs_name_trgm_idx on songs using gin (name gin_trgm_ops);
@@ -0,0 +1 @@
+alter table songs add column sections jsonb;
@@ -0,0 +1,7 @@
+create table if not exists song_synced_lines (
+    song_id bigint not null references songs(id) on delete cascade,
+    position integer not null,
+    time_ms integer not null,
+    line text not null,
+    primary key (song_id, position)
+);
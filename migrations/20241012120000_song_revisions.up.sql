@@ -0,0 +1,22 @@
+create table if not exists song_revisions (
+    id bigserial primary key,
+    song_id bigint not null,
+    revision integer not null,
+    action text not null,
+    artist_id bigint,
+    song_group text,
+    name text,
+    release_date date,
+    text text,
+    link text,
+    album_id bigint,
+    track_number integer,
+    created_at timestamptz not null default now(),
+    unique (song_id, revision)
+);
+
+insert into song_revisions(song_id, revision, action, artist_id, song_group, name,
+    release_date, text, link, album_id, track_number)
+select id, 1, 'create', artist_id, song_group, name, release_date, text, link,
+    album_id, track_number
+from songs;
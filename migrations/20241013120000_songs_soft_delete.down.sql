@@ -0,0 +1,9 @@
+delete from songs where deleted_at is not null;
+
+drop index if exists songs_deleted_at_idx;
+drop index if exists songs_album_track_key;
+alter table songs add constraint songs_album_track_key unique (album_id, track_number);
+drop index if exists songs_group_name_key;
+alter table songs add constraint songs_group_name_key unique (song_group, name);
+
+alter table songs drop column deleted_at;
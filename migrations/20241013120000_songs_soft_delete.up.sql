@@ -0,0 +1,13 @@
+alter table songs add column deleted_at timestamptz;
+
+alter table songs drop constraint songs_group_name_key;
+create unique index if not exists songs_group_name_key on songs (song_group, name)
+    where deleted_at is null;
+
+-- a song in the trash does not keep its place on the album
+alter table songs drop constraint songs_album_track_key;
+create unique index if not exists songs_album_track_key on songs (album_id, track_number)
+    where deleted_at is null;
+
+create index if not exists songs_deleted_at_idx on songs (deleted_at)
+    where deleted_at is not null;
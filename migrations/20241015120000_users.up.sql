@@ -0,0 +1,17 @@
+create table if not exists users (
+    id bigserial primary key,
+    username text not null unique,
+    password_hash text not null,
+    created_at timestamptz not null default now()
+);
+
+create table if not exists refresh_tokens (
+    id bigserial primary key,
+    user_id bigint not null references users (id) on delete cascade,
+    token_hash text not null unique,
+    expires_at timestamptz not null,
+    revoked_at timestamptz,
+    created_at timestamptz not null default now()
+);
+
+create index if not exists refresh_tokens_user_id_idx on refresh_tokens (user_id);
@@ -0,0 +1,5 @@
+alter table users add column if not exists role text not null default 'viewer'
+    check (role in ('viewer', 'editor', 'moderator', 'admin'));
+
+-- every user could edit songs before roles existed
+update users set role = 'editor';
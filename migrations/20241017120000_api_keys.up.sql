@@ -0,0 +1,14 @@
+create table if not exists api_keys (
+    id bigserial primary key,
+    user_id bigint not null references users (id) on delete cascade,
+    name text not null,
+    prefix text not null,
+    key_hash text not null unique,
+    scopes text[] not null default '{}',
+    rate_limit integer not null,
+    created_at timestamptz not null default now(),
+    last_used_at timestamptz,
+    revoked_at timestamptz
+);
+
+create index if not exists api_keys_user_id_idx on api_keys (user_id);
@@ -0,0 +1,2 @@
+drop table if exists playlist_items;
+drop table if exists playlists;
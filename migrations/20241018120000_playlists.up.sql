@@ -0,0 +1,23 @@
+create table if not exists playlists (
+    id bigserial primary key,
+    user_id bigint not null references users (id) on delete cascade,
+    name text not null,
+    is_public boolean not null default false,
+    created_at timestamptz not null default now(),
+    updated_at timestamptz not null default now()
+);
+
+create index if not exists playlists_user_id_idx on playlists (user_id);
+
+-- positions go from 1 without gaps, the check is deferred so that moving
+-- an item can shift the others in one statement
+create table if not exists playlist_items (
+    id bigserial primary key,
+    playlist_id bigint not null references playlists (id) on delete cascade,
+    song_id bigint not null references songs (id) on delete cascade,
+    position integer not null check (position > 0),
+    added_at timestamptz not null default now(),
+    unique (playlist_id, position) deferrable initially deferred
+);
+
+create index if not exists playlist_items_song_id_idx on playlist_items (song_id);